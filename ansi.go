@@ -0,0 +1,202 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// stripANSI quita las secuencias de escape CSI (colores, cursor) de un string
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\033[") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
+			// Salta hasta la letra final de la secuencia
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// visibleLen devuelve el ancho en pantalla de un string, ignorando los colores
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(stripANSI(s))
+}
+
+// padRight completa con espacios hasta el ancho visible pedido
+func padRight(s string, width int) string {
+	if n := visibleLen(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// truncateVisible corta s para que ocupe como mucho width columnas,
+// terminando en ellipsis. Los colores se conservan y, si había alguno, se
+// cierra con reset para que no se siga pintando la línea de abajo
+func truncateVisible(s string, width int, ellipsis string) string {
+	if visibleLen(s) <= width {
+		return s
+	}
+	keep := width - utf8.RuneCountInString(ellipsis)
+	var b strings.Builder
+	colored := false
+	for i := 0; i < len(s); {
+		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
+			// Copia la secuencia entera
+			j := i + 2
+			for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+				j++
+			}
+			if j < len(s) {
+				j++
+			}
+			b.WriteString(s[i:j])
+			colored = true
+			i = j
+			continue
+		}
+		if keep <= 0 {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		keep--
+		i += size
+	}
+	b.WriteString(ellipsis)
+	if colored {
+		b.WriteString(ansiColors["reset"])
+	}
+	return b.String()
+}
+
+// wrapWords parte s en líneas de como mucho width columnas, cortando entre
+// palabras. Una palabra más larga que width se trunca
+func wrapWords(s string, width int, ellipsis string) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Split(s, " ") {
+		switch {
+		case line == "":
+			line = word
+		case visibleLen(line)+1+visibleLen(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, truncateVisible(line, width, ellipsis))
+			line = word
+		}
+	}
+	return append(lines, truncateVisible(line, width, ellipsis))
+}
+
+// ansiSpan es un pedazo de texto con un mismo estilo. fg es el color en
+// 0xRRGGBB, -1 para el color por defecto
+type ansiSpan struct {
+	text string
+	fg   int32
+	bold bool
+}
+
+// parseANSI separa s en pedazos según los colores SGR que trae (los de
+// colorCode, bar y los logos): reset, negrita, los 16 colores, la paleta
+// de 256 y los de 24 bits. Las demás secuencias se descartan
+func parseANSI(s string) []ansiSpan {
+	var spans []ansiSpan
+	cur := ansiSpan{fg: -1}
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			cur.text = text.String()
+			spans = append(spans, cur)
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\033' || i+1 >= len(s) || s[i+1] != '[' {
+			text.WriteByte(s[i])
+			continue
+		}
+		j := i + 2
+		for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+			j++
+		}
+		if j < len(s) && s[j] == 'm' {
+			flush()
+			cur = applySGR(cur, s[i+2:j])
+		}
+		i = j
+	}
+	flush()
+	return spans
+}
+
+// applySGR aplica los parámetros de una secuencia "\033[...m" al estilo
+func applySGR(st ansiSpan, params string) ansiSpan {
+	codes := strings.Split(params, ";")
+	for k := 0; k < len(codes); k++ {
+		n, _ := strconv.Atoi(codes[k])
+		switch {
+		case n == 0:
+			st = ansiSpan{fg: -1}
+		case n == 1:
+			st.bold = true
+		case n == 22:
+			st.bold = false
+		case n == 39:
+			st.fg = -1
+		case n >= 30 && n <= 37:
+			st.fg = palette256(n - 30)
+		case n >= 90 && n <= 97:
+			st.fg = palette256(n - 90 + 8)
+		case n == 38 && k+2 < len(codes) && codes[k+1] == "5":
+			c, _ := strconv.Atoi(codes[k+2])
+			st.fg = palette256(c)
+			k += 2
+		case n == 38 && k+4 < len(codes) && codes[k+1] == "2":
+			r, _ := strconv.Atoi(codes[k+2])
+			g, _ := strconv.Atoi(codes[k+3])
+			b, _ := strconv.Atoi(codes[k+4])
+			st.fg = int32(r&0xff)<<16 | int32(g&0xff)<<8 | int32(b&0xff)
+			k += 4
+		}
+	}
+	return st
+}
+
+// basicColors son los 16 primeros colores de la paleta, con los valores de xterm
+var basicColors = [16]int32{
+	0x000000, 0xcd0000, 0x00cd00, 0xcdcd00, 0x0000ee, 0xcd00cd, 0x00cdcd, 0xe5e5e5,
+	0x7f7f7f, 0xff0000, 0x00ff00, 0xffff00, 0x5c5cff, 0xff00ff, 0x00ffff, 0xffffff,
+}
+
+// palette256 devuelve el color n de la paleta de 256 en 0xRRGGBB: los 16
+// básicos, el cubo de 6x6x6 y la escala de grises (como en rgbTo256)
+func palette256(n int) int32 {
+	switch {
+	case n < 0 || n > 255:
+		return -1
+	case n < 16:
+		return basicColors[n]
+	case n < 232:
+		n -= 16
+		level := func(l int) int32 {
+			if l == 0 {
+				return 0
+			}
+			return int32(55 + l*40)
+		}
+		return level(n/36)<<16 | level(n/6%6)<<8 | level(n%6)
+	}
+	g := int32(8 + (n-232)*10)
+	return g<<16 | g<<8 | g
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// bar dibuja una barra de uso, ej. "[███░░░░░░░]". Es verde hasta BarWarn,
+// amarilla hasta BarCritical y roja desde ahí. Sin Unicode usa "#" y "-"
+func (cfg config) bar(percent float64) string {
+	filled := int(percent/100*float64(cfg.BarWidth) + 0.5)
+	if filled < 0 {
+		filled = 0
+	} else if filled > cfg.BarWidth {
+		filled = cfg.BarWidth
+	}
+
+	full, empty := "█", "░"
+	if !cfg.Unicode {
+		full, empty = "#", "-"
+	}
+
+	color := ""
+	if cfg.Color {
+		switch {
+		case percent >= float64(cfg.BarCritical):
+			color = ansiColors["red"]
+		case percent >= float64(cfg.BarWarn):
+			color = ansiColors["yellow"]
+		default:
+			color = ansiColors["green"]
+		}
+	}
+	return "[" + color + strings.Repeat(full, filled) + cfg.reset() + strings.Repeat(empty, cfg.BarWidth-filled) + "]"
+}
+
+// supportsUnicode adivina si la terminal puede mostrar caracteres de bloque
+// mirando el locale. En Windows solo se asume en Windows Terminal
+func supportsUnicode() bool {
+	if runtime.GOOS == "windows" {
+		return os.Getenv("WT_SESSION") != ""
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
@@ -1,270 +1,444 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
-	"os/exec"
 	"runtime"
-	"strconv"
+	"slices"
 	"strings"
-	"syscall"
 	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
 )
 
-// el type SystemInfo guarda toda la información del sistema
-type SystemInfo struct {
-	OS, Kernel, Arch, Host, User, Shell, Term, CPU, Uptime string
-	MemUsed, MemTotal, DiskUsed, DiskTotal                 int
+// options guarda las opciones recibidas por línea de comandos
+type options struct {
+	IP6         bool   // incluye las direcciones IPv6 globales
+	PublicIP    bool   // consulta la IP pública (hace una petición de red)
+	Refresh     int    // segundos entre redibujados, 0 desactiva el modo watch (--watch o --refresh)
+	JSON        bool   // imprime la info como JSON en vez del logo
+	PublicIPURL string // endpoint para la IP pública, reemplaza al del config
+	Weather     bool   // consulta el clima (hace una petición de red)
+	Modules     string // lista de módulos separada por comas, reemplaza la del config
+	NoLogo      bool   // oculta el logo
+	Logo        string // logo de la colección, "random" o "seasonal"
+	LogoFile    string // archivo con un logo ASCII propio, reemplaza al del config
+	LogoImage   string // PNG para usar de logo, reemplaza al del config
+	NoColor     bool   // imprime sin códigos ANSI, igual que Color "never"
+	Color       string // "auto", "always" o "never", reemplaza al del config
+	Theme       string // tema de colores, reemplaza al del config
+	Bars        bool   // agrega barras de uso a Mem y Disk
+	NoCache     bool   // recolecta todo sin usar la caché de datos estáticos
+	TUI         bool   // abre la vista interactiva
+	Remote      string // user@host cuya info se muestra en vez de la local (por ssh)
+	Since       string // snapshot contra el que se muestran los cambios
+	Format      string // plantilla de salida, reemplaza a la del config
+	LogoPos     string // "left" o "right", reemplaza al del config
+	Separator   string // separador entre etiqueta y valor, reemplaza al del config
+	Anonymize   bool   // oculta los datos personales de la salida
+	MemoryMode  string // cómo se cuenta la memoria usada, reemplaza al del config
+	Version     bool   // imprime la versión instalada y sale
+	Output      string // "text", "markdown" o "html"
+	Screenshot  string // archivo .png o .svg donde se dibuja la salida
+	Share       bool   // sube la salida anonimizada a share_url e imprime el link
+	Copy        bool   // copia la salida sin colores al portapapeles
+	Timings     bool   // muestra al final lo que tardó cada colector
+	Debug       bool   // registra en stderr cada archivo, comando y error
 }
 
 func main() {
-	info := getSystemInfo()
-	printInfo(info)
-}
-
-// la func getSystemInfo recolecta toda la información del sistema
-func getSystemInfo() SystemInfo {
-	info := SystemInfo{
-		OS:     getOS(),
-		Kernel: runCmd("uname", "-r"),
-		Arch:   runtime.GOARCH,
-		Host:   getEnvOrDefault("HOSTNAME", "N/A"),
-		User:   getEnvOrDefault("USER", "N/A"),
-		Shell:  getEnvOrDefault("SHELL", "N/A"),
-		Term:   getEnvOrDefault("TERM", "N/A"),
-		CPU:    getCPU(),
-		Uptime: getUptime(),
+	// Subcomandos
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "snapshot":
+			runSnapshot(os.Args[2:])
+			return
+		case "diff":
+			runDiff(os.Args[2:])
+			return
+		case "history":
+			runHistory(os.Args[2:])
+			return
+		case "update":
+			runUpdate(os.Args[2:])
+			return
+		case "completion":
+			runCompletion(os.Args[2:])
+			return
+		case "migrate":
+			runMigrate(os.Args[2:])
+			return
+		case "get":
+			runGet(os.Args[2:])
+			return
+		}
+	}
+	opts := parseFlags()
+	if opts.Version {
+		fmt.Println(currentBuild())
+		return
+	}
+	if opts.Debug {
+		debugLog = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		debugLog.Debug("start", "version", currentBuild().Version, "os", runtime.GOOS, "arch", runtime.GOARCH)
 	}
 
-	// Memoria
-	info.MemTotal, info.MemUsed = getMemory()
+	// Los plugins se registran antes de leer el config para poder ubicarlos en modules
+	registerPlugins(pluginDir())
 
-	// Disco
-	info.DiskTotal, info.DiskUsed = getDisk("/")
+	// Sin config se usan los valores por defecto; si está roto se avisa pero se sigue
+	cfg := userConfig()
+	if err := cfg.applyOptions(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(2)
+	}
+	if opts.Remote != "" {
+		dropCustomModules()
+	}
 
-	return info
-}
+	sizeUnits = cfg.sizeUnits()
+	uptimeFormat = cfg.UptimeFormat
 
-// runCmd ejecuta un comando y devuelve su salida
-func runCmd(name string, args ...string) string {
-	out, err := exec.Command(name, args...).Output()
-	if err != nil {
-		return "N/A"
+	// Si el logo propio no se puede leer se avisa y se usa la taza
+	if err := cfg.loadLogo(); err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: logo:", err)
 	}
-	return strings.TrimSpace(string(out))
-}
 
-// getEnvOrDefault obtiene una variable de entorno o devuelve un valor por defecto
-func getEnvOrDefault(key, defaultVal string) string {
-	if val := os.Getenv(key); val != "" {
-		return val
+	// La imagen solo se dibuja una vez: en modo watch o si la salida no es
+	// una terminal se queda el logo de texto
+	if opts.Refresh == 0 && !opts.JSON && !opts.TUI && opts.Output == "text" && opts.Screenshot == "" && !opts.Share && isTerminal(os.Stdout) {
+		if err := cfg.loadImage(); err != nil {
+			fmt.Fprintln(os.Stderr, "cafetch: logo image:", err)
+		}
 	}
-	return defaultVal
-}
 
-// getOS obtiene el nombre del sistema operativo
-func getOS() string {
-	// Intenta leer /etc/os-release primero
-	file, err := os.Open("/etc/os-release")
-	if err != nil {
-		return runtime.GOOS
+	// Colores solo si corresponde (terminal, NO_COLOR...). En consolas de
+	// Windows sin soporte ANSI se imprime sin colores
+	cfg.Color = useColor(cfg.ColorMode) && enableANSI()
+	cfg.TrueColor = supportsTruecolor()
+	cfg.Unicode = supportsUnicode()
+	cfg.TUI = opts.TUI
+
+	// Solo corren los colectores de lo que se muestra; --json, --share y
+	// --since usan todos los datos
+	collectOpts := cfg.shownOptions()
+	if opts.JSON || opts.Share || opts.Since != "" {
+		collectOpts = cfg.collectOptions()
 	}
-	defer file.Close()
-
-	// Busca la línea PRETTY_NAME
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "PRETTY_NAME=") {
-			return strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), `"`)
-		}
+	if opts.Debug {
+		collectOpts.Logger = debugLog
+	}
+	var report *timingReport
+	if opts.Timings {
+		report = newTimingReport()
+		report.timeCustomModules()
+		collectOpts.Timings = report.add
+		// El reporte va a stderr al final, después de la salida
+		defer report.print(os.Stderr)
 	}
-	return runtime.GOOS
-}
 
-// getCPU obtiene el modelo de CPU
-func getCPU() string {
-	file, err := os.Open("/proc/cpuinfo")
+	var info *sysinfo.SystemInfo
+	var err error
+	start := time.Now()
+	if opts.Remote != "" {
+		info, err = fetchRemote(opts.Remote)
+	} else {
+		info, err = sysinfo.Collect(context.Background(), collectOpts)
+	}
+	if report != nil {
+		report.collect = time.Since(start)
+	}
 	if err != nil {
-		return "N/A"
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
 	}
-	defer file.Close()
-
-	// Busca la línea "model name"
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "model name") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				return strings.TrimSpace(parts[1])
-			}
+	if cfg.History && opts.Remote == "" {
+		appendHistory(historyPath(), cfg.HistoryMaxKB, *info)
+	}
+	// Se oculta antes de elegir la salida, así vale para todos los formatos
+	if cfg.Anonymize {
+		cfg.Redactor = newRedactor(*info)
+		anonymize(info, cfg.Redactor)
+	}
+	// --copy copia lo mismo que se muestra, sin volver a armarlo: así los
+	// plugins y los comandos custom corren una sola vez
+	if opts.Share {
+		cfg.Color = false
+		text := shareText(*info, cfg, opts)
+		if opts.Copy {
+			copyOutput(text)
 		}
+		link, err := shareOutput(cfg.ShareURL, text)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "cafetch: share:", err)
+			os.Exit(1)
+		}
+		fmt.Println(link)
+		return
 	}
-	return "N/A"
-}
-
-// getUptime calcula el tiempo que lleva encendido el sistema
-func getUptime() string {
-	data, err := os.ReadFile("/proc/uptime")
-	if err != nil {
-		return "N/A"
+	if opts.JSON || opts.Output != "text" {
+		text := shareText(*info, cfg, opts)
+		if opts.Copy {
+			copyOutput(text)
+		}
+		fmt.Print(text)
+		return
 	}
-
-	// Parsea los segundos desde /proc/uptime
-	fields := strings.Fields(string(data))
-	if len(fields) == 0 {
-		return "N/A"
+	if opts.Screenshot != "" {
+		// La imagen siempre va con colores de 24 bits y caracteres de bloque
+		cfg.Color, cfg.TrueColor, cfg.Unicode = true, true, true
+		lines := renderInfo(*info, cfg)
+		if opts.Copy {
+			copyLines(lines)
+		}
+		if err := writeScreenshot(opts.Screenshot, lines); err != nil {
+			fmt.Fprintln(os.Stderr, "cafetch: screenshot:", err)
+			os.Exit(1)
+		}
+		return
 	}
-	seconds, err := strconv.ParseFloat(fields[0], 64)
-	if err != nil {
-		return "N/A"
+	if opts.Since != "" {
+		old, err := loadSnapshot(opts.Since)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "cafetch:", err)
+			os.Exit(1)
+		}
+		printDiff(diffInfo(*old, *info), cfg)
+		return
 	}
+	if opts.TUI {
+		interval := time.Duration(opts.Refresh) * time.Second
+		if interval == 0 {
+			interval = defaultWatchInterval * time.Second
+		}
+		if err := runTUI(*info, cfg, interval); err != nil {
+			fmt.Fprintln(os.Stderr, "cafetch:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if opts.Refresh > 0 {
+		runWatch(*info, cfg, time.Duration(opts.Refresh)*time.Second)
+		return
+	}
+	lines := printInfo(*info, cfg)
+	if opts.Copy {
+		copyLines(lines)
+	}
+}
 
-	// Convierte a días, horas y minutos
-	s := int(seconds)
-	days := s / 86400
-	hours := (s % 86400) / 3600
-	minutes := (s % 3600) / 60
+// copyOutput pone text en el portapapeles; si no se puede solo avisa
+func copyOutput(text string) {
+	if err := copyToClipboard(text); err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: copy:", err)
+	}
+}
 
-	if days > 0 {
-		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+// copyLines copia las líneas ya armadas sin los colores
+func copyLines(lines []string) {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(stripANSI(line), " ") + "\n")
 	}
-	return fmt.Sprintf("%dh %dm", hours, minutes)
+	copyOutput(b.String())
 }
 
-// getMemory obtiene la memoria total y usada en MB
-func getMemory() (total, used int) {
-	file, err := os.Open("/proc/meminfo")
+// parseFlags lee los flags de la línea de comandos
+func parseFlags() options {
+	var opts options
+	defineFlags(flag.CommandLine, &opts)
+	args, err := watchArgs(os.Args[1:])
 	if err != nil {
-		return 0, 0
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(2)
 	}
-	defer file.Close()
-
-	var memTotal, memAvail int
+	flag.CommandLine.Parse(args)
 
-	// Lee las líneas de /proc/meminfo
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			continue
-		}
-
-		// Extrae los valores en kilobytes
-		val, _ := strconv.Atoi(fields[1])
+	if opts.Refresh < 0 {
+		fmt.Fprintln(os.Stderr, "cafetch: --refresh must be a positive number of seconds")
+		os.Exit(2)
+	}
+	if !slices.Contains(outputFormats, opts.Output) {
+		fmt.Fprintf(os.Stderr, "cafetch: --output: unknown format %q (use %s)\n", opts.Output, strings.Join(outputFormats, ", "))
+		os.Exit(2)
+	}
+	if opts.Remote != "" && (opts.Refresh > 0 || opts.TUI) {
+		fmt.Fprintln(os.Stderr, "cafetch: --remote can't be combined with --watch or --tui")
+		os.Exit(2)
+	}
+	if opts.Share && (opts.Refresh > 0 || opts.TUI || opts.Since != "" || opts.Screenshot != "") {
+		fmt.Fprintln(os.Stderr, "cafetch: --share can't be combined with --watch, --tui, --since or --screenshot")
+		os.Exit(2)
+	}
+	if opts.Timings && (opts.Refresh > 0 || opts.TUI || opts.Remote != "") {
+		fmt.Fprintln(os.Stderr, "cafetch: --timings can't be combined with --watch, --tui or --remote")
+		os.Exit(2)
+	}
+	if opts.Copy && (opts.Refresh > 0 || opts.TUI || opts.Since != "") {
+		fmt.Fprintln(os.Stderr, "cafetch: --copy can't be combined with --watch, --tui or --since")
+		os.Exit(2)
+	}
+	return opts
+}
 
-		if strings.HasPrefix(line, "MemTotal:") {
-			memTotal = val
-		}
-		if strings.HasPrefix(line, "MemAvailable:") {
-			memAvail = val
-		}
+// defineFlags declara los flags de la salida normal en fs. Está aparte para
+// que cafetch completion pueda listarlos
+func defineFlags(fs *flag.FlagSet, opts *options) {
+	fs.BoolVar(&opts.IP6, "ip6", false, "include global IPv6 addresses")
+	fs.BoolVar(&opts.PublicIP, "public-ip", false, "look up the public IP (makes a network request)")
+	fs.StringVar(&opts.PublicIPURL, "public-ip-url", "", "HTTPS `url` used by --public-ip")
+	fs.BoolVar(&opts.Weather, "weather", false, "show the current weather (makes a network request, cached for 30 minutes)")
+	fs.BoolVar(&opts.JSON, "json", false, "print the collected info as JSON")
+	fs.IntVar(&opts.Refresh, "refresh", 0, "redraw the output every `seconds` until interrupted")
+	fs.Var(watchFlag{&opts.Refresh}, "watch", "redraw the output in place every 2 seconds (or --watch=N) until interrupted")
+	fs.BoolVar(&opts.TUI, "tui", false, "open an interactive view with expandable modules (q to quit)")
+	fs.StringVar(&opts.Remote, "remote", "", "show the info of another machine over ssh (`user@host`)")
+	fs.StringVar(&opts.Since, "since", "", "show what changed since a snapshot `file` (see cafetch snapshot)")
+	fs.StringVar(&opts.Format, "format", "", "print a `template` like \"{os} | {mem.used}/{mem.total}\" instead of the module lines")
+	fs.StringVar(&opts.Modules, "modules", "", "comma-separated `list` of modules to show, in order")
+	fs.BoolVar(&opts.NoLogo, "no-logo", false, "hide the logo")
+	fs.StringVar(&opts.Logo, "logo", "", "`logo` to draw: cup, espresso, mug, iced, festive, latte, random or seasonal")
+	fs.StringVar(&opts.LogoPos, "logo-position", "", "put the logo on the `side` left or right of the info")
+	fs.StringVar(&opts.Separator, "separator", "", "`text` between each label and its value (default \":\")")
+	fs.StringVar(&opts.LogoImage, "logo-image", "", "draw a PNG `file` as the logo (kitty, iTerm2 or sixel terminals)")
+	fs.StringVar(&opts.LogoFile, "logo-file", "", "read the logo from a text `file` (ANSI colors allowed)")
+	fs.BoolVar(&opts.NoColor, "no-color", false, "disable colors (same as --color=never)")
+	fs.StringVar(&opts.Color, "color", "", "use colors: `auto` (only on a terminal, honors NO_COLOR), always or never")
+	fs.BoolVar(&opts.Anonymize, "anonymize", false, "mask the username, hostname, IP and MAC addresses (to share the output)")
+	fs.BoolVar(&opts.NoCache, "no-cache", false, "ignore the cache of static hardware info")
+	fs.StringVar(&opts.MemoryMode, "memory-mode", "", "count used memory like `tool`: available (default), free or htop")
+	fs.BoolVar(&opts.Bars, "bars", false, "show usage bars next to Mem and Disk")
+	fs.StringVar(&opts.Theme, "theme", "", "color `theme`: default, nord, gruvbox, dracula or mono")
+	fs.StringVar(&opts.Output, "output", "text", "output `format`: text, markdown or html (to paste into wikis and issues)")
+	fs.StringVar(&opts.Screenshot, "screenshot", "", "draw the colored output (logo and info) to a .png or .svg `file`")
+	fs.BoolVar(&opts.Share, "share", false, "upload the output (anonymized) to a paste service and print the link")
+	fs.BoolVar(&opts.Copy, "copy", false, "also copy the output without colors to the clipboard")
+	fs.BoolVar(&opts.Timings, "timings", false, "after the output, show how long each collector, plugin and custom command took")
+	fs.BoolVar(&opts.Debug, "debug", false, "log every file read, command run and parse error to stderr")
+	fs.BoolVar(&opts.Version, "version", false, "print the version, commit, build date and Go version")
+}
 
-		// Si esta el valor de MemTotal y MemAvailable ya no es necesario seguir leyendo
-		if memTotal > 0 && memAvail > 0 {
-			break
+// printInfo imprime toda la información con formato bonito y devuelve las
+// líneas de texto que imprimió (con un logo de imagen, solo las de la info)
+func printInfo(info sysinfo.SystemInfo, cfg config) []string {
+	if cfg.Logo && cfg.Image != nil {
+		if cols := terminalColumns(); cols > 0 {
+			cfg.InfoWidth = cols - cfg.Margin - cfg.Image.cols - cfg.Padding
 		}
+		lines := buildLines(info, cfg)
+		printImageInfo(cfg.Image, lines, cfg.Margin, cfg.Padding)
+		return lines
 	}
-
-	// Convierte KB a MB
-	total = memTotal / 1024
-	used = total - (memAvail / 1024)
-	return
+	lines := renderInfo(info, cfg)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return lines
 }
 
-// getDisk obtiene el espacio total y usado del disco en GB
-func getDisk(path string) (total, used int) {
-	var stat syscall.Statfs_t
-	if err := syscall.Statfs(path, &stat); err != nil {
-		return 0, 0
-	}
+// minInfoWidth es el ancho mínimo de la info al lado del logo; en una
+// terminal más angosta el logo va arriba
+const minInfoWidth = 30
 
-	// Calcula el espacio total y libre
-	totalBytes := stat.Blocks * uint64(stat.Bsize)
-	freeBytes := stat.Bavail * uint64(stat.Bsize)
-	usedBytes := totalBytes - freeBytes
+// minValueWidth es lo mínimo que se deja para un valor al cortarlo
+const minValueWidth = 8
 
-	// Convierte a GB
-	gb := float64(1024 * 1024 * 1024)
-	total = int(float64(totalBytes) / gb)
-	used = int(float64(usedBytes) / gb)
-	return
+// terminalColumns devuelve el ancho de la terminal, 0 si la salida no es
+// una terminal (ahí no se corta nada)
+func terminalColumns() int {
+	if !isTerminal(os.Stdout) {
+		return 0
+	}
+	ws, ok := termWinsize()
+	if !ok {
+		return 0
+	}
+	return int(ws.Col)
 }
 
-// printInfo imprime toda la información con formato bonito
-func printInfo(info SystemInfo) {
-	// Colores ANSI
-	c := map[string]string{
-		"reset":   "\033[0m",
-		"bold":    "\033[1m",
-		"cyan":    "\033[36m",
-		"magenta": "\033[35m",
-		"yellow":  "\033[33m",
-		"green":   "\033[32m",
+// renderInfo arma las líneas de salida con el logo de texto a un costado.
+// Los valores largos se ajustan al ancho de la terminal
+func renderInfo(info sysinfo.SystemInfo, cfg config) []string {
+	cols := terminalColumns()
+	if !cfg.Logo {
+		cfg.InfoWidth = cols
+		return buildLines(info, cfg)
 	}
 
-	// Logo en formato ASCII de una taza de cafe :D
-	logo := []string{
-		c["cyan"] + "     ( (  " + c["reset"],
-		c["cyan"] + "      ) ) " + c["reset"],
-		c["yellow"] + "  ........ " + c["reset"],
-		c["yellow"] + "  |      |]" + c["reset"],
-		c["yellow"] + "  |      | " + c["reset"],
-		c["yellow"] + "   ======  " + c["reset"],
+	// Logo propio del config o la taza de cafe :D
+	logo := cfg.logoLines()
+	if cfg.LogoLines != nil && !cfg.Color {
+		// Sin colores se quitan los que traiga el archivo
+		plain := make([]string, len(logo))
+		for i, line := range logo {
+			plain[i] = stripANSI(line)
+		}
+		logo = plain
 	}
+	margin, padding := strings.Repeat(" ", cfg.Margin), strings.Repeat(" ", cfg.Padding)
 
-	// Calcula porcentajes
-	memPercent := 0.0
-	if info.MemTotal > 0 {
-		memPercent = float64(info.MemUsed) / float64(info.MemTotal) * 100
+	// En una terminal angosta el logo va arriba y la info abajo
+	if cols > 0 {
+		cfg.InfoWidth = cols - cfg.Margin - logoWidth(logo) - cfg.Padding
 	}
-	diskPercent := 0.0
-	if info.DiskTotal > 0 {
-		diskPercent = float64(info.DiskUsed) / float64(info.DiskTotal) * 100
+	if cols > 0 && cfg.InfoWidth < minInfoWidth {
+		cfg.InfoWidth = cols - cfg.Margin
+		var lines []string
+		for _, line := range logo {
+			lines = append(lines, strings.TrimRight(margin+line, " "))
+		}
+		lines = append(lines, "")
+		for _, line := range buildLines(info, cfg) {
+			lines = append(lines, margin+line)
+		}
+		return lines
 	}
 
-	// Información del sistema
-	data := []string{
-		c["bold"] + info.User + "@" + info.Host + c["reset"],
-		c["cyan"] + "cafetch" + c["reset"] + " (Go " + runtime.Version() + ")",
-		"",
-		c["yellow"] + "OS:     " + c["reset"] + info.OS,
-		c["yellow"] + "Kernel: " + c["reset"] + info.Kernel,
-		c["yellow"] + "Arch:   " + c["reset"] + info.Arch,
-		c["yellow"] + "Uptime: " + c["reset"] + info.Uptime,
-		"",
-		c["green"] + "CPU:  " + c["reset"] + info.CPU,
-		fmt.Sprintf(c["green"]+"Mem:  "+c["reset"]+"%dMB / %dMB (%.1f%%)", info.MemUsed, info.MemTotal, memPercent),
-		fmt.Sprintf(c["green"]+"Disk: "+c["reset"]+"%dGB / %dGB (%.1f%%)", info.DiskUsed, info.DiskTotal, diskPercent),
-		"",
-		c["magenta"] + "Shell: " + c["reset"] + info.Shell,
-		c["magenta"] + "Term:  " + c["reset"] + info.Term,
-		c["magenta"] + "Time:  " + c["reset"] + time.Now().Format("2006-01-02 15:04:05"),
+	// Logo e info lado a lado; con el logo a la derecha se intercambian
+	data := buildLines(info, cfg)
+	left, right := logo, data
+	if cfg.LogoPosition == "right" {
+		left, right = data, logo
 	}
-
-	// Imprime logo e info lado a lado
-	maxLines := len(logo)
-	if len(data) > maxLines {
-		maxLines = len(data)
+	width := logoWidth(left)
+	maxLines := len(left)
+	if len(right) > maxLines {
+		maxLines = len(right)
 	}
 
+	lines := make([]string, 0, maxLines)
 	for i := 0; i < maxLines; i++ {
-		// Obtiene línea del logo
-		logoLine := ""
-		if i < len(logo) {
-			logoLine = logo[i]
+		leftLine, rightLine := "", ""
+		if i < len(left) {
+			leftLine = left[i]
 		}
-
-		// Obtiene línea de datos
-		dataLine := ""
-		if i < len(data) {
-			dataLine = data[i]
+		if i < len(right) {
+			rightLine = right[i]
 		}
 
-		// Imprime las 2 con espaciado
-		fmt.Printf("  %-20s  %s\n", logoLine, dataLine)
+		// Junta las 2 con espaciado (el ancho se mide sin los colores)
+		line := margin + padRight(leftLine, width) + padding + rightLine
+		lines = append(lines, strings.TrimRight(line, " "))
 	}
+	return lines
+}
+
+// printJSON imprime la info como JSON indentado para usar desde scripts.
+// La versión de cafetch va aparte en "cafetch", así el JSON se sigue
+// pudiendo leer como un SystemInfo (--remote, --since)
+func printJSON(w io.Writer, info sysinfo.SystemInfo) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		sysinfo.SystemInfo
+		Cafetch buildInfo `json:"cafetch"`
+	}{info, currentBuild()})
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardTools son los comandos que copian lo que reciben por stdin, en
+// el orden en que se prueban. when dice si tiene sentido en esta sesión
+var clipboardTools = []struct {
+	name string
+	args []string
+	when func() bool
+}{
+	{"wl-copy", nil, func() bool { return os.Getenv("WAYLAND_DISPLAY") != "" }},
+	{"xclip", []string{"-selection", "clipboard"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+	{"xsel", []string{"--clipboard", "--input"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+	{"pbcopy", nil, func() bool { return runtime.GOOS == "darwin" }},
+	{"clip", nil, func() bool { return runtime.GOOS == "windows" }},
+}
+
+// copyToClipboard pone text en el portapapeles. Si hay una terminal se
+// manda con OSC 52, que también anda por ssh pero no todas las terminales
+// lo soportan (ni avisan si no), así que además se usa la herramienta del
+// sistema si hay una. Solo falla si no se pudo de ninguna forma
+func copyToClipboard(text string) error {
+	copied := false
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		if isTerminal(f) {
+			fmt.Fprint(f, osc52(text))
+			copied = true
+			break
+		}
+	}
+	// Por ssh el portapapeles de la máquina remota no le sirve a nadie
+	if os.Getenv("SSH_CONNECTION") != "" {
+		if !copied {
+			return errors.New("no terminal to send the text to over ssh")
+		}
+		return nil
+	}
+	for _, tool := range clipboardTools {
+		if !tool.when() {
+			continue
+		}
+		if _, err := exec.LookPath(tool.name); err != nil {
+			continue
+		}
+		cmd := exec.Command(tool.name, tool.args...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return nil
+		}
+	}
+	if !copied {
+		return errors.New("no terminal for OSC 52 and no clipboard tool found (wl-copy, xclip, xsel, pbcopy)")
+	}
+	return nil
+}
+
+// osc52 arma la secuencia que le pide a la terminal que copie text. Dentro
+// de screen hay que envolverla para que llegue a la terminal de afuera;
+// tmux la reenvía si tiene set-clipboard on
+func osc52(text string) string {
+	seq := "\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if strings.HasPrefix(os.Getenv("TERM"), "screen") && os.Getenv("TMUX") == "" {
+		seq = "\033P" + seq + "\033\\"
+	}
+	return seq
+}
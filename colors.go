@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ansiColors son los colores con nombre que se pueden usar en el config y
+// en los temas
+var ansiColors = map[string]string{
+	"reset":   "\033[0m",
+	"bold":    "\033[1m",
+	"black":   "\033[30m",
+	"red":     "\033[31m",
+	"green":   "\033[32m",
+	"yellow":  "\033[33m",
+	"blue":    "\033[34m",
+	"magenta": "\033[35m",
+	"cyan":    "\033[36m",
+	"white":   "\033[37m",
+}
+
+// colorCode convierte un color en su secuencia ANSI. Puede ser un nombre
+// ("cyan"), un número de la paleta de 256 colores ("208"), un color de 24
+// bits ("#88c0d0") o varios separados por espacios ("bold cyan"). Sin
+// truecolor los de 24 bits se aproximan con la paleta de 256. ok es false si
+// no se reconoce
+func colorCode(spec string, truecolor bool) (code string, ok bool) {
+	for _, part := range strings.Fields(spec) {
+		if c, found := ansiColors[part]; found {
+			code += c
+			continue
+		}
+		if hex, isHex := strings.CutPrefix(part, "#"); isHex {
+			rgb, err := strconv.ParseUint(hex, 16, 32)
+			if err != nil || len(hex) != 6 {
+				return "", false
+			}
+			r, g, b := uint8(rgb>>16), uint8(rgb>>8), uint8(rgb)
+			if truecolor {
+				code += fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+			} else {
+				code += fmt.Sprintf("\033[38;5;%dm", rgbTo256(r, g, b))
+			}
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || n > 255 {
+			return "", false
+		}
+		code += "\033[38;5;" + part + "m"
+	}
+	return code, true
+}
+
+// rgbTo256 devuelve el color más cercano de la paleta de 256: del cubo de
+// 6x6x6 (16-231) o de la escala de grises (232-255)
+func rgbTo256(r, g, b uint8) int {
+	// Niveles del cubo: 0, 95, 135, 175, 215, 255
+	level := func(v uint8) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (int(v) - 35) / 40
+	}
+	value := func(l int) int {
+		if l == 0 {
+			return 0
+		}
+		return 55 + l*40
+	}
+	dist := func(r2, g2, b2 int) int {
+		dr, dg, db := int(r)-r2, int(g)-g2, int(b)-b2
+		return dr*dr + dg*dg + db*db
+	}
+
+	lr, lg, lb := level(r), level(g), level(b)
+	cube := 16 + 36*lr + 6*lg + lb
+	cubeDist := dist(value(lr), value(lg), value(lb))
+
+	// Gris: 24 niveles de 8 a 238
+	avg := (int(r) + int(g) + int(b)) / 3
+	gray := (avg - 3) / 10
+	if gray < 0 {
+		gray = 0
+	} else if gray > 23 {
+		gray = 23
+	}
+	v := 8 + gray*10
+	if dist(v, v, v) < cubeDist {
+		return 232 + gray
+	}
+	return cube
+}
+
+// supportsTruecolor indica si la terminal entiende colores de 24 bits.
+// COLORTERM es la convención; Windows Terminal no la define pero los soporta
+func supportsTruecolor() bool {
+	ct := os.Getenv("COLORTERM")
+	return ct == "truecolor" || ct == "24bit" || os.Getenv("WT_SESSION") != ""
+}
+
+// useColor decide si se imprimen colores según el modo: "always", "never" o
+// "auto", que los apaga si la salida no es una terminal, si TERM=dumb o si
+// está definida NO_COLOR (https://no-color.org)
+func useColor(mode string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(os.Stdout)
+}
+
+// colorSections son las partes de la salida que cada tema colorea. Los
+// módulos se agrupan en system, hardware, network y desktop; custom es para
+// los plugins
+var colorSections = []string{"title", "version", "logo", "logo_accent", "system", "hardware", "network", "desktop", "custom"}
+
+// colorTheme asigna un color a cada sección
+type colorTheme map[string]string
+
+// themes son los temas que se pueden elegir con --theme o theme en el config
+var themes = map[string]colorTheme{
+	"default": {
+		"title": "bold", "version": "cyan", "logo": "yellow", "logo_accent": "cyan",
+		"system": "yellow", "hardware": "green", "network": "cyan", "desktop": "magenta", "custom": "blue",
+	},
+	"nord": {
+		"title": "bold #eceff4", "version": "#88c0d0", "logo": "#ebcb8b", "logo_accent": "#88c0d0",
+		"system": "#88c0d0", "hardware": "#a3be8c", "network": "#5e81ac", "desktop": "#b48ead", "custom": "#81a1c1",
+	},
+	"gruvbox": {
+		"title": "bold #ebdbb2", "version": "#8ec07c", "logo": "#fabd2f", "logo_accent": "#ebdbb2",
+		"system": "#fabd2f", "hardware": "#b8bb26", "network": "#8ec07c", "desktop": "#d3869b", "custom": "#83a598",
+	},
+	"dracula": {
+		"title": "bold #f8f8f2", "version": "#8be9fd", "logo": "#ffb86c", "logo_accent": "#8be9fd",
+		"system": "#bd93f9", "hardware": "#50fa7b", "network": "#8be9fd", "desktop": "#ff79c6", "custom": "#f1fa8c",
+	},
+	"mono": {
+		"title": "bold", "version": "", "logo": "", "logo_accent": "",
+		"system": "bold", "hardware": "bold", "network": "bold", "desktop": "bold", "custom": "bold",
+	},
+}
+
+// isColorSection indica si name es una sección de los temas
+func isColorSection(name string) bool {
+	for _, s := range colorSections {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// subcommands son los subcomandos de cafetch con su descripción para la
+// completación
+var subcommands = [][2]string{
+	{"serve", "serve the info over HTTP as JSON and Prometheus metrics"},
+	{"snapshot", "save the info as a JSON snapshot"},
+	{"diff", "show what changed between two snapshots"},
+	{"history", "show the saved history of runs"},
+	{"update", "replace this binary with the latest release"},
+	{"completion", "print a shell completion script"},
+	{"migrate", "convert a neofetch or fastfetch config"},
+	{"get", "print a single raw value for status bars"},
+}
+
+// fileFlags son los flags que reciben una ruta
+var fileFlags = map[string]bool{"logo-file": true, "logo-image": true, "since": true, "screenshot": true}
+
+// completionFlag es un flag de la salida normal tal como se completa
+type completionFlag struct {
+	name, usage string
+	value       bool     // recibe un valor (no es booleano)
+	choices     []string // valores posibles, nil si es libre
+}
+
+// runCompletion imprime el script de completación de bash, zsh o fish.
+// Los nombres de los módulos (con los plugins y los [[custom]]) y de los
+// temas se leen al generarlo, así que hay que regenerarlo si cambian
+func runCompletion(args []string) {
+	fs := flag.NewFlagSet("completion", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: cafetch completion bash|zsh|fish")
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	registerPlugins(pluginDir())
+	userConfig()
+	flags := completionFlags()
+	switch fs.Arg(0) {
+	case "bash":
+		fmt.Print(bashCompletion(flags))
+	case "zsh":
+		fmt.Print(zshCompletion(flags))
+	case "fish":
+		fmt.Print(fishCompletion(flags))
+	default:
+		fmt.Fprintf(os.Stderr, "cafetch: completion: unknown shell %q (use bash, zsh or fish)\n", fs.Arg(0))
+		os.Exit(2)
+	}
+}
+
+// completionFlags lista los flags de defineFlags con los valores que se
+// pueden completar
+func completionFlags() []completionFlag {
+	fs := flag.NewFlagSet("cafetch", flag.ContinueOnError)
+	defineFlags(fs, &options{})
+
+	choices := map[string][]string{
+		"modules":       moduleNames(),
+		"theme":         themeNames(),
+		"logo":          logoNames(),
+		"color":         {"auto", "always", "never"},
+		"logo-position": {"left", "right"},
+		"memory-mode":   {"available", "free", "htop"},
+		"output":        outputFormats,
+	}
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		_, usage := flag.UnquoteUsage(f)
+		b, isBool := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, completionFlag{
+			name:    f.Name,
+			usage:   usage,
+			value:   !isBool || !b.IsBoolFlag(),
+			choices: choices[f.Name],
+		})
+	})
+	return flags
+}
+
+// moduleNames devuelve los módulos en orden alfabético
+func moduleNames() []string {
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// themeNames devuelve los temas en orden alfabético
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bashCompletion arma el script de bash. Los módulos de --modules se
+// completan después de cada coma
+func bashCompletion(flags []completionFlag) string {
+	var b strings.Builder
+	var subs, all []string
+	for _, s := range subcommands {
+		subs = append(subs, s[0])
+	}
+	b.WriteString("# cafetch completion bash\n_cafetch() {\n")
+	b.WriteString("\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(&b, "\tif [ \"$COMP_CWORD\" -eq 1 ] && [[ \"$cur\" != -* ]]; then\n\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n\t\treturn\n\tfi\n", strings.Join(subs, " "))
+	b.WriteString("\tcase \"${COMP_WORDS[1]}\" in\n")
+	b.WriteString("\tcompletion) COMPREPLY=($(compgen -W \"bash zsh fish\" -- \"$cur\")); return ;;\n")
+	b.WriteString("\tupdate) COMPREPLY=($(compgen -W \"--check --force\" -- \"$cur\")); return ;;\n")
+	b.WriteString("\tmigrate)\n\t\tif [ \"$prev\" = --from ]; then COMPREPLY=($(compgen -W \"neofetch fastfetch\" -- \"$cur\")); else COMPREPLY=($(compgen -f -- \"$cur\")); fi\n\t\treturn ;;\n")
+	fmt.Fprintf(&b, "\tget) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", strings.Join(append(getFieldNames(), "--list"), " "))
+	b.WriteString("\tserve|snapshot|diff|history) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n")
+	b.WriteString("\tesac\n\tcase \"$prev\" in\n")
+	for _, f := range flags {
+		all = append(all, "--"+f.name)
+		switch {
+		case f.name == "modules":
+			fmt.Fprintf(&b, "\t--%s|-%s)\n\t\tlocal prefix=\"\"\n\t\t[[ \"$cur\" == *,* ]] && prefix=\"${cur%%,*},\"\n", f.name, f.name)
+			fmt.Fprintf(&b, "\t\tCOMPREPLY=($(compgen -P \"$prefix\" -W %q -- \"${cur##*,}\"))\n\t\tcompopt -o nospace\n\t\treturn ;;\n", strings.Join(f.choices, " "))
+		case f.choices != nil:
+			fmt.Fprintf(&b, "\t--%s|-%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", f.name, f.name, strings.Join(f.choices, " "))
+		case fileFlags[f.name]:
+			fmt.Fprintf(&b, "\t--%s|-%s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", f.name, f.name)
+		case f.value:
+			fmt.Fprintf(&b, "\t--%s|-%s) return ;;\n", f.name, f.name)
+		}
+	}
+	b.WriteString("\tesac\n")
+	fmt.Fprintf(&b, "\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n}\ncomplete -F _cafetch cafetch\n", strings.Join(all, " "))
+	return b.String()
+}
+
+// zshQuote escapa una descripción para las especificaciones de _arguments
+var zshQuote = strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`)
+
+// zshCompletion arma el script de zsh con _arguments
+func zshCompletion(flags []completionFlag) string {
+	var b strings.Builder
+	b.WriteString("#compdef cafetch\n\n_cafetch() {\n\tlocal -a subcommands\n\tsubcommands=(\n")
+	for _, s := range subcommands {
+		fmt.Fprintf(&b, "\t\t'%s:%s'\n", s[0], zshQuote.Replace(s[1]))
+	}
+	b.WriteString("\t)\n")
+	b.WriteString("\tif (( CURRENT == 2 )) && [[ $PREFIX != -* ]]; then\n\t\t_describe subcommand subcommands\n\t\treturn\n\tfi\n")
+	b.WriteString("\tcase $words[2] in\n\tcompletion) _values shell bash zsh fish; return ;;\n\tupdate) _arguments '--check[only check for a newer release]' '--force[install even if not newer]'; return ;;\n\tmigrate) _arguments '--from[tool whose config is read]:tool:(neofetch fastfetch)' '-o[output file]:file:_files' '1:config:_files'; return ;;\n\tserve|snapshot|diff|history) _files; return ;;\n")
+	fmt.Fprintf(&b, "\tget) _arguments '--list[list the available fields]' '1:field:(%s)'; return ;;\n\tesac\n", strings.Join(getFieldNames(), " "))
+	b.WriteString("\t_arguments \\\n")
+	for _, f := range flags {
+		spec := fmt.Sprintf("--%s[%s]", f.name, zshQuote.Replace(f.usage))
+		switch {
+		case f.name == "modules":
+			spec += ":modules:_sequence compadd - " + strings.Join(f.choices, " ")
+		case f.choices != nil:
+			spec += fmt.Sprintf(":%s:(%s)", f.name, strings.Join(f.choices, " "))
+		case fileFlags[f.name]:
+			spec += ":file:_files"
+		case f.value:
+			spec += ":" + f.name + ": "
+		}
+		fmt.Fprintf(&b, "\t\t'%s' \\\n", spec)
+	}
+	b.WriteString("\t\t&& return\n}\n\n_cafetch \"$@\"\n")
+	return b.String()
+}
+
+// fishQuote escapa un texto entre comillas simples de fish
+var fishQuote = strings.NewReplacer(`\`, `\\`, "'", `\'`)
+
+// fishCompletion arma el script de fish
+func fishCompletion(flags []completionFlag) string {
+	var b strings.Builder
+	b.WriteString("# cafetch completion fish\ncomplete -c cafetch -f\n")
+	for _, s := range subcommands {
+		fmt.Fprintf(&b, "complete -c cafetch -n __fish_use_subcommand -a %s -d '%s'\n", s[0], fishQuote.Replace(s[1]))
+	}
+	b.WriteString("complete -c cafetch -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'\n")
+	b.WriteString("complete -c cafetch -n '__fish_seen_subcommand_from update' -l check -d 'only check for a newer release'\n")
+	b.WriteString("complete -c cafetch -n '__fish_seen_subcommand_from update' -l force -d 'install even if not newer'\n")
+	b.WriteString("complete -c cafetch -n '__fish_seen_subcommand_from migrate' -l from -x -a 'neofetch fastfetch' -d 'tool whose config is read'\n")
+	b.WriteString("complete -c cafetch -n '__fish_seen_subcommand_from serve snapshot diff history migrate' -F\n")
+	fmt.Fprintf(&b, "complete -c cafetch -n '__fish_seen_subcommand_from get' -a '%s'\n", strings.Join(getFieldNames(), " "))
+	b.WriteString("complete -c cafetch -n '__fish_seen_subcommand_from get' -l list -d 'list the available fields'\n")
+	for _, f := range flags {
+		line := fmt.Sprintf("complete -c cafetch -n __fish_use_subcommand -l %s -d '%s'", f.name, fishQuote.Replace(f.usage))
+		switch {
+		case f.name == "modules":
+			line += fmt.Sprintf(" -x -a '(__fish_complete_list , \"string split \\\" \\\" %s\")'", strings.Join(f.choices, " "))
+		case f.choices != nil:
+			line += fmt.Sprintf(" -x -a '%s'", strings.Join(f.choices, " "))
+		case fileFlags[f.name]:
+			line += " -r -F"
+		case f.value:
+			line += " -x"
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,840 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// config guarda la personalización leída de ~/.config/cafetch/config.toml
+type config struct {
+	Modules []string          // orden de los módulos, "break" es una línea en blanco
+	Disable []string          // módulos a ocultar
+	Labels  map[string]string // etiquetas renombradas por módulo
+	Colors  map[string]string // color por módulo o por sección, reemplaza al del tema
+	Custom  []customCommand   // comandos de [[custom]], ya ubicados en Modules
+	Theme   string            // tema de colores, ver themes
+	Logo    bool              // muestra el logo a la izquierda
+	Color   bool              // usa colores ANSI, resuelto desde ColorMode al arrancar
+	TUI     bool              // corre en --tui, donde los módulos de Disable se pueden mostrar
+
+	ColorMode string // "auto", "always" o "never"
+	TrueColor bool   // la terminal entiende colores de 24 bits
+	Unicode   bool   // la terminal muestra caracteres de bloque
+
+	Bars        bool // barras de uso junto a Mem y Disk
+	BarWidth    int  // ancho de las barras en caracteres
+	BarWarn     int  // porcentaje desde el que la barra es amarilla
+	BarCritical int  // porcentaje desde el que la barra es roja
+
+	LogoName  string   // logo de la colección (ver logos), "random" o "seasonal"; loadLogo lo resuelve a un nombre
+	LogoSeed  int      // semilla de "random", 0 elige uno distinto en cada ejecución
+	LogoFile  string   // archivo con un logo ASCII propio, "" usa LogoName
+	LogoLines []string // líneas del logo propio, leídas por loadLogo
+
+	LogoImage      string     // PNG a dibujar como logo si la terminal lo soporta
+	LogoImageWidth int        // ancho de la imagen en columnas
+	ImageProtocol  string     // "auto", "kitty", "iterm2", "sixel" o "none"
+	Image          *imageLogo // imagen ya leída por loadImage, nil usa el logo de texto
+
+	IPv6        bool   // incluye las direcciones IPv6 globales
+	PublicIP    bool   // consulta la IP pública, apagado por privacidad
+	PublicIPURL string // endpoint HTTPS que devuelve la IP en texto plano
+
+	Weather         bool   // consulta el clima, apagado por defecto (hace una petición de red)
+	WeatherURL      string // endpoint HTTPS estilo wttr.in; {location} se reemplaza por WeatherLocation
+	WeatherLocation string // ciudad o coordenadas, "" deja que el servicio la deduzca de la IP
+
+	ShareURL string // endpoint HTTPS al que --share sube la salida
+
+	Sensors []string // sensores de temperatura a mostrar: cpu, gpu, nvme
+	Timeout int      // segundos que se espera a cada colector
+	Cache   bool     // guarda los datos estáticos en ~/.cache/cafetch
+	Disks   []string // puntos de montaje a mostrar, ["auto"] los descubre
+
+	GPUDriver bool // agrega el driver y la VRAM a la línea GPU (puede correr nvidia-smi o glxinfo)
+
+	LogoPosition string // "left" o "right": de qué lado del texto va el logo
+	Separator    string // entre la etiqueta y el valor, ej. ":" o " ->"
+	Margin       int    // espacios a la izquierda de todo
+	Padding      int    // espacios entre el logo y la info
+
+	Overflow  string // "truncate", "wrap" o "none": qué hacer con los valores que no entran
+	InfoWidth int    // columnas disponibles para la info, 0 sin límite; lo calcula renderInfo
+
+	Format string // plantilla que reemplaza a las líneas de los módulos, ver parseFormat
+
+	Language string // idioma de las etiquetas: "auto" (el del locale), "en", "es" o "ca"
+
+	Units         string // "binary" (KiB, MiB, GiB) o "decimal" (kB, MB, GB)
+	UnitPrecision int    // decimales de los tamaños
+
+	History      bool // guarda cada ejecución en ~/.local/share/cafetch/history.jsonl
+	HistoryMaxKB int  // tamaño desde el que se rota el historial
+
+	Hostname string // "auto" (como lo da el sistema), "short" o "fqdn"
+
+	MemoryMode string // cómo se cuenta la memoria usada: "available", "free" o "htop"
+
+	UptimeFormat string // forma de la línea de Uptime, ver uptimeFormats
+
+	SampleIntervalMS int // milisegundos entre las dos lecturas de cpu_usage y net_rate
+
+	Anonymize bool      // oculta usuario, hostname, IPs y MAC para compartir la salida
+	Redactor  *redactor // reemplazos de los datos reales, lo arma main con --anonymize
+}
+
+// configPath devuelve la ruta del archivo de configuración
+func configPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "cafetch", "config.toml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "cafetch", "config.toml")
+}
+
+// cachePath devuelve la carpeta de la caché, ~/.cache/cafetch en Linux
+// (o $XDG_CACHE_HOME/cafetch), "" si no hay una
+func cachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "cafetch")
+}
+
+// defaultConfig devuelve la configuración que se usa sin archivo
+func defaultConfig() config {
+	return config{
+		Modules: defaultModules,
+		Labels:  map[string]string{},
+		Colors:  map[string]string{},
+		Theme:   "default",
+		Logo:    true,
+
+		LogoName: "cup",
+		Color:    true,
+
+		ColorMode: "auto",
+
+		BarWidth:    10,
+		BarWarn:     60,
+		BarCritical: 85,
+
+		LogoImageWidth: 20,
+		ImageProtocol:  "auto",
+
+		PublicIPURL: sysinfo.DefaultPublicIPURL,
+		WeatherURL:  defaultWeatherURL,
+		ShareURL:    defaultShareURL,
+		Sensors:     sysinfo.DefaultSensors,
+		Disks:       sysinfo.DefaultDisks,
+		Timeout:     int(sysinfo.DefaultTimeout / time.Second),
+		Cache:       true,
+
+		LogoPosition: "left",
+		Separator:    ":",
+		Margin:       2,
+		Padding:      2,
+
+		Overflow: "truncate",
+
+		Language: "auto",
+
+		Units:         "binary",
+		UnitPrecision: 1,
+
+		Hostname: "auto",
+
+		MemoryMode: sysinfo.MemoryAvailable,
+
+		UptimeFormat: "default",
+
+		SampleIntervalMS: int(sysinfo.DefaultSampleInterval / time.Millisecond),
+
+		HistoryMaxKB: 1024,
+	}
+}
+
+// fit ajusta una línea de info a InfoWidth según Overflow: la corta con
+// "…" o sigue en más líneas alineadas bajo el valor
+func (cfg config) fit(prefix, value string) []string {
+	limit := cfg.InfoWidth - visibleLen(prefix)
+	if cfg.InfoWidth <= 0 || cfg.Overflow == "none" || visibleLen(value) <= limit {
+		return []string{prefix + value}
+	}
+	// Si la etiqueta sola ya no entra se deja un mínimo para el valor
+	if limit < minValueWidth {
+		limit = minValueWidth
+	}
+	ellipsis := "..."
+	if cfg.Unicode {
+		ellipsis = "…"
+	}
+	if cfg.Overflow == "truncate" {
+		return []string{prefix + truncateVisible(value, limit, ellipsis)}
+	}
+
+	indent := strings.Repeat(" ", visibleLen(prefix))
+	lines := wrapWords(value, limit, ellipsis)
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return lines
+}
+
+// sizeUnits devuelve el formato de los tamaños elegido en el config
+func (cfg config) sizeUnits() units {
+	return units{Decimal: cfg.Units == "decimal", Precision: cfg.UnitPrecision}
+}
+
+// userConfig lee el config del usuario. Si está roto se avisa y se usan los
+// valores por defecto
+func userConfig() config {
+	path := configPath()
+	debugLog.Debug("config", "path", path)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: config:", err)
+		return defaultConfig()
+	}
+	registerCustom(cfg.Custom)
+	return cfg
+}
+
+// loadConfig lee el archivo de configuración. Si no existe devuelve la
+// configuración por defecto sin error
+func loadConfig(path string) (config, error) {
+	cfg := defaultConfig()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	doc, err := parseTOML(string(data))
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := cfg.decode(doc); err != nil {
+		return cfg, fmt.Errorf("%s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// decode copia al config los valores del documento TOML ya parseado
+func (cfg *config) decode(doc map[string]any) error {
+	if v, ok := doc["modules"]; ok {
+		mods, err := toStringList(v)
+		if err != nil {
+			return fmt.Errorf("modules: %v", err)
+		}
+		cfg.Modules = mods
+	}
+	if v, ok := doc["disable"]; ok {
+		mods, err := toStringList(v)
+		if err != nil {
+			return fmt.Errorf("disable: %v", err)
+		}
+		cfg.Disable = mods
+	}
+	if v, ok := doc["sensors"]; ok {
+		sensors, err := toStringList(v)
+		if err != nil {
+			return fmt.Errorf("sensors: %v", err)
+		}
+		cfg.Sensors = sensors
+	}
+	if v, ok := doc["disks"]; ok {
+		disks, err := toStringList(v)
+		if err != nil {
+			return fmt.Errorf("disks: %v", err)
+		}
+		cfg.Disks = disks
+	}
+	for key, dst := range map[string]*bool{
+		"ipv6":       &cfg.IPv6,
+		"public_ip":  &cfg.PublicIP,
+		"weather":    &cfg.Weather,
+		"bars":       &cfg.Bars,
+		"cache":      &cfg.Cache,
+		"history":    &cfg.History,
+		"anonymize":  &cfg.Anonymize,
+		"gpu_driver": &cfg.GPUDriver,
+	} {
+		if err := readBool(doc, key, dst); err != nil {
+			return err
+		}
+	}
+	if err := readString(doc, "public_ip_url", &cfg.PublicIPURL); err != nil {
+		return err
+	}
+	if err := readString(doc, "weather_url", &cfg.WeatherURL); err != nil {
+		return err
+	}
+	if err := readString(doc, "weather_location", &cfg.WeatherLocation); err != nil {
+		return err
+	}
+	if err := readString(doc, "share_url", &cfg.ShareURL); err != nil {
+		return err
+	}
+	// color acepta true/false (como antes) o "auto", "always" y "never"
+	switch v := doc["color"].(type) {
+	case nil:
+	case bool:
+		cfg.ColorMode = "never"
+		if v {
+			cfg.ColorMode = "auto"
+		}
+	case string:
+		cfg.ColorMode = v
+	default:
+		return fmt.Errorf("color: expected true, false, \"auto\", \"always\" or \"never\"")
+	}
+	if err := readString(doc, "theme", &cfg.Theme); err != nil {
+		return err
+	}
+	if err := readString(doc, "logo_file", &cfg.LogoFile); err != nil {
+		return err
+	}
+	if err := readString(doc, "logo_image", &cfg.LogoImage); err != nil {
+		return err
+	}
+	if err := readInt(doc, "logo_image_width", &cfg.LogoImageWidth); err != nil {
+		return err
+	}
+	// logo acepta true/false o el nombre de un logo, "random" o "seasonal"
+	switch v := doc["logo"].(type) {
+	case nil:
+	case bool:
+		cfg.Logo = v
+	case string:
+		cfg.Logo, cfg.LogoName = true, v
+	default:
+		return fmt.Errorf("logo: expected true, false or a logo name")
+	}
+	if err := readInt(doc, "logo_seed", &cfg.LogoSeed); err != nil {
+		return err
+	}
+	for key, dst := range map[string]*int{
+		"bar_width":    &cfg.BarWidth,
+		"bar_warn":     &cfg.BarWarn,
+		"bar_critical": &cfg.BarCritical,
+		"timeout":      &cfg.Timeout,
+		"margin":       &cfg.Margin,
+		"padding":      &cfg.Padding,
+
+		"history_max_kb":     &cfg.HistoryMaxKB,
+		"sample_interval_ms": &cfg.SampleIntervalMS,
+	} {
+		if err := readInt(doc, key, dst); err != nil {
+			return err
+		}
+	}
+	if err := readString(doc, "image_protocol", &cfg.ImageProtocol); err != nil {
+		return err
+	}
+	if err := readString(doc, "logo_position", &cfg.LogoPosition); err != nil {
+		return err
+	}
+	if err := readString(doc, "separator", &cfg.Separator); err != nil {
+		return err
+	}
+	if err := readString(doc, "overflow", &cfg.Overflow); err != nil {
+		return err
+	}
+	// format es un texto o un array con una línea por elemento
+	switch v := doc["format"].(type) {
+	case nil:
+	case string:
+		cfg.Format = v
+	default:
+		lines, err := toStringList(v)
+		if err != nil {
+			return fmt.Errorf("format: expected a string or %v", err)
+		}
+		cfg.Format = strings.Join(lines, "\n")
+	}
+	if err := readString(doc, "language", &cfg.Language); err != nil {
+		return err
+	}
+	if err := readString(doc, "hostname", &cfg.Hostname); err != nil {
+		return err
+	}
+	if err := readString(doc, "uptime_format", &cfg.UptimeFormat); err != nil {
+		return err
+	}
+	if err := readString(doc, "memory_mode", &cfg.MemoryMode); err != nil {
+		return err
+	}
+	if err := readString(doc, "units", &cfg.Units); err != nil {
+		return err
+	}
+	if err := readInt(doc, "unit_precision", &cfg.UnitPrecision); err != nil {
+		return err
+	}
+	if err := toStringMap(doc["labels"], cfg.Labels); err != nil {
+		return fmt.Errorf("labels: %v", err)
+	}
+	if err := toStringMap(doc["colors"], cfg.Colors); err != nil {
+		return fmt.Errorf("colors: %v", err)
+	}
+	custom, err := parseCustom(doc["custom"])
+	if err != nil {
+		return fmt.Errorf("custom: %v", err)
+	}
+	cfg.Custom = custom
+	cfg.Modules = placeCustom(cfg.Modules, custom)
+	return cfg.validate()
+}
+
+// validate revisa nombres y valores para que un typo no pase desapercibido
+func (cfg config) validate() error {
+	for _, name := range append(append([]string{}, cfg.Modules...), cfg.Disable...) {
+		if _, ok := modules[name]; !ok && name != "break" && !cfg.isCustom(name) {
+			return fmt.Errorf("unknown module %q", name)
+		}
+	}
+	for _, c := range cfg.Custom {
+		if _, ok := modules[c.After]; c.After != "" && !ok && !cfg.isCustom(c.After) {
+			return fmt.Errorf("custom: %s: after: unknown module %q", c.Name, c.After)
+		}
+	}
+	if cfg.ColorMode != "auto" && cfg.ColorMode != "always" && cfg.ColorMode != "never" {
+		return fmt.Errorf("color: unknown mode %q (use auto, always or never)", cfg.ColorMode)
+	}
+	if _, ok := themes[cfg.Theme]; !ok {
+		return fmt.Errorf("theme: unknown theme %q", cfg.Theme)
+	}
+	for name, color := range cfg.Colors {
+		if _, ok := modules[name]; !ok && !isColorSection(name) && !cfg.isCustom(name) {
+			return fmt.Errorf("colors: unknown module or section %q", name)
+		}
+		if _, ok := colorCode(color, true); !ok {
+			return fmt.Errorf("colors: unknown color %q", color)
+		}
+	}
+	for _, sensor := range cfg.Sensors {
+		if sensor != "cpu" && sensor != "gpu" && sensor != "nvme" {
+			return fmt.Errorf("sensors: unknown sensor %q", sensor)
+		}
+	}
+	knownProtocol := false
+	for _, p := range imageProtocols {
+		knownProtocol = knownProtocol || p == cfg.ImageProtocol
+	}
+	if !knownProtocol {
+		return fmt.Errorf("image_protocol: unknown protocol %q", cfg.ImageProtocol)
+	}
+	if cfg.LogoImageWidth <= 0 {
+		return fmt.Errorf("logo_image_width: must be a positive number of columns")
+	}
+	if !slices.Contains(logoNames(), cfg.LogoName) {
+		return fmt.Errorf("logo: unknown logo %q (use %s)", cfg.LogoName, strings.Join(logoNames(), ", "))
+	}
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("timeout: must be a positive number of seconds")
+	}
+	if cfg.LogoPosition != "left" && cfg.LogoPosition != "right" {
+		return fmt.Errorf("logo_position: unknown position %q (use left or right)", cfg.LogoPosition)
+	}
+	if cfg.Overflow != "truncate" && cfg.Overflow != "wrap" && cfg.Overflow != "none" {
+		return fmt.Errorf("overflow: unknown mode %q (use truncate, wrap or none)", cfg.Overflow)
+	}
+	if cfg.Margin < 0 || cfg.Padding < 0 {
+		return fmt.Errorf("margin and padding can't be negative")
+	}
+	if _, err := parseFormat(cfg.Format); err != nil {
+		return fmt.Errorf("format: %v", err)
+	}
+	knownLanguage := false
+	for _, l := range languages {
+		knownLanguage = knownLanguage || l == cfg.Language
+	}
+	if !knownLanguage {
+		return fmt.Errorf("language: unknown language %q (use %s)", cfg.Language, strings.Join(languages, ", "))
+	}
+	if cfg.Hostname != "auto" && cfg.Hostname != sysinfo.HostnameShort && cfg.Hostname != sysinfo.HostnameFQDN {
+		return fmt.Errorf("hostname: unknown form %q (use auto, short or fqdn)", cfg.Hostname)
+	}
+	if !slices.Contains(uptimeFormats, cfg.UptimeFormat) {
+		return fmt.Errorf("uptime_format: unknown format %q (use %s)", cfg.UptimeFormat, strings.Join(uptimeFormats, ", "))
+	}
+	switch cfg.MemoryMode {
+	case sysinfo.MemoryAvailable, sysinfo.MemoryFree, sysinfo.MemoryHtop:
+	default:
+		return fmt.Errorf("memory_mode: unknown mode %q (use available, free or htop)", cfg.MemoryMode)
+	}
+	if cfg.Units != "binary" && cfg.Units != "decimal" {
+		return fmt.Errorf("units: unknown units %q (use binary or decimal)", cfg.Units)
+	}
+	if cfg.UnitPrecision < 0 || cfg.UnitPrecision > 3 {
+		return fmt.Errorf("unit_precision: must be between 0 and 3")
+	}
+	if cfg.SampleIntervalMS < 10 || cfg.SampleIntervalMS > 5000 {
+		return fmt.Errorf("sample_interval_ms: must be between 10 and 5000")
+	}
+	if cfg.HistoryMaxKB <= 0 {
+		return fmt.Errorf("history_max_kb: must be a positive size")
+	}
+	if cfg.BarWidth <= 0 {
+		return fmt.Errorf("bar_width: must be a positive number of characters")
+	}
+	if cfg.BarWarn < 0 || cfg.BarWarn > cfg.BarCritical || cfg.BarCritical > 100 {
+		return fmt.Errorf("bar_warn and bar_critical: expected 0 <= bar_warn <= bar_critical <= 100")
+	}
+	// La IP pública solo se consulta por HTTPS
+	if u, err := url.Parse(cfg.PublicIPURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("public_ip_url: %q is not an https:// URL", cfg.PublicIPURL)
+	}
+	if u, err := url.Parse(cfg.weatherURL()); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("weather_url: %q is not an https:// URL", cfg.WeatherURL)
+	}
+	if u, err := url.Parse(cfg.ShareURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("share_url: %q is not an https:// URL", cfg.ShareURL)
+	}
+	return nil
+}
+
+// defaultWeatherURL es sysinfo.DefaultWeatherURL con lugar para la ubicación
+const defaultWeatherURL = "https://wttr.in/{location}?format=%C+%t"
+
+// weatherURL devuelve el endpoint del clima con la ubicación ya puesta
+func (cfg config) weatherURL() string {
+	return strings.ReplaceAll(cfg.WeatherURL, "{location}", url.PathEscape(cfg.WeatherLocation))
+}
+
+// applyOptions aplica los flags de la línea de comandos, que tienen
+// prioridad sobre el archivo de configuración
+func (cfg *config) applyOptions(opts options) error {
+	if opts.Modules != "" {
+		var mods []string
+		for _, name := range strings.Split(opts.Modules, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if _, ok := modules[name]; !ok && name != "break" {
+				return fmt.Errorf("--modules: unknown module %q", name)
+			}
+			mods = append(mods, name)
+		}
+		cfg.Modules = mods
+		cfg.Disable = nil
+	}
+	if opts.Logo != "" {
+		cfg.Logo, cfg.LogoName = true, opts.Logo
+	}
+	if opts.NoLogo {
+		cfg.Logo = false
+	}
+	if opts.Theme != "" {
+		cfg.Theme = opts.Theme
+	}
+	if opts.LogoFile != "" {
+		cfg.LogoFile = opts.LogoFile
+	}
+	if opts.LogoImage != "" {
+		cfg.LogoImage = opts.LogoImage
+	}
+	if opts.Bars {
+		cfg.Bars = true
+	}
+	if opts.NoCache {
+		cfg.Cache = false
+	}
+	if opts.IP6 {
+		cfg.IPv6 = true
+	}
+	if opts.PublicIP {
+		cfg.PublicIP = true
+	}
+	if opts.Weather {
+		cfg.Weather = true
+	}
+	if opts.PublicIPURL != "" {
+		cfg.PublicIPURL = opts.PublicIPURL
+	}
+	if opts.Color != "" {
+		cfg.ColorMode = opts.Color
+	}
+	if opts.NoColor {
+		cfg.ColorMode = "never"
+	}
+	if opts.Format != "" {
+		cfg.Format = opts.Format
+	}
+	if opts.LogoPos != "" {
+		cfg.LogoPosition = opts.LogoPos
+	}
+	if opts.Separator != "" {
+		cfg.Separator = opts.Separator
+	}
+	// Lo que se sube con --share siempre va anonimizado
+	if opts.Anonymize || opts.Share {
+		cfg.Anonymize = true
+	}
+	if opts.MemoryMode != "" {
+		cfg.MemoryMode = opts.MemoryMode
+	}
+	return cfg.validate()
+}
+
+// loadLogo lee el logo propio si hay uno configurado o, si no, elige el
+// de la colección una sola vez (así "random" no cambia al redibujar)
+func (cfg *config) loadLogo() error {
+	if cfg.LogoFile == "" {
+		cfg.LogoName = pickLogo(cfg.LogoName, int64(cfg.LogoSeed), time.Now())
+		return nil
+	}
+	lines, err := loadLogo(cfg.LogoFile)
+	if err != nil {
+		return err
+	}
+	cfg.LogoLines = lines
+	return nil
+}
+
+// loadImage lee la imagen del logo si hay una configurada y la terminal
+// tiene un protocolo de imágenes. Si no, se queda el logo de texto
+func (cfg *config) loadImage() error {
+	if cfg.LogoImage == "" || !cfg.Logo {
+		return nil
+	}
+	protocol := cfg.ImageProtocol
+	if protocol == "auto" {
+		protocol = detectImageProtocol()
+	}
+	if protocol == "" || protocol == "none" {
+		return nil
+	}
+	img, err := loadImageLogo(cfg.LogoImage, protocol, cfg.LogoImageWidth)
+	if err != nil {
+		return err
+	}
+	cfg.Image = img
+	return nil
+}
+
+// collectOptions devuelve las opciones de recolección según el config
+func (cfg config) collectOptions() sysinfo.Options {
+	cacheDir := ""
+	if cfg.Cache {
+		cacheDir = cachePath()
+	}
+	hostname := cfg.Hostname
+	if hostname == "auto" {
+		hostname = ""
+	}
+	return sysinfo.Options{
+		CacheDir:    cacheDir,
+		IPv6:        cfg.IPv6,
+		PublicIP:    cfg.PublicIP,
+		PublicIPURL: cfg.PublicIPURL,
+		Sensors:     cfg.Sensors,
+		Disks:       cfg.Disks,
+		Timeout:     time.Duration(cfg.Timeout) * time.Second,
+		Hostname:    hostname,
+		Memory:      cfg.MemoryMode,
+		Media:       cfg.shows("media"),
+		WiFi:        cfg.shows("wifi"),
+		Bluetooth:   cfg.shows("bluetooth"),
+		Sound:       cfg.shows("sound"),
+		Sessions:    cfg.shows("users"),
+		Containers:  cfg.shows("containers"),
+		GPUDrivers:  cfg.GPUDriver || cfg.shows("vram"),
+		CPUUsage:    cfg.shows("cpu_usage"),
+		NetRate:     cfg.shows("net_rate"),
+		Weather:     cfg.Weather && cfg.shows("weather"),
+		WeatherURL:  cfg.weatherURL(),
+
+		SampleInterval: time.Duration(cfg.SampleIntervalMS) * time.Millisecond,
+	}
+}
+
+// color devuelve la secuencia ANSI para un módulo de una sección: primero
+// se busca el color del módulo en el config, después el de la sección y si
+// no hay ninguno el del tema. Con los colores apagados devuelve ""
+func (cfg config) color(module, section string) string {
+	if !cfg.Color {
+		return ""
+	}
+	spec, ok := cfg.Colors[module]
+	if !ok {
+		spec, ok = cfg.Colors[section]
+	}
+	if !ok {
+		spec = themes[cfg.Theme][section]
+	}
+	code, _ := colorCode(spec, cfg.TrueColor)
+	return code
+}
+
+// reset devuelve la secuencia que apaga los colores, "" si están apagados
+func (cfg config) reset() string {
+	if !cfg.Color {
+		return ""
+	}
+	return ansiColors["reset"]
+}
+
+// enabledModules devuelve los módulos a mostrar en orden, sin los desactivados
+func (cfg config) enabledModules() []string {
+	var out []string
+	for _, name := range cfg.Modules {
+		disabled := false
+		for _, d := range cfg.Disable {
+			if strings.EqualFold(d, name) {
+				disabled = true
+				break
+			}
+		}
+		if !disabled {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// listedModules son los módulos de la lista que se pueden llegar a ver: sin
+// los de disable, salvo en --tui, que deja mostrarlos
+func (cfg config) listedModules() []string {
+	if cfg.TUI {
+		return cfg.Modules
+	}
+	return cfg.enabledModules()
+}
+
+// shows indica si el módulo name se puede ver, por la lista o por la
+// plantilla. Sirve para no recolectar lo que es caro y nadie va a ver
+func (cfg config) shows(name string) bool {
+	return slices.Contains(cfg.listedModules(), name) || strings.Contains(cfg.Format, "{"+name+"}")
+}
+
+// collectModules son los módulos cuyos datos hay que recolectar: los de
+// listedModules, los de la plantilla y, con history, los que guarda el
+// historial
+func (cfg config) collectModules() []string {
+	names := append([]string{}, cfg.listedModules()...)
+	if parts, err := parseFormat(cfg.Format); err == nil {
+		for _, p := range parts {
+			switch {
+			case p.field == "hostname":
+				names = append(names, "title")
+			case p.field != "" && !strings.Contains(p.field, ":"):
+				// {mem.used} necesita el módulo mem
+				name, _, _ := strings.Cut(p.field, ".")
+				names = append(names, name)
+			}
+		}
+	}
+	if cfg.History {
+		names = append(names, historyModules...)
+	}
+	return names
+}
+
+// shownOptions es collectOptions solo con los colectores de lo que se
+// muestra, para no leer lo que nadie va a ver
+func (cfg config) shownOptions() sysinfo.Options {
+	opts := cfg.collectOptions()
+	opts.Modules = cfg.collectModules()
+	return opts
+}
+
+// readBool copia doc[key] a dst si existe y es un booleano
+func readBool(doc map[string]any, key string, dst *bool) error {
+	v, ok := doc[key]
+	if !ok {
+		return nil
+	}
+	b, isBool := v.(bool)
+	if !isBool {
+		return fmt.Errorf("%s: expected true or false", key)
+	}
+	*dst = b
+	return nil
+}
+
+// readInt copia doc[key] a dst si existe y es un entero
+func readInt(doc map[string]any, key string, dst *int) error {
+	v, ok := doc[key]
+	if !ok {
+		return nil
+	}
+	n, isInt := v.(int64)
+	if !isInt {
+		return fmt.Errorf("%s: expected an integer", key)
+	}
+	*dst = int(n)
+	return nil
+}
+
+// readString copia doc[key] a dst si existe y es un string
+func readString(doc map[string]any, key string, dst *string) error {
+	v, ok := doc[key]
+	if !ok {
+		return nil
+	}
+	s, isString := v.(string)
+	if !isString {
+		return fmt.Errorf("%s: expected a string", key)
+	}
+	*dst = s
+	return nil
+}
+
+// toStringList convierte un array de TOML a []string
+func toStringList(v any) ([]string, error) {
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an array of strings")
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an array of strings")
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// toStringMap copia una tabla de TOML con valores string a dst
+func toStringMap(v any, dst map[string]string) error {
+	if v == nil {
+		return nil
+	}
+	table, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("expected a table")
+	}
+	for key, val := range table {
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string", key)
+		}
+		dst[key] = s
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package main
+
+// enableANSI no hace nada fuera de Windows: las terminales ya entienden ANSI
+func enableANSI() bool {
+	return true
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Funciones de la consola que no trae el paquete syscall
+var (
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// enableVirtualTerminal es ENABLE_VIRTUAL_TERMINAL_PROCESSING
+const enableVirtualTerminal = 0x0004
+
+// enableANSI activa las secuencias ANSI en la consola de Windows 10+.
+// Devuelve false si la consola no las soporta (conhost viejo)
+func enableANSI() bool {
+	handle := os.Stdout.Fd()
+	var mode uint32
+	if ok, _, _ := procGetConsoleMode.Call(handle, uintptr(unsafe.Pointer(&mode))); ok == 0 {
+		// No es una consola (redirigido a archivo o a una terminal tipo mintty)
+		return true
+	}
+	if mode&enableVirtualTerminal != 0 {
+		return true
+	}
+	ok, _, _ := procSetConsoleMode.Call(handle, uintptr(mode|enableVirtualTerminal))
+	return ok != 0
+}
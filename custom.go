@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// customCommand es una línea [[custom]] del config: un comando cuya salida
+// se muestra como un módulo más, sin tener que escribir un plugin
+type customCommand struct {
+	Name    string        // nombre del módulo, para modules, disable, labels y colors
+	Label   string        // etiqueta de la línea
+	Command string        // se corre con sh -c (cmd /C en Windows)
+	After   string        // módulo tras el que se ubica si no está en modules
+	Timeout time.Duration // lo máximo que se espera al comando
+}
+
+// parseCustom lee los [[custom]] del config:
+//
+//	[[custom]]
+//	label = "K8s"
+//	command = "kubectl config current-context"
+//	after = "kernel"   # opcional, si no va al final
+//	timeout_ms = 500   # opcional, 2000 por defecto
+//
+// Sin name el módulo se llama como la etiqueta en minúsculas ("k8s")
+func parseCustom(v any) ([]customCommand, error) {
+	if v == nil {
+		return nil, nil
+	}
+	tables, ok := v.([]map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected [[custom]] tables")
+	}
+	var out []customCommand
+	for n, t := range tables {
+		c := customCommand{Timeout: pluginTimeout}
+		ms := int(pluginTimeout / time.Millisecond)
+		for key, dst := range map[string]*string{"name": &c.Name, "label": &c.Label, "command": &c.Command, "after": &c.After} {
+			if err := readString(t, key, dst); err != nil {
+				return nil, fmt.Errorf("entry %d: %v", n+1, err)
+			}
+		}
+		if err := readInt(t, "timeout_ms", &ms); err != nil {
+			return nil, fmt.Errorf("entry %d: %v", n+1, err)
+		}
+		if c.Label == "" || c.Command == "" {
+			return nil, fmt.Errorf("entry %d: label and command are required", n+1)
+		}
+		if ms <= 0 {
+			return nil, fmt.Errorf("entry %d: timeout_ms must be positive", n+1)
+		}
+		c.Timeout = time.Duration(ms) * time.Millisecond
+		if c.Name == "" {
+			c.Name = strings.ReplaceAll(strings.ToLower(c.Label), " ", "_")
+		}
+		if _, exists := modules[c.Name]; exists || c.Name == "break" {
+			return nil, fmt.Errorf("%s: module already exists (set another name)", c.Name)
+		}
+		if slices.ContainsFunc(out, func(o customCommand) bool { return o.Name == c.Name }) {
+			return nil, fmt.Errorf("%s: repeated name", c.Name)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// placeCustom agrega a mods los comandos que no están ya en la lista:
+// después del módulo de After o, sin él, al final
+func placeCustom(mods []string, custom []customCommand) []string {
+	mods = slices.Clone(mods)
+	var rest []string
+	for _, c := range custom {
+		if slices.Contains(mods, c.Name) {
+			continue
+		}
+		if n := slices.Index(mods, c.After); c.After != "" && n >= 0 {
+			mods = slices.Insert(mods, n+1, c.Name)
+		} else {
+			rest = append(rest, c.Name)
+		}
+	}
+	if len(rest) > 0 {
+		mods = append(append(mods, "break"), rest...)
+	}
+	return mods
+}
+
+// registerCustom agrega los comandos como módulos de la sección "custom",
+// igual que los plugins
+func registerCustom(custom []customCommand) {
+	for _, c := range custom {
+		c := c
+		modules[c.Name] = module{Label: c.Label, Section: "custom", Lines: func(sysinfo.SystemInfo) []string {
+			return runCustom(c)
+		}}
+	}
+}
+
+// runCustom corre el comando y devuelve una línea por renglón no vacío de
+// su salida. Si falla o tarda más de c.Timeout no muestra nada
+func runCustom(c customCommand) []string {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	out, took, err := runWithTimeout(c.Timeout, shell, flag, c.Command)
+	if err != nil {
+		debugLog.Debug("custom command failed", "module", c.Name, "command", c.Command, "duration", took, "err", err)
+		return nil
+	}
+	debugLog.Debug("custom command", "module", c.Name, "command", c.Command, "duration", took, "bytes", len(out))
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// dropCustomModules quita los plugins y los [[custom]] ya registrados. Con
+// --remote correrían en esta máquina y su salida se mezclaría con la de la
+// remota sin nada que la distinga
+func dropCustomModules() {
+	for name, m := range modules {
+		if m.Section == "custom" {
+			delete(modules, name)
+		}
+	}
+}
+
+// customCache guarda la salida de los plugins y los [[custom]] entre los
+// cuadros de --watch y --tui, así corren una vez por refresco y no cada vez
+// que se redibuja (en --tui, con cada tecla)
+type customCache struct {
+	pairs map[string][]labeledValue
+	lines map[string][]string
+}
+
+// cacheCustomModules envuelve los plugins y los [[custom]] para que lean de
+// la caché; la primera vez después de reset corren y se guarda su salida
+func cacheCustomModules() *customCache {
+	c := &customCache{}
+	c.reset()
+	for name, m := range modules {
+		if m.Section != "custom" {
+			continue
+		}
+		name, pairs, lines := name, m.Pairs, m.Lines
+		if pairs != nil {
+			m.Pairs = func(info sysinfo.SystemInfo) []labeledValue {
+				out, ok := c.pairs[name]
+				if !ok {
+					out = pairs(info)
+					c.pairs[name] = out
+				}
+				return out
+			}
+		}
+		if lines != nil {
+			m.Lines = func(info sysinfo.SystemInfo) []string {
+				out, ok := c.lines[name]
+				if !ok {
+					out = lines(info)
+					c.lines[name] = out
+				}
+				return out
+			}
+		}
+		modules[name] = m
+	}
+	return c
+}
+
+// reset descarta la salida guardada, para el próximo refresco
+func (c *customCache) reset() {
+	c.pairs = map[string][]labeledValue{}
+	c.lines = map[string][]string{}
+}
+
+// isCustom indica si name es uno de los [[custom]] del config
+func (cfg config) isCustom(name string) bool {
+	return slices.ContainsFunc(cfg.Custom, func(c customCommand) bool { return c.Name == name })
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+)
+
+// debugLog recibe los mensajes de --debug. Sin el flag descarta todo (los
+// mensajes son de nivel Debug y el handler solo deja pasar Info)
+var debugLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// commandError explica por qué falló un plugin o comando: timeout, código
+// de salida con lo que escribió en stderr o que no se pudo ejecutar
+func commandError(ctx context.Context, err error) string {
+	if ctx.Err() != nil {
+		return "timed out"
+	}
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		if stderr := strings.TrimSpace(string(exit.Stderr)); stderr != "" {
+			return exit.Error() + ": " + stderr
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// change es una diferencia entre dos snapshots. detail va después de la
+// etiqueta del módulo (el punto de montaje, el gestor de paquetes) y grew
+// es 1 si un recurso se llenó más, -1 si se liberó y 0 si no aplica
+type change struct {
+	module   string
+	detail   string
+	old, new string
+	delta    string
+	grew     int
+}
+
+// diffModules son los módulos de texto que se comparan tal cual
+var diffModules = []string{"os", "host", "virt", "container", "kernel", "init", "arch", "cpu", "gpu", "shell", "de", "wm", "theme"}
+
+// runSnapshot es el subcomando "cafetch snapshot": guarda la info como
+// JSON (lo mismo que --json) para compararla después con diff o --since
+func runSnapshot(args []string) {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	output := fs.String("o", "", "write the snapshot to `file` instead of stdout")
+	fs.Parse(args)
+
+	cfg := userConfig()
+	info, err := sysinfo.Collect(context.Background(), cfg.collectOptions())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
+	}
+	if cfg.Anonymize {
+		anonymize(info, newRedactor(*info))
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+	if *output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
+	}
+}
+
+// runDiff es el subcomando "cafetch diff old.json [new.json]". Sin el
+// segundo archivo compara con el estado actual
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: cafetch diff old.json [new.json]")
+	}
+	fs.Parse(args)
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cfg := userConfig()
+	cfg.Color = useColor(cfg.ColorMode) && enableANSI()
+	cfg.TrueColor = supportsTruecolor()
+	sizeUnits = cfg.sizeUnits()
+
+	old, err := loadSnapshot(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
+	}
+	var cur *sysinfo.SystemInfo
+	if fs.NArg() == 2 {
+		cur, err = loadSnapshot(fs.Arg(1))
+	} else {
+		cur, err = sysinfo.Collect(context.Background(), cfg.collectOptions())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
+	}
+	printDiff(diffInfo(*old, *cur), cfg)
+}
+
+// loadSnapshot lee un snapshot guardado con "cafetch snapshot" o --json
+func loadSnapshot(path string) (*sysinfo.SystemInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info sysinfo.SystemInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &info, nil
+}
+
+// diffInfo compara dos snapshots: los textos que cambiaron (ej. el kernel
+// después de actualizar), el uso de memoria y discos y los paquetes
+func diffInfo(old, cur sysinfo.SystemInfo) []change {
+	var changes []change
+	for _, name := range diffModules {
+		mod := modules[name]
+		a, b := moduleText(mod, old), moduleText(mod, cur)
+		if a != b {
+			changes = append(changes, change{module: name, old: a, new: b})
+		}
+	}
+
+	// Si el uptime bajó la máquina se reinició en el medio
+	if cur.Uptime < old.Uptime {
+		changes = append(changes, change{module: "uptime",
+			old: formatUptime(old.Uptime), new: formatUptime(cur.Uptime), delta: "rebooted"})
+	}
+
+	if c, ok := diffUsage("mem", "", old.Memory, cur.Memory); ok {
+		changes = append(changes, c)
+	}
+	if c, ok := diffUsage("swap", "", old.Swap, cur.Swap); ok {
+		changes = append(changes, c)
+	}
+
+	// Discos por punto de montaje, incluidos los que aparecieron o ya no están
+	oldDisks := map[string]sysinfo.Usage{}
+	for _, m := range old.Disks {
+		oldDisks[m.Path] = m.Usage
+	}
+	for _, m := range cur.Disks {
+		if c, ok := diffUsage("disk", m.Path, oldDisks[m.Path], m.Usage); ok {
+			changes = append(changes, c)
+		}
+		delete(oldDisks, m.Path)
+	}
+	for _, m := range old.Disks {
+		if _, gone := oldDisks[m.Path]; gone {
+			changes = append(changes, change{module: "disk", detail: m.Path, old: formatMount(m, false), new: "-"})
+		}
+	}
+
+	// Paquetes por gestor
+	oldPkgs := map[string]int{}
+	for _, p := range old.Packages {
+		oldPkgs[p.Manager] = p.Count
+	}
+	for _, p := range cur.Packages {
+		if n := oldPkgs[p.Manager]; n != p.Count {
+			changes = append(changes, change{module: "packages", detail: "(" + p.Manager + ")",
+				old: fmt.Sprint(n), new: fmt.Sprint(p.Count), delta: fmt.Sprintf("%+d", p.Count-n)})
+		}
+		delete(oldPkgs, p.Manager)
+	}
+	for _, p := range old.Packages {
+		if _, gone := oldPkgs[p.Manager]; gone {
+			changes = append(changes, change{module: "packages", detail: "(" + p.Manager + ")",
+				old: fmt.Sprint(p.Count), new: "0", delta: fmt.Sprintf("%+d", -p.Count)})
+		}
+	}
+	return changes
+}
+
+// moduleText devuelve el valor de un módulo como un solo texto
+func moduleText(mod module, info sysinfo.SystemInfo) string {
+	if mod.Lines != nil {
+		return strings.Join(mod.Lines(info), ", ")
+	}
+	return mod.Value(info)
+}
+
+// diffUsage compara el uso de un recurso. Los cambios que no se notan con
+// la precisión de sizeUnits no cuentan
+func diffUsage(module, detail string, old, cur sysinfo.Usage) (change, bool) {
+	if sizeUnits.format(old.Used) == sizeUnits.format(cur.Used) && old.Total == cur.Total {
+		return change{}, false
+	}
+	c := change{module: module, detail: detail, old: formatUsage(old), new: formatUsage(cur)}
+	if old.Total == 0 {
+		c.old = "-"
+	}
+	switch {
+	case cur.Used > old.Used:
+		c.grew, c.delta = 1, "+"+sizeUnits.format(cur.Used-old.Used)
+	case cur.Used < old.Used:
+		c.grew, c.delta = -1, "-"+sizeUnits.format(old.Used-cur.Used)
+	}
+	return c, true
+}
+
+// printDiff imprime los cambios alineados. El delta va en rojo si el
+// recurso se llenó más y en verde si se liberó
+func printDiff(changes []change, cfg config) {
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+		return
+	}
+	arrow := "->"
+	if supportsUnicode() {
+		arrow = "→"
+	}
+
+	labels := make([]string, len(changes))
+	width := 0
+	for n, c := range changes {
+		labels[n] = cfg.label(c.module)
+		if c.detail != "" {
+			labels[n] += " " + c.detail
+		}
+		if w := utf8.RuneCountInString(labels[n]); w > width {
+			width = w
+		}
+	}
+
+	reset := cfg.reset()
+	for n, c := range changes {
+		line := cfg.color(c.module, modules[c.module].Section) + labels[n] + ":" + reset +
+			strings.Repeat(" ", width-utf8.RuneCountInString(labels[n])) + " " + c.old + " " + arrow + " " + c.new
+		if c.delta != "" {
+			color := ""
+			if cfg.Color {
+				switch c.grew {
+				case 1:
+					color = ansiColors["red"]
+				case -1:
+					color = ansiColors["green"]
+				}
+			}
+			line += " (" + color + c.delta + reset + ")"
+		}
+		fmt.Println(line)
+	}
+}
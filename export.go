@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// outputFormats son los valores de --output; "text" es la salida normal
+var outputFormats = []string{"text", "markdown", "html"}
+
+// renderMarkdown arma la info para pegar en un issue o una wiki: el logo
+// en un bloque de código (o como imagen si hay logo_image), las líneas sin
+// etiqueta (título, versión) como párrafos y cada grupo como una tabla
+func renderMarkdown(info sysinfo.SystemInfo, cfg config) string {
+	cfg.Color = false
+	var b strings.Builder
+	if cfg.Logo {
+		if cfg.LogoImage != "" {
+			fmt.Fprintf(&b, "![logo](%s)\n\n", cfg.LogoImage)
+		} else {
+			b.WriteString("```text\n")
+			for _, line := range cfg.logoLines() {
+				b.WriteString(strings.TrimRight(stripANSI(line), " ") + "\n")
+			}
+			b.WriteString("```\n\n")
+		}
+	}
+
+	// Con una plantilla no hay etiquetas: las líneas van tal cual
+	if cfg.Format != "" {
+		b.WriteString("```text\n" + strings.Join(buildLines(info, cfg), "\n") + "\n```\n")
+		return b.String()
+	}
+
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+	for _, g := range entryGroups(info, cfg) {
+		inTable := false
+		for _, e := range g {
+			if e.label == "" {
+				if inTable {
+					b.WriteString("\n")
+					inTable = false
+				}
+				fmt.Fprintf(&b, "**%s**\n\n", e.value)
+				continue
+			}
+			if !inTable {
+				b.WriteString("| | |\n|---|---|\n")
+				inTable = true
+			}
+			fmt.Fprintf(&b, "| **%s** | %s |\n", cell.Replace(e.label), cell.Replace(e.value))
+		}
+		if inTable {
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// renderHTML arma un bloque HTML con estilos en línea (sin CSS aparte, así
+// se puede pegar en cualquier página): el logo y la tabla lado a lado con
+// los colores del tema sobre fondo oscuro
+func renderHTML(info sysinfo.SystemInfo, cfg config) string {
+	cfg.Color, cfg.TrueColor = true, true
+	var b strings.Builder
+	b.WriteString(`<div class="cafetch" style="display:flex;gap:2em;align-items:flex-start;padding:1em;` +
+		`background:#1e1e1e;color:#d4d4d4;font-family:ui-monospace,Menlo,Consolas,monospace;border-radius:6px">` + "\n")
+	if cfg.Logo {
+		if cfg.LogoImage != "" {
+			fmt.Fprintf(&b, "<img src=\"%s\" alt=\"logo\">\n", html.EscapeString(cfg.LogoImage))
+		} else {
+			b.WriteString(`<pre style="margin:0">`)
+			for _, line := range cfg.logoLines() {
+				b.WriteString(ansiToHTML(line) + "\n")
+			}
+			b.WriteString("</pre>\n")
+		}
+	}
+
+	if cfg.Format != "" {
+		b.WriteString(`<pre style="margin:0">`)
+		for _, line := range buildLines(info, cfg) {
+			b.WriteString(ansiToHTML(line) + "\n")
+		}
+		b.WriteString("</pre>\n</div>\n")
+		return b.String()
+	}
+
+	b.WriteString(`<table style="border-collapse:collapse">` + "\n")
+	for gi, g := range entryGroups(info, cfg) {
+		if gi > 0 {
+			b.WriteString(`<tr><td colspan="2" style="height:1em"></td></tr>` + "\n")
+		}
+		for _, e := range g {
+			if e.label == "" {
+				fmt.Fprintf(&b, "<tr><td colspan=\"2\">%s</td></tr>\n", ansiToHTML(e.color+e.value+cfg.reset()))
+				continue
+			}
+			fmt.Fprintf(&b, "<tr><td style=\"padding-right:1em;vertical-align:top\">%s</td><td>%s</td></tr>\n",
+				ansiToHTML(e.color+e.label+cfg.reset()), ansiToHTML(e.value))
+		}
+	}
+	b.WriteString("</table>\n</div>\n")
+	return b.String()
+}
+
+// ansiToHTML convierte los colores ANSI de s en spans con estilo
+func ansiToHTML(s string) string {
+	var b strings.Builder
+	for _, span := range parseANSI(s) {
+		text := html.EscapeString(span.text)
+		var style []string
+		if span.fg >= 0 {
+			style = append(style, fmt.Sprintf("color:#%06x", span.fg))
+		}
+		if span.bold {
+			style = append(style, "font-weight:bold")
+		}
+		if len(style) == 0 {
+			b.WriteString(text)
+			continue
+		}
+		fmt.Fprintf(&b, `<span style="%s">%s</span>`, strings.Join(style, ";"), text)
+	}
+	return b.String()
+}
@@ -0,0 +1,549 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// uptimeFormats son las formas de la línea de Uptime que se pueden elegir
+// con uptime_format
+var uptimeFormats = []string{"default", "seconds", "weeks", "compact", "since"}
+
+// uptimeFormat es la forma de la línea de Uptime. main lo configura desde
+// el config antes de imprimir
+var uptimeFormat = "default"
+
+// formatUptime convierte los segundos a días, horas y minutos
+func formatUptime(seconds int64) string {
+	return formatUptimeAs(seconds, "default")
+}
+
+// formatUptimeAs escribe el uptime en una de las uptimeFormats:
+//
+//	default  "3d 4h 12m"
+//	seconds  "3d 4h 12m 5s"
+//	weeks    "1w 2d 4h 12m"
+//	compact  "3d4h12m"
+//	since    "since 2024-01-05 08:12" (la hora de arranque)
+func formatUptimeAs(seconds int64, format string) string {
+	if seconds <= 0 {
+		return "N/A"
+	}
+	if format == "since" {
+		boot := time.Now().Add(-time.Duration(seconds) * time.Second)
+		return "since " + boot.Format("2006-01-02 15:04")
+	}
+
+	s := int(seconds)
+	weeks, days := 0, s/86400
+	if format == "weeks" {
+		weeks, days = days/7, days%7
+	}
+	hours := (s % 86400) / 3600
+	minutes := (s % 3600) / 60
+
+	if format == "compact" {
+		var b strings.Builder
+		for _, p := range []struct {
+			n    int
+			unit string
+		}{{days, "d"}, {hours, "h"}, {minutes, "m"}} {
+			if p.n > 0 {
+				fmt.Fprintf(&b, "%d%s", p.n, p.unit)
+			}
+		}
+		if b.Len() == 0 {
+			return "0m"
+		}
+		return b.String()
+	}
+
+	out := fmt.Sprintf("%dh %dm", hours, minutes)
+	if days > 0 || weeks > 0 {
+		out = fmt.Sprintf("%dd %s", days, out)
+	}
+	if weeks > 0 {
+		out = fmt.Sprintf("%dw %s", weeks, out)
+	}
+	if format == "seconds" {
+		out += fmt.Sprintf(" %ds", s%60)
+	}
+	return out
+}
+
+// formatBootTime arma la línea del arranque con sus etapas, ej.
+// "8.2s (firmware 3.1s + userspace 5.1s)". Sin datos devuelve "" y la
+// línea se oculta
+func formatBootTime(b sysinfo.BootTime) string {
+	if b.Total == 0 {
+		return ""
+	}
+	var stages []string
+	for _, st := range []struct {
+		name string
+		secs float64
+	}{
+		{"firmware", b.Firmware}, {"loader", b.Loader}, {"kernel", b.Kernel},
+		{"initrd", b.Initrd}, {"userspace", b.Userspace},
+	} {
+		if st.secs > 0 {
+			stages = append(stages, st.name+" "+formatSeconds(st.secs))
+		}
+	}
+	if len(stages) == 0 {
+		return formatSeconds(b.Total)
+	}
+	return formatSeconds(b.Total) + " (" + strings.Join(stages, " + ") + ")"
+}
+
+// formatSeconds escribe una duración corta, ej. "5.1s" o "1m 12.4s"
+func formatSeconds(secs float64) string {
+	if secs < 60 {
+		return fmt.Sprintf("%.1fs", secs)
+	}
+	return fmt.Sprintf("%dm %.1fs", int(secs)/60, secs-float64(int(secs)/60*60))
+}
+
+// formatSecurity arma la línea de seguridad, ej. "AppArmor, Secure Boot
+// enabled, lockdown integrity". Sin nada que informar devuelve ""
+func formatSecurity(s sysinfo.Security) string {
+	var parts []string
+	if s.MAC != "" {
+		parts = append(parts, s.MAC)
+	}
+	if s.SecureBoot != "" {
+		parts = append(parts, "Secure Boot "+s.SecureBoot)
+	}
+	// En los BSD ya viene como "securelevel N"
+	switch {
+	case strings.HasPrefix(s.Lockdown, "securelevel"):
+		parts = append(parts, s.Lockdown)
+	case s.Lockdown != "":
+		parts = append(parts, "lockdown "+s.Lockdown)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatFirewall arma la línea del firewall, ej. "ufw (active, 24 rules)"
+func formatFirewall(f sysinfo.Firewall) string {
+	if f.Name == "" {
+		return ""
+	}
+	var details []string
+	if f.Status != "" {
+		details = append(details, f.Status)
+	}
+	if f.Rules > 0 {
+		details = append(details, fmt.Sprintf("%d rules", f.Rules))
+	}
+	if len(details) == 0 {
+		return f.Name
+	}
+	return f.Name + " (" + strings.Join(details, ", ") + ")"
+}
+
+// formatTimezone arma la línea de la zona horaria con su diferencia con
+// UTC, ej. "America/Argentina/Buenos_Aires (UTC-03:00)"
+func formatTimezone(name string, offset int) string {
+	if name == "" {
+		return ""
+	}
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("%s (UTC%s%02d:%02d)", name, sign, offset/3600, offset%3600/60)
+}
+
+// formatMedia arma la línea del reproductor, ej. "Daft Punk - One More
+// Time (Spotify)". En pausa se aclara al lado del reproductor
+func formatMedia(m sysinfo.Media) string {
+	if m.Title == "" {
+		return ""
+	}
+	track := m.Title
+	if m.Artist != "" {
+		track = m.Artist + " - " + m.Title
+	}
+	var details []string
+	if m.Player != "" {
+		details = append(details, m.Player)
+	}
+	if m.Status == "Paused" {
+		details = append(details, "paused")
+	}
+	if len(details) == 0 {
+		return track
+	}
+	return track + " (" + strings.Join(details, ", ") + ")"
+}
+
+// formatBattery arma la línea de una batería, ej. "87% (Discharging, health 92%)"
+func formatBattery(b sysinfo.Battery) string {
+	details := b.Status
+	if b.Health > 0 {
+		if details != "" {
+			details += ", "
+		}
+		details += fmt.Sprintf("health %.0f%%", b.Health)
+	}
+	if details == "" {
+		return fmt.Sprintf("%d%%", b.Capacity)
+	}
+	return fmt.Sprintf("%d%% (%s)", b.Capacity, details)
+}
+
+// formatLoad arma la línea de carga, ej. "0.52, 0.58, 0.59"
+func formatLoad(l sysinfo.Load, p sysinfo.Processes) string {
+	// Sin datos (o en Windows, que no tiene load average) la línea se oculta
+	if p.Total == 0 || l == (sysinfo.Load{}) {
+		return ""
+	}
+	return fmt.Sprintf("%.2f, %.2f, %.2f", l.One, l.Five, l.Fifteen)
+}
+
+// formatProcesses arma la línea de procesos, ej. "312 (2 running)"
+func formatProcesses(p sysinfo.Processes) string {
+	if p.Total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d (%d running)", p.Total, p.Running)
+}
+
+// formatContainers arma la línea de un runtime, ej. "4 running / 11 total (podman)"
+func formatContainers(c sysinfo.ContainerCount) string {
+	return fmt.Sprintf("%d running / %d total (%s)", c.Running, c.Total, c.Runtime)
+}
+
+// formatSessions arma la línea de usuarios, ej. "3 sessions: alice (2), bob".
+// Los usuarios van en el orden en que aparecen
+func formatSessions(sessions []sysinfo.Session) string {
+	if len(sessions) == 0 {
+		return ""
+	}
+	var users []string
+	counts := map[string]int{}
+	for _, s := range sessions {
+		if counts[s.User] == 0 {
+			users = append(users, s.User)
+		}
+		counts[s.User]++
+	}
+	for n, u := range users {
+		if counts[u] > 1 {
+			users[n] = fmt.Sprintf("%s (%d)", u, counts[u])
+		}
+	}
+	noun := "sessions"
+	if len(sessions) == 1 {
+		noun = "session"
+	}
+	return fmt.Sprintf("%d %s: %s", len(sessions), noun, strings.Join(users, ", "))
+}
+
+// sessionDetails detalla cada sesión con su terminal y desde dónde se conectó
+func sessionDetails(i sysinfo.SystemInfo) []string {
+	var lines []string
+	for _, s := range i.Sessions {
+		line := s.TTY + ": " + s.User
+		if s.Host != "" {
+			line += " from " + s.Host
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formatTemperatures arma la línea, ej. "CPU 52°C, GPU 45°C, NVMe 38°C"
+func formatTemperatures(temps []sysinfo.Temperature) string {
+	names := map[string]string{"cpu": "CPU", "gpu": "GPU", "nvme": "NVMe"}
+	parts := make([]string, 0, len(temps))
+	for _, t := range temps {
+		parts = append(parts, fmt.Sprintf("%s %.0f°C", names[t.Sensor], t.Celsius))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatInterface arma la línea de una interfaz, ej. "192.168.1.5, 2001:db8::5 (wlan0)"
+func formatInterface(ni sysinfo.NetInterface) string {
+	var addrs []string
+	if ni.IPv4 != "" {
+		addrs = append(addrs, ni.IPv4)
+	}
+	if ni.IPv6 != "" {
+		addrs = append(addrs, ni.IPv6)
+	}
+	return strings.Join(addrs, ", ") + " (" + ni.Name + ")"
+}
+
+// formatPackages arma la línea de paquetes, ej. "1432 (dpkg), 12 (brew)"
+func formatPackages(pkgs []sysinfo.PackageCount) string {
+	parts := make([]string, 0, len(pkgs))
+	for _, p := range pkgs {
+		parts = append(parts, fmt.Sprintf("%d (%s)", p.Count, p.Manager))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatTheme arma la línea de tema, ej. "Adwaita-dark [GTK], Breeze [Qt]"
+func formatTheme(t sysinfo.Theme) string {
+	var parts []string
+	if t.GTK != "" {
+		parts = append(parts, t.GTK+" [GTK]")
+	}
+	if t.Qt != "" {
+		parts = append(parts, t.Qt+" [Qt]")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSound arma la línea de audio, ej. "Built-in Audio Analog Stereo
+// (PipeWire 1.0.5)"
+func formatSound(s sysinfo.Sound) string {
+	if s.Device == "" {
+		return s.Server
+	}
+	if s.Server == "" {
+		return s.Device
+	}
+	return s.Device + " (" + s.Server + ")"
+}
+
+// formatBluetooth arma la línea de un dispositivo, ej. "WH-1000XM4 (80%)"
+func formatBluetooth(d sysinfo.BluetoothDevice) string {
+	if d.Battery == 0 {
+		return d.Name
+	}
+	return fmt.Sprintf("%s (%d%%)", d.Name, d.Battery)
+}
+
+// formatWiFi arma la línea de una red inalámbrica, ej. "MiRed (5 GHz,
+// -52 dBm, 96%)". La señal puede venir en dBm, en porcentaje o en los dos
+func formatWiFi(w sysinfo.WiFi) string {
+	var details []string
+	if w.Band != "" {
+		details = append(details, w.Band)
+	}
+	if w.Signal != 0 {
+		details = append(details, fmt.Sprintf("%d dBm", w.Signal))
+	}
+	if w.Quality != 0 {
+		details = append(details, fmt.Sprintf("%d%%", w.Quality))
+	}
+	if len(details) == 0 {
+		return w.SSID
+	}
+	return w.SSID + " (" + strings.Join(details, ", ") + ")"
+}
+
+// formatNetRate arma la línea del tráfico actual, ej. "↓ 2.3MiB/s ↑ 140KiB/s
+// (wlan0)"
+func formatNetRate(r sysinfo.NetRate) string {
+	if r.Interface == "" {
+		return ""
+	}
+	return fmt.Sprintf("↓ %s/s ↑ %s/s (%s)", sizeUnits.format(uint64(r.RX)), sizeUnits.format(uint64(r.TX)), r.Interface)
+}
+
+// formatDisplay arma la línea de un monitor, ej. "2560x1440 @ 144Hz (DP-1)"
+func formatDisplay(d sysinfo.Display) string {
+	s := fmt.Sprintf("%dx%d", d.Width, d.Height)
+	if d.Refresh > 0 {
+		s += fmt.Sprintf(" @ %.0fHz", d.Refresh)
+	}
+	return s + " (" + d.Name + ")"
+}
+
+// cpuFreqSuffix es el " @ 2.40GHz" que Intel agrega al nombre del modelo
+var cpuFreqSuffix = regexp.MustCompile(`\s*@\s*[\d.]+\s*GHz$`)
+
+// formatCPU arma la línea de CPU, ej. "AMD Ryzen 7 5800X (8c/16t) @ 4.7GHz"
+func formatCPU(cpu sysinfo.CPUInfo) string {
+	if cpu.Model == "N/A" {
+		return cpu.Model
+	}
+
+	mhz := cpu.MaxMHz
+	if mhz == 0 {
+		mhz = cpu.CurrentMHz
+	}
+
+	s := cpu.Model
+	if mhz > 0 {
+		// Se reemplaza la frecuencia del nombre por la real
+		s = cpuFreqSuffix.ReplaceAllString(s, "")
+	}
+	switch {
+	case cpu.Cores > 0 && cpu.Threads > 0:
+		s += fmt.Sprintf(" (%dc/%dt)", cpu.Cores, cpu.Threads)
+	case cpu.Threads > 0:
+		// Sin núcleos conocidos (ej. --remote sin /proc/cpuinfo)
+		s += fmt.Sprintf(" (%dt)", cpu.Threads)
+	}
+	if mhz > 0 {
+		s += fmt.Sprintf(" @ %.1fGHz", mhz/1000)
+	}
+	return s
+}
+
+// gpuDrivers asocia cada línea de GPUs con su driver por el fabricante, en
+// orden: la primera GPU de NVIDIA con el primer driver de NVIDIA y así. Las
+// que no tienen driver quedan vacías
+func gpuDrivers(i sysinfo.SystemInfo) []sysinfo.GPUDriver {
+	out := make([]sysinfo.GPUDriver, len(i.GPUs))
+	taken := make([]bool, len(i.GPUDrivers))
+	for n, gpu := range i.GPUs {
+		for k, d := range i.GPUDrivers {
+			if !taken[k] && d.Vendor != "" && strings.HasPrefix(strings.ToLower(gpu), strings.ToLower(d.Vendor)) {
+				out[n], taken[k] = d, true
+				break
+			}
+		}
+	}
+	return out
+}
+
+// formatGPU arma la línea de una GPU con su driver y VRAM total, ej.
+// "NVIDIA GeForce RTX 3070 (nvidia 550.67, 8GiB VRAM)". La VRAM usada va
+// en el módulo vram
+func formatGPU(name string, d sysinfo.GPUDriver) string {
+	var details []string
+	if driver := strings.TrimSpace(d.Driver + " " + d.Version); driver != "" {
+		details = append(details, driver)
+	}
+	if d.VRAM.Total > 0 {
+		details = append(details, sizeUnits.format(d.VRAM.Total)+" VRAM")
+	}
+	if len(details) == 0 {
+		return name
+	}
+	return name + " (" + strings.Join(details, ", ") + ")"
+}
+
+// gpuDetails detalla el driver y la memoria de cada GPU, con la VRAM usada
+// si se sabe
+func gpuDetails(i sysinfo.SystemInfo) []string {
+	var lines []string
+	for n, d := range gpuDrivers(i) {
+		line := i.GPUs[n]
+		if driver := strings.TrimSpace(d.Driver + " " + d.Version); driver != "" {
+			line += ": " + driver
+		}
+		if d.VRAM.Used > 0 {
+			line += ", VRAM " + formatUsage(d.VRAM)
+		} else if d.VRAM.Total > 0 {
+			line += ", VRAM " + sizeUnits.format(d.VRAM.Total)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// cpuDetails detalla la CPU con la frecuencia de cada CPU lógica
+func cpuDetails(i sysinfo.SystemInfo) []string {
+	cpu := i.CPU
+	lines := []string{
+		"Model: " + cpu.Model,
+		fmt.Sprintf("Cores: %d, threads: %d", cpu.Cores, cpu.Threads),
+	}
+	if cpu.MaxMHz > 0 {
+		lines = append(lines, fmt.Sprintf("Max: %.0f MHz", cpu.MaxMHz))
+	}
+	if cpu.CurrentMHz > 0 {
+		lines = append(lines, fmt.Sprintf("Current: %.0f MHz", cpu.CurrentMHz))
+	}
+	for n, mhz := range cpu.CoreMHz {
+		lines = append(lines, fmt.Sprintf("CPU %d: %.0f MHz", n, mhz))
+	}
+	return lines
+}
+
+// formatBIOS arma la línea del firmware, ej. "American Megatrends Inc.
+// 2803 (2022-04-14, UEFI)"
+func formatBIOS(b sysinfo.Board) string {
+	line := strings.TrimSpace(b.BIOSVendor + " " + b.BIOSVersion)
+	var details []string
+	for _, d := range []string{b.BIOSDate, b.Firmware} {
+		if d != "" {
+			details = append(details, d)
+		}
+	}
+	if line == "" || len(details) == 0 {
+		return line
+	}
+	return line + " (" + strings.Join(details, ", ") + ")"
+}
+
+// formatDrive arma la línea de un disco físico, ej. "Samsung SSD 980 PRO
+// 1TB (931.5GiB, NVMe)". Sin modelo (discos virtuales) se usa el nombre
+func formatDrive(d sysinfo.Drive) string {
+	name := d.Model
+	if name == "" {
+		name = d.Name
+	}
+	details := []string{sizeUnits.format(d.Size)}
+	if d.Type != "" {
+		details = append(details, d.Type)
+	}
+	if d.Removable {
+		details = append(details, "removable")
+	}
+	return name + " (" + strings.Join(details, ", ") + ")"
+}
+
+// kernelDetails detalla la versión completa, la fecha de compilación y los
+// motivos del taint
+func kernelDetails(i sysinfo.SystemInfo) []string {
+	k := i.KernelInfo
+	lines := []string{"Release: " + i.Kernel}
+	if k.Version != "" {
+		lines = append(lines, "Version: "+k.Version)
+	}
+	if k.BuildDate != "" {
+		lines = append(lines, "Built: "+k.BuildDate)
+	}
+	if k.Tainted != 0 {
+		lines = append(lines, fmt.Sprintf("Tainted: %d (%s)", k.Tainted, strings.Join(k.Taints, ", ")))
+	}
+	return lines
+}
+
+// formatKernelBuild arma la línea de kernel_build, ej. "#1 SMP
+// PREEMPT_DYNAMIC Debian 6.1.76-1 (2024-02-01), tainted: out-of-tree module"
+func formatKernelBuild(k sysinfo.KernelInfo) string {
+	line := k.Version
+	if k.BuildDate != "" && !strings.Contains(line, k.BuildDate) {
+		line += ", built " + k.BuildDate
+	}
+	if len(k.Taints) > 0 {
+		line += ", tainted: " + strings.Join(k.Taints, ", ")
+	}
+	return strings.TrimPrefix(line, ", ")
+}
+
+// diskDetails detalla cada punto de montaje con su dispositivo, sistema
+// de archivos y pool
+func diskDetails(i sysinfo.SystemInfo) []string {
+	var lines []string
+	for _, m := range i.Disks {
+		line := fmt.Sprintf("%s: %s (%s, %s)", m.Path, formatMount(m, false), m.Device, m.FSType)
+		if m.Pool != "" {
+			line += " - pool " + m.Pool
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formatMount arma la línea de un disco. Con más de uno se agrega el punto de montaje
+func formatMount(m sysinfo.Mount, showPath bool) string {
+	s := formatUsage(m.Usage)
+	if showPath {
+		s += " - " + m.Path
+	}
+	return s
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// getField es un campo de cafetch get: el módulo cuyo colector hace falta y
+// cómo leer el valor crudo, con false si no está disponible
+type getField struct {
+	name   string
+	module string
+	value  func(i sysinfo.SystemInfo) (string, bool)
+}
+
+// getFields son los campos con valores crudos (bytes, segundos,
+// porcentajes) para scripts y barras de estado. Además se puede pedir
+// cualquier módulo por su nombre, con el valor como en la salida normal
+var getFields = []getField{
+	{"user", "", func(i sysinfo.SystemInfo) (string, bool) { return i.User, i.User != "" }},
+	{"hostname", "title", func(i sysinfo.SystemInfo) (string, bool) { return known(i.Host) }},
+
+	{"mem.used", "mem", func(i sysinfo.SystemInfo) (string, bool) { return usageBytes(i.Memory, i.Memory.Used) }},
+	{"mem.total", "mem", func(i sysinfo.SystemInfo) (string, bool) { return usageBytes(i.Memory, i.Memory.Total) }},
+	{"mem.used_percent", "mem", func(i sysinfo.SystemInfo) (string, bool) { return usagePercent(i.Memory) }},
+	{"swap.used", "swap", func(i sysinfo.SystemInfo) (string, bool) { return usageBytes(i.Swap, i.Swap.Used) }},
+	{"swap.total", "swap", func(i sysinfo.SystemInfo) (string, bool) { return usageBytes(i.Swap, i.Swap.Total) }},
+	{"swap.used_percent", "swap", func(i sysinfo.SystemInfo) (string, bool) { return usagePercent(i.Swap) }},
+	{"disk.used", "disk", func(i sysinfo.SystemInfo) (string, bool) { return usageBytes(i.Disk, i.Disk.Used) }},
+	{"disk.total", "disk", func(i sysinfo.SystemInfo) (string, bool) { return usageBytes(i.Disk, i.Disk.Total) }},
+	{"disk.used_percent", "disk", func(i sysinfo.SystemInfo) (string, bool) { return usagePercent(i.Disk) }},
+
+	{"cpu.model", "cpu", func(i sysinfo.SystemInfo) (string, bool) { return known(i.CPU.Model) }},
+	{"cpu.cores", "cpu", func(i sysinfo.SystemInfo) (string, bool) { return positive(i.CPU.Cores) }},
+	{"cpu.threads", "cpu", func(i sysinfo.SystemInfo) (string, bool) { return positive(i.CPU.Threads) }},
+	{"cpu.usage_percent", "cpu_usage", func(i sysinfo.SystemInfo) (string, bool) {
+		// 0 es válido (una máquina quieta), sin medición es nil
+		if i.CPUUsage == nil {
+			return "", false
+		}
+		return fmt.Sprintf("%.1f", *i.CPUUsage), true
+	}},
+
+	{"uptime.seconds", "uptime", func(i sysinfo.SystemInfo) (string, bool) {
+		return strconv.FormatInt(i.Uptime, 10), i.Uptime > 0
+	}},
+	{"load.1", "load", func(i sysinfo.SystemInfo) (string, bool) { return loadValue(i.Load.One, i) }},
+	{"load.5", "load", func(i sysinfo.SystemInfo) (string, bool) { return loadValue(i.Load.Five, i) }},
+	{"load.15", "load", func(i sysinfo.SystemInfo) (string, bool) { return loadValue(i.Load.Fifteen, i) }},
+	{"procs.total", "procs", func(i sysinfo.SystemInfo) (string, bool) { return positive(i.Processes.Total) }},
+	{"procs.running", "procs", func(i sysinfo.SystemInfo) (string, bool) {
+		return strconv.Itoa(i.Processes.Running), i.Processes.Total > 0
+	}},
+	{"packages.total", "packages", func(i sysinfo.SystemInfo) (string, bool) {
+		total := 0
+		for _, p := range i.Packages {
+			total += p.Count
+		}
+		return positive(total)
+	}},
+
+	{"battery.percent", "battery", func(i sysinfo.SystemInfo) (string, bool) {
+		if len(i.Batteries) == 0 {
+			return "", false
+		}
+		return strconv.Itoa(i.Batteries[0].Capacity), true
+	}},
+	{"battery.status", "battery", func(i sysinfo.SystemInfo) (string, bool) {
+		if len(i.Batteries) == 0 {
+			return "", false
+		}
+		return known(i.Batteries[0].Status)
+	}},
+
+	{"ip", "ip", func(i sysinfo.SystemInfo) (string, bool) { return known(i.IP) }},
+	{"ipv6", "ipv6", func(i sysinfo.SystemInfo) (string, bool) { return known(i.IPv6) }},
+	{"public_ip", "public_ip", func(i sysinfo.SystemInfo) (string, bool) { return known(i.PublicIP) }},
+	{"net_rate.interface", "net_rate", func(i sysinfo.SystemInfo) (string, bool) { return known(i.NetRate.Interface) }},
+	{"net_rate.rx_bytes_per_second", "net_rate", func(i sysinfo.SystemInfo) (string, bool) {
+		return fmt.Sprintf("%.0f", i.NetRate.RX), i.NetRate.Interface != ""
+	}},
+	{"net_rate.tx_bytes_per_second", "net_rate", func(i sysinfo.SystemInfo) (string, bool) {
+		return fmt.Sprintf("%.0f", i.NetRate.TX), i.NetRate.Interface != ""
+	}},
+}
+
+// known devuelve s si es un valor detectado (ni vacío ni "N/A")
+func known(s string) (string, bool) {
+	return s, s != "" && s != "N/A"
+}
+
+// loadValue devuelve v si hay load average, con el mismo criterio que
+// formatLoad (Windows no lo tiene)
+func loadValue(v float64, i sysinfo.SystemInfo) (string, bool) {
+	return fmt.Sprintf("%.2f", v), i.Processes.Total > 0 && i.Load != (sysinfo.Load{})
+}
+
+// positive devuelve n si es mayor que 0
+func positive(n int) (string, bool) {
+	return strconv.Itoa(n), n > 0
+}
+
+// usageBytes devuelve n en bytes si u tiene total
+func usageBytes(u sysinfo.Usage, n uint64) (string, bool) {
+	return strconv.FormatUint(n, 10), u.Total > 0
+}
+
+// usagePercent devuelve el porcentaje usado de u con un decimal
+func usagePercent(u sysinfo.Usage) (string, bool) {
+	return fmt.Sprintf("%.1f", u.Percent()), u.Total > 0
+}
+
+// getFieldNames devuelve los campos crudos y después los módulos, para
+// --list y el autocompletado
+func getFieldNames() []string {
+	var names []string
+	for _, f := range getFields {
+		names = append(names, f.name)
+	}
+	return append(names, moduleNames()...)
+}
+
+// Códigos de salida de cafetch get
+const (
+	getUnavailable = 1 // el campo no se pudo leer en este sistema
+	getUsage       = 2 // campo desconocido o argumentos mal puestos
+)
+
+// runGet imprime un solo campo, sin etiqueta ni formato, para barras de
+// estado (polybar, waybar, tmux). Solo corren los colectores de ese campo,
+// así que es mucho más barato que la salida completa
+func runGet(args []string) {
+	fs := flag.NewFlagSet("get", flag.ExitOnError)
+	list := fs.Bool("list", false, "list the available fields")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: cafetch get <field> (cafetch get --list shows the fields)")
+	}
+	fs.Parse(args)
+
+	registerPlugins(pluginDir())
+	cfg := userConfig()
+	if *list {
+		for _, name := range getFieldNames() {
+			fmt.Println(name)
+		}
+		return
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(getUsage)
+	}
+
+	name := fs.Arg(0)
+	i := slices.IndexFunc(getFields, func(f getField) bool { return f.name == name })
+	module := name
+	if i >= 0 {
+		module = getFields[i].module
+	} else if _, ok := modules[name]; !ok {
+		fmt.Fprintf(os.Stderr, "cafetch: get: unknown field %q (cafetch get --list shows the fields)\n", name)
+		os.Exit(getUsage)
+	}
+
+	// Pedir el campo ya es pedir el dato, aunque el config no lo muestre
+	sizeUnits = cfg.sizeUnits()
+	uptimeFormat = cfg.UptimeFormat
+	cfg.Color, cfg.Modules, cfg.Format = false, []string{module}, ""
+	cfg.PublicIP = cfg.PublicIP || module == "public_ip"
+	cfg.Weather = cfg.Weather || module == "weather"
+	cfg.IPv6 = cfg.IPv6 || module == "ipv6"
+	opts := cfg.collectOptions()
+	opts.Modules = []string{module}
+	var timing sysinfo.Timing
+	opts.Timings = func(t sysinfo.Timing) {
+		if slices.Contains(strings.Split(t.Name, ","), module) {
+			timing = t
+		}
+	}
+
+	info, err := sysinfo.Collect(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: get:", err)
+		os.Exit(getUnavailable)
+	}
+	// Un colector que se pasó del timeout o no leyó nada deja ceros que no
+	// hay que imprimir como si fueran el valor. Si falló (y no es que el
+	// dato no existe acá) se dice por qué
+	if timing.Status == sysinfo.TimingTimeout || timing.Status == sysinfo.TimingNoData {
+		if timing.Err != nil {
+			fmt.Fprintf(os.Stderr, "cafetch: get: %s: %v\n", name, timing.Err)
+		}
+		os.Exit(getUnavailable)
+	}
+	if cfg.Anonymize {
+		cfg.Redactor = newRedactor(*info)
+		anonymize(info, cfg.Redactor)
+	}
+
+	var value string
+	ok := true
+	if i >= 0 {
+		value, ok = getFields[i].value(*info)
+	} else {
+		value = formatValue(name, *info, cfg)
+		ok = strings.TrimSpace(value) != ""
+	}
+	if !ok {
+		os.Exit(getUnavailable)
+	}
+	fmt.Println(value)
+}
@@ -0,0 +1,3 @@
+module github.com/c4feina/cafetch
+
+go 1.21
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// historyModules son los módulos de los datos que guarda el historial
+var historyModules = []string{"kernel", "uptime", "load", "mem", "disk", "packages"}
+
+// historyEntry es lo que se guarda de cada ejecución: solo lo que sirve
+// para ver tendencias, no la info completa
+type historyEntry struct {
+	Time     time.Time     `json:"time"`
+	Kernel   string        `json:"kernel"`
+	Uptime   int64         `json:"uptime_seconds"`
+	Load     float64       `json:"load1"`
+	Memory   sysinfo.Usage `json:"memory"`
+	Disks    []historyDisk `json:"disks,omitempty"`
+	Packages int           `json:"packages"`
+}
+
+// historyDisk es el uso de un punto de montaje
+type historyDisk struct {
+	Path string `json:"path"`
+	sysinfo.Usage
+}
+
+// historyFile es el nombre del historial; al rotar el anterior queda con
+// el sufijo ".1"
+const historyFile = "history.jsonl"
+
+// historySparkWidth es la cantidad de puntos de las líneas de tendencia
+const historySparkWidth = 20
+
+// historyPath devuelve la carpeta del historial, ~/.local/share/cafetch
+// (o $XDG_DATA_HOME/cafetch), "" si no hay una
+func historyPath() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "cafetch")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share", "cafetch")
+}
+
+// appendHistory agrega info al historial. Si el archivo pasaría de maxKB
+// se rota: el actual pasa a ser el ".1" (pisando al anterior), así que
+// nunca ocupa más del doble de maxKB. Los errores se ignoran
+func appendHistory(dir string, maxKB int, info sysinfo.SystemInfo) {
+	if dir == "" {
+		return
+	}
+	e := historyEntry{
+		Time:   time.Now().UTC().Truncate(time.Second),
+		Kernel: info.Kernel,
+		Uptime: info.Uptime,
+		Load:   info.Load.One,
+		Memory: info.Memory,
+	}
+	for _, m := range info.Disks {
+		e.Disks = append(e.Disks, historyDisk{Path: m.Path, Usage: m.Usage})
+	}
+	for _, p := range info.Packages {
+		e.Packages += p.Count
+	}
+	line, err := json.Marshal(e)
+	if err != nil || os.MkdirAll(dir, 0o755) != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	path := filepath.Join(dir, historyFile)
+	if st, err := os.Stat(path); err == nil && st.Size()+int64(len(line)) > int64(maxKB)*1024 {
+		os.Rename(path, path+".1")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(line)
+}
+
+// readHistory lee el historial rotado y el actual, del más viejo al más
+// nuevo. Las líneas rotas (ej. de una escritura cortada) se saltean
+func readHistory(dir string) []historyEntry {
+	var entries []historyEntry
+	for _, name := range []string{historyFile + ".1", historyFile} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var e historyEntry
+			if json.Unmarshal(scanner.Bytes(), &e) == nil {
+				entries = append(entries, e)
+			}
+		}
+		f.Close()
+	}
+	return entries
+}
+
+// runHistory es el subcomando "cafetch history": muestra las tendencias
+// del historial (uso de disco y memoria, paquetes, reinicios)
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	fs.Parse(args)
+
+	cfg := userConfig()
+	cfg.Color = useColor(cfg.ColorMode) && enableANSI()
+	cfg.TrueColor = supportsTruecolor()
+	cfg.Unicode = supportsUnicode()
+
+	entries := readHistory(historyPath())
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "cafetch: the history is empty (enable it with history = true in the config)")
+		os.Exit(1)
+	}
+	for _, line := range historyLines(entries, cfg) {
+		fmt.Println(line)
+	}
+}
+
+// historyLines arma el resumen del historial alineado como la salida normal
+func historyLines(entries []historyEntry, cfg config) []string {
+	first, last := entries[0], entries[len(entries)-1]
+	arrow := "->"
+	if cfg.Unicode {
+		arrow = "→"
+	}
+
+	type row struct{ module, label, value string }
+	rows := []row{{"", "Runs", fmt.Sprintf("%d, %s %s %s", len(entries),
+		first.Time.Local().Format("2006-01-02"), arrow, last.Time.Local().Format("2006-01-02"))}}
+
+	// Uso de cada disco a lo largo del tiempo
+	var paths []string
+	seen := map[string]bool{}
+	for _, e := range entries {
+		for _, d := range e.Disks {
+			if !seen[d.Path] {
+				seen[d.Path] = true
+				paths = append(paths, d.Path)
+			}
+		}
+	}
+	for _, path := range paths {
+		var percents []float64
+		for _, e := range entries {
+			for _, d := range e.Disks {
+				if d.Path == path {
+					percents = append(percents, d.Percent())
+				}
+			}
+		}
+		start, end := percents[0], percents[len(percents)-1]
+		rows = append(rows, row{"disk", cfg.label("disk") + " " + path, fmt.Sprintf("%.1f%% %s %.1f%% (%+.1f) %s",
+			start, arrow, end, end-start, sparkline(percents, cfg.Unicode))})
+	}
+
+	// Memoria: promedio y máximo
+	var mem []float64
+	var sum, peak float64
+	for _, e := range entries {
+		p := e.Memory.Percent()
+		mem = append(mem, p)
+		sum += p
+		if p > peak {
+			peak = p
+		}
+	}
+	rows = append(rows, row{"mem", cfg.label("mem"), fmt.Sprintf("avg %.1f%%, max %.1f%% %s",
+		sum/float64(len(mem)), peak, sparkline(mem, cfg.Unicode))})
+
+	if first.Packages != last.Packages {
+		rows = append(rows, row{"packages", cfg.label("packages"), fmt.Sprintf("%d %s %d (%+d)",
+			first.Packages, arrow, last.Packages, last.Packages-first.Packages)})
+	} else {
+		rows = append(rows, row{"packages", cfg.label("packages"), fmt.Sprint(last.Packages)})
+	}
+
+	// Racha de uptime: el mayor visto; cada vez que baja hubo un reinicio
+	var longest int64
+	reboots := 0
+	for i, e := range entries {
+		if e.Uptime > longest {
+			longest = e.Uptime
+		}
+		if i > 0 && e.Uptime < entries[i-1].Uptime {
+			reboots++
+		}
+	}
+	rows = append(rows, row{"uptime", cfg.label("uptime"), fmt.Sprintf("longest %s, %d reboots", formatUptime(longest), reboots)})
+
+	if first.Kernel != last.Kernel {
+		rows = append(rows, row{"kernel", cfg.label("kernel"), first.Kernel + " " + arrow + " " + last.Kernel})
+	}
+
+	width := 0
+	for _, r := range rows {
+		if n := utf8.RuneCountInString(r.label); n > width {
+			width = n
+		}
+	}
+	reset := cfg.reset()
+	var lines []string
+	for _, r := range rows {
+		section := "system"
+		if mod, ok := modules[r.module]; ok {
+			section = mod.Section
+		}
+		pad := strings.Repeat(" ", width-utf8.RuneCountInString(r.label))
+		lines = append(lines, cfg.color(r.module, section)+r.label+":"+reset+pad+" "+r.value)
+	}
+	return lines
+}
+
+// sparkline dibuja los porcentajes como una línea de historySparkWidth
+// caracteres; con muchos valores cada carácter es el promedio de un tramo
+func sparkline(percents []float64, unicode bool) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+	if !unicode {
+		levels = []rune("_.-=+*#@")
+	}
+
+	n := len(percents)
+	width := historySparkWidth
+	if n < width {
+		width = n
+	}
+	out := make([]rune, width)
+	for i := range out {
+		from, to := i*n/width, (i+1)*n/width
+		var sum float64
+		for _, p := range percents[from:to] {
+			sum += p
+		}
+		level := int(sum / float64(to-from) / 100 * float64(len(levels)))
+		if level >= len(levels) {
+			level = len(levels) - 1
+		}
+		out[i] = levels[level]
+	}
+	return string(out)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// languages son los idiomas que se pueden elegir con language; "auto" usa
+// el del sistema si está en la lista
+var languages = []string{"auto", "en", "es", "ca"}
+
+// labelTranslations son las etiquetas de los módulos en cada idioma, por
+// nombre de módulo. Lo que no está se muestra en inglés
+var labelTranslations = map[string]map[string]string{
+	"es": {
+		"os":           "SO",
+		"host":         "Equipo",
+		"virt":         "Virtualización",
+		"container":    "Contenedor",
+		"kernel":       "Kernel",
+		"kernel_build": "Compilación del kernel",
+		"arch":         "Arquitectura",
+		"uptime":       "Encendido",
+		"boot":         "Arranque",
+		"security":     "Seguridad",
+		"firewall":     "Cortafuegos",
+		"load":         "Carga",
+		"procs":        "Procesos",
+		"containers":   "Contenedores",
+		"users":        "Usuarios",
+		"packages":     "Paquetes",
+		"display":      "Pantalla",
+		"sound":        "Sonido",
+		"cpu_usage":    "Uso de CPU",
+		"board":        "Placa base",
+		"mem":          "Memoria",
+		"disk":         "Disco",
+		"drives":       "Unidad",
+		"battery":      "Batería",
+		"temps":        "Temperatura",
+		"net":          "Red",
+		"net_rate":     "Tráfico",
+		"public_ip":    "IP pública",
+		"de":           "Escritorio",
+		"theme":        "Tema",
+		"icons":        "Iconos",
+		"font":         "Fuente",
+		"term":         "Terminal",
+		"time":         "Hora",
+		"timezone":     "Zona horaria",
+		"media":        "Sonando",
+		"weather":      "Clima",
+		"locale":       "Idioma",
+	},
+	"ca": {
+		"os":           "SO",
+		"host":         "Equip",
+		"virt":         "Virtualització",
+		"container":    "Contenidor",
+		"kernel":       "Nucli",
+		"kernel_build": "Compilació del nucli",
+		"arch":         "Arquitectura",
+		"uptime":       "Temps actiu",
+		"boot":         "Arrencada",
+		"security":     "Seguretat",
+		"firewall":     "Tallafocs",
+		"load":         "Càrrega",
+		"procs":        "Processos",
+		"containers":   "Contenidors",
+		"users":        "Usuaris",
+		"packages":     "Paquets",
+		"display":      "Pantalla",
+		"sound":        "So",
+		"cpu_usage":    "Ús de CPU",
+		"board":        "Placa base",
+		"mem":          "Memòria",
+		"swap":         "Intercanvi",
+		"disk":         "Disc",
+		"drives":       "Unitat",
+		"battery":      "Bateria",
+		"temps":        "Temperatura",
+		"net":          "Xarxa",
+		"net_rate":     "Trànsit",
+		"public_ip":    "IP pública",
+		"de":           "Escriptori",
+		"theme":        "Tema",
+		"icons":        "Icones",
+		"font":         "Font",
+		"term":         "Terminal",
+		"time":         "Hora",
+		"timezone":     "Zona horària",
+		"media":        "Sonant",
+		"weather":      "Temps",
+		"locale":       "Idioma",
+	},
+}
+
+// systemLanguage devuelve el idioma del locale, ej. "es" para
+// "es_AR.UTF-8". Se miran LC_ALL, LC_MESSAGES y LANG en ese orden, como
+// hace gettext
+func systemLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		lang, _, _ := strings.Cut(v, "_")
+		lang, _, _ = strings.Cut(lang, ".")
+		return strings.ToLower(lang)
+	}
+	return "en"
+}
+
+// label devuelve la etiqueta del módulo name: la renombrada en el config,
+// la traducida al idioma elegido o la original en inglés
+func (cfg config) label(name string) string {
+	if l, ok := cfg.Labels[name]; ok {
+		return l
+	}
+	lang := cfg.Language
+	if lang == "auto" {
+		lang = systemLanguage()
+	}
+	if l, ok := labelTranslations[lang][name]; ok {
+		return l
+	}
+	return modules[name].Label
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"strings"
+)
+
+// winsize es el tamaño de la terminal en celdas y en píxeles (el struct de TIOCGWINSZ)
+type winsize struct {
+	Row, Col, Xpixel, Ypixel uint16
+}
+
+// cellSize devuelve el tamaño de una celda en píxeles. Si la terminal no lo
+// informa se asume 10x20, que es lo típico con fuentes de 10-12pt
+func cellSize() (w, h int) {
+	ws, ok := termWinsize()
+	if !ok || ws.Col == 0 || ws.Row == 0 || ws.Xpixel == 0 || ws.Ypixel == 0 {
+		return 10, 20
+	}
+	return int(ws.Xpixel / ws.Col), int(ws.Ypixel / ws.Row)
+}
+
+// imageProtocols son los valores válidos de image_protocol en el config
+var imageProtocols = []string{"auto", "kitty", "iterm2", "sixel", "none"}
+
+// detectImageProtocol adivina qué protocolo de imágenes entiende la terminal
+// mirando el entorno. Devuelve "" si no se reconoce ninguno
+func detectImageProtocol() string {
+	term, program := os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")
+	// tmux y screen no dejan pasar las imágenes sin configuración extra
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux") {
+		return ""
+	}
+	switch {
+	case term == "xterm-kitty", os.Getenv("KITTY_WINDOW_ID") != "", term == "xterm-ghostty", program == "ghostty":
+		return "kitty"
+	case program == "iTerm.app", os.Getenv("LC_TERMINAL") == "iTerm2", program == "WezTerm":
+		return "iterm2"
+	case strings.Contains(term, "sixel"), term == "foot", strings.HasPrefix(term, "foot-"), term == "mlterm",
+		term == "contour", os.Getenv("KONSOLE_VERSION") != "":
+		return "sixel"
+	}
+	return ""
+}
+
+// imageLogo es un PNG listo para dibujar como logo
+type imageLogo struct {
+	data       []byte      // el archivo PNG tal cual (kitty e iTerm2 lo reciben así)
+	img        image.Image // los píxeles, para sixel
+	protocol   string      // "kitty", "iterm2" o "sixel"
+	cols, rows int         // tamaño en celdas de la terminal
+}
+
+// loadImageLogo lee un PNG y calcula cuántas filas ocupa con el ancho pedido
+// en columnas, respetando la proporción de la imagen y de las celdas
+func loadImageLogo(path, protocol string, cols int) (*imageLogo, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	b := img.Bounds()
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return nil, fmt.Errorf("%s: empty image", path)
+	}
+
+	cw, ch := cellSize()
+	rows := (cols*cw*b.Dy()/b.Dx() + ch - 1) / ch
+	if rows < 1 {
+		rows = 1
+	}
+	return &imageLogo{data: data, img: img, protocol: protocol, cols: cols, rows: rows}, nil
+}
+
+// render devuelve las secuencias de escape que dibujan la imagen en la
+// posición del cursor
+func (l *imageLogo) render() string {
+	switch l.protocol {
+	case "kitty":
+		return kittyImage(l.data, l.cols, l.rows)
+	case "iterm2":
+		return fmt.Sprintf("\033]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+			len(l.data), l.cols, l.rows, base64.StdEncoding.EncodeToString(l.data))
+	case "sixel":
+		cw, ch := cellSize()
+		return sixelImage(l.img, l.cols*cw, l.rows*ch)
+	}
+	return ""
+}
+
+// kittyImage manda el PNG con el protocolo de gráficos de kitty en trozos de
+// 4096 bytes. C=1 deja el cursor donde estaba y q=2 silencia las respuestas
+func kittyImage(data []byte, cols, rows int) string {
+	payload := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for i := 0; i < len(payload); i += 4096 {
+		end, more := i+4096, 1
+		if end >= len(payload) {
+			end, more = len(payload), 0
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\033_Ga=T,f=100,c=%d,r=%d,C=1,q=2,m=%d;%s\033\\", cols, rows, more, payload[i:end])
+		} else {
+			fmt.Fprintf(&b, "\033_Gm=%d;%s\033\\", more, payload[i:end])
+		}
+	}
+	return b.String()
+}
+
+// printImageInfo dibuja la imagen a margin columnas del borde y escribe la
+// info a su derecha, separada por padding columnas. Primero se reserva el
+// alto de la imagen con saltos de línea, así si la terminal hace scroll la
+// posición guardada del cursor sigue siendo válida
+func printImageInfo(logo *imageLogo, data []string, margin, padding int) {
+	fmt.Print(strings.Repeat("\n", logo.rows+1))
+	fmt.Printf("\033[%dA\0337", logo.rows+1)
+	if margin > 0 {
+		fmt.Printf("\033[%dC", margin)
+	}
+	fmt.Print(logo.render())
+	fmt.Print("\0338")
+
+	for _, line := range data {
+		fmt.Printf("\033[%dC%s\n", margin+logo.cols+padding, line)
+	}
+	for i := len(data); i < logo.rows; i++ {
+		fmt.Println()
+	}
+}
+
+// isTerminal indica si f es una terminal (y no un pipe o un archivo)
+func isTerminal(f *os.File) bool {
+	st, err := f.Stat()
+	return err == nil && st.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// logoLines devuelve el logo de texto: el del archivo o el de la colección
+// (la taza si LogoName todavía no se resolvió)
+func (cfg config) logoLines() []string {
+	if cfg.LogoLines != nil {
+		return cfg.LogoLines
+	}
+	art, ok := findLogo(cfg.LogoName)
+	if !ok {
+		art = logos[0]
+	}
+	return art.render(cfg.color("", "logo_accent"), cfg.color("", "logo"), cfg.reset())
+}
+
+// escapeForms son las formas de escribir ESC como texto en un archivo (como
+// en echo -e o printf), que se aceptan además del byte crudo
+var escapeForms = strings.NewReplacer(`\033[`, "\033[", `\e[`, "\033[", `\x1b[`, "\033[", `\x1B[`, "\033[")
+
+// loadLogo lee un logo ASCII de un archivo. Los colores pueden venir como
+// secuencias ANSI crudas o escritas como \e[31m; cada línea termina con un
+// reset para que el color no se pase a la info
+func loadLogo(path string) ([]string, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	lines := strings.Split(escapeForms.Replace(text), "\n")
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if strings.Contains(line, "\033[") {
+			line += ansiColors["reset"]
+		}
+		lines[i] = line
+	}
+	return lines, nil
+}
+
+// logoWidth devuelve el ancho visible de la línea más larga del logo
+func logoWidth(logo []string) int {
+	width := 0
+	for _, line := range logo {
+		if n := visibleLen(line); n > width {
+			width = n
+		}
+	}
+	return width
+}
+
+// expandHome reemplaza un "~/" inicial por el directorio del usuario
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// logoArt es un logo de texto de la colección. En las líneas {a} pasa al
+// color de acento (logo_accent) y {b} al principal (logo)
+type logoArt struct {
+	name  string
+	tags  []string // cuándo lo elige "seasonal": "morning", "night", un mes ("december") o una fecha ("02-14")
+	lines []string
+}
+
+// logos es la colección que se elige con logo en el config o --logo. El
+// primero es el de siempre
+var logos = []logoArt{
+	{name: "cup", lines: []string{
+		"{a}     ( (  ",
+		"{a}      ) ) ",
+		"{b}  ........ ",
+		"{b}  |      |]",
+		"{b}  |      | ",
+		"{b}   ======  ",
+	}},
+	{name: "espresso", tags: []string{"morning"}, lines: []string{
+		"{a}    ) ) )  ",
+		"{a}   ( ( (   ",
+		"{b}  .-------.",
+		"{b}  |       |D",
+		"{b}  `-------'",
+		"{b} ~~~~~~~~~~",
+	}},
+	{name: "mug", tags: []string{"night"}, lines: []string{
+		"{a}    (  )  ( ",
+		"{a}     )  (  )",
+		"{b}  .________. ",
+		"{b}  |        |\\",
+		"{b}  |        |/",
+		"{b}  `--------' ",
+	}},
+	{name: "iced", tags: []string{"june", "july", "august"}, lines: []string{
+		"{a}     //    ",
+		"{b}  .--{a}//{b}--.  ",
+		"{b}  |{a}[]{b}{a}//{b}{a}[]{b}|  ",
+		"{b}  | {a}[]{b}   |  ",
+		"{b}  |   {a}[]{b} |  ",
+		"{b}  '------'  ",
+	}},
+	{name: "festive", tags: []string{"december"}, lines: []string{
+		"{a}      *    ",
+		"{a}     ( (   ",
+		"{a}      ) )  ",
+		"{b}  ........ ",
+		"{b}  |{a}*{b}  {a}*{b}  |]",
+		"{b}  |  {a}*{b}  {a}*{b}| ",
+		"{b}   ======  ",
+	}},
+	{name: "latte", tags: []string{"02-14"}, lines: []string{
+		"{a}     ( (  ",
+		"{a}      ) ) ",
+		"{b}  .{a}~~~~~~{b}. ",
+		"{b}  | {a}<3{b}   |]",
+		"{b}  |      | ",
+		"{b}   ======  ",
+	}},
+}
+
+// logoModes son los valores de logo que no son un logo de la colección
+var logoModes = []string{"random", "seasonal"}
+
+// findLogo busca un logo por nombre
+func findLogo(name string) (logoArt, bool) {
+	for _, l := range logos {
+		if l.name == name {
+			return l, true
+		}
+	}
+	return logoArt{}, false
+}
+
+// logoNames devuelve los nombres de la colección y los modos, para
+// validar el config y completar --logo
+func logoNames() []string {
+	var names []string
+	for _, l := range logos {
+		names = append(names, l.name)
+	}
+	return append(names, logoModes...)
+}
+
+// render arma las líneas con los colores; cada una termina con reset
+func (l logoArt) render(accent, body, reset string) []string {
+	r := strings.NewReplacer("{a}", accent, "{b}", body)
+	out := make([]string, len(l.lines))
+	for i, line := range l.lines {
+		out[i] = r.Replace(line) + reset
+	}
+	return out
+}
+
+// pickLogo resuelve logo (un nombre, "random" o "seasonal") a un logo de
+// la colección. "random" elige uno distinto en cada ejecución salvo que
+// logo_seed no sea 0: con la misma semilla sale siempre el mismo.
+// "seasonal" elige por la fecha y la hora de now, con la taza si no
+// corresponde ninguno
+func pickLogo(name string, seed int64, now time.Time) string {
+	switch name {
+	case "random":
+		src := rand.NewSource(now.UnixNano())
+		if seed != 0 {
+			src = rand.NewSource(seed)
+		}
+		return logos[rand.New(src).Intn(len(logos))].name
+	case "seasonal":
+		return seasonalLogo(now)
+	}
+	return name
+}
+
+// seasonalLogo busca el logo de la fecha (ej. "02-14"), si no el del mes
+// y si no el del momento del día
+func seasonalLogo(now time.Time) string {
+	day := "night"
+	if h := now.Hour(); h >= 5 && h < 12 {
+		day = "morning"
+	} else if h >= 5 && h < 20 {
+		day = ""
+	}
+	for _, tag := range []string{fmt.Sprintf("%02d-%02d", now.Month(), now.Day()), strings.ToLower(now.Month().String()), day} {
+		for _, l := range logos {
+			for _, t := range l.tags {
+				if tag != "" && t == tag {
+					return l.name
+				}
+			}
+		}
+	}
+	return logos[0].name
+}
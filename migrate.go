@@ -0,0 +1,456 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// neofetchModules traduce las funciones de "info" de neofetch a módulos
+var neofetchModules = map[string]string{
+	"title": "title", "distro": "os", "model": "host", "kernel": "kernel",
+	"uptime": "uptime", "packages": "packages", "shell": "shell",
+	"resolution": "display", "de": "de", "wm": "wm", "theme": "theme",
+	"icons": "icons", "cursor": "cursor", "font": "font", "term": "term",
+	"cpu": "cpu", "gpu": "gpu", "memory": "mem", "disk": "disk",
+	"battery": "battery", "local_ip": "ip", "public_ip": "public_ip",
+	"users": "users", "locale": "locale", "song": "media",
+	"cpu_usage": "cpu_usage", "line_break": "break",
+}
+
+// fastfetchModules traduce los "type" de los módulos de fastfetch
+var fastfetchModules = map[string]string{
+	"title": "title", "os": "os", "host": "host", "kernel": "kernel",
+	"uptime": "uptime", "packages": "packages", "shell": "shell",
+	"display": "display", "de": "de", "wm": "wm", "theme": "theme",
+	"icons": "icons", "cursor": "cursor", "font": "font", "terminal": "term",
+	"cpu": "cpu", "gpu": "gpu", "memory": "mem", "swap": "swap", "disk": "disk",
+	"battery": "battery", "localip": "ip", "publicip": "public_ip",
+	"users": "users", "locale": "locale", "media": "media", "player": "media",
+	"cpuusage": "cpu_usage", "board": "board", "bios": "bios",
+	"loadavg": "load", "processes": "procs", "initsystem": "init",
+	"datetime": "time", "weather": "weather", "sound": "sound",
+	"wifi": "wifi", "bluetooth": "bluetooth", "netio": "net_rate",
+	"physicaldisk": "drives", "break": "break",
+}
+
+// migration es el config que se va armando a partir del de la otra
+// herramienta. Lo que no tiene equivalente se anota en skipped
+type migration struct {
+	modules   []string
+	labels    map[string]string
+	colors    map[string]string
+	settings  [][2]string // clave y valor ya en TOML, en orden
+	custom    []customCommand
+	skipped   []string
+	hasModule map[string]bool
+}
+
+func newMigration() *migration {
+	return &migration{labels: map[string]string{}, colors: map[string]string{}, hasModule: map[string]bool{}}
+}
+
+// addModule agrega el módulo una sola vez (neofetch y fastfetch permiten
+// repetir) y guarda la etiqueta si no es la de cafetch
+func (m *migration) addModule(name, label string) {
+	if name == "break" {
+		if len(m.modules) > 0 && m.modules[len(m.modules)-1] != "break" {
+			m.modules = append(m.modules, "break")
+		}
+		return
+	}
+	if m.hasModule[name] {
+		return
+	}
+	m.hasModule[name] = true
+	m.modules = append(m.modules, name)
+	if label != "" && label != modules[name].Label {
+		m.labels[name] = label
+	}
+}
+
+// set guarda una opción con su valor ya escrito en TOML
+func (m *migration) set(key, value string) {
+	m.settings = append(m.settings, [2]string{key, value})
+}
+
+// sectionColor pone el mismo color a las etiquetas de todas las secciones
+func (m *migration) sectionColor(color string) {
+	for _, s := range []string{"system", "hardware", "network", "desktop", "custom"} {
+		m.colors[s] = color
+	}
+}
+
+// runMigrate lee el config de neofetch o fastfetch e imprime (o escribe
+// con -o) un config de cafetch equivalente
+func runMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	from := fs.String("from", "", "`tool` whose config is read: neofetch or fastfetch (by default from the file extension)")
+	output := fs.String("o", "", "write the config to `file` instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: cafetch migrate [--from neofetch|fastfetch] [-o file] [config]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	// El config puede ir antes de los flags: migrate config.conf -o out.toml
+	path := fs.Arg(0)
+	if fs.NArg() > 0 {
+		fs.Parse(fs.Args()[1:])
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	tool := *from
+	if tool == "" {
+		tool = "neofetch"
+		if ext := filepath.Ext(path); ext == ".jsonc" || ext == ".json" {
+			tool = "fastfetch"
+		}
+	}
+	if path == "" {
+		path = map[string]string{
+			"neofetch":  "~/.config/neofetch/config.conf",
+			"fastfetch": "~/.config/fastfetch/config.jsonc",
+		}[tool]
+	}
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: migrate:", err)
+		os.Exit(1)
+	}
+
+	m := newMigration()
+	switch tool {
+	case "neofetch":
+		m.fromNeofetch(data)
+	case "fastfetch":
+		err = m.fromFastfetch(data)
+	default:
+		err = fmt.Errorf("--from: unknown tool %q (use neofetch or fastfetch)", tool)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: migrate:", err)
+		os.Exit(1)
+	}
+
+	out := m.toml(tool, path)
+	// Se valida con el mismo parser del config para no dejar uno roto
+	doc, err := parseTOML(out)
+	if err == nil {
+		cfg := defaultConfig()
+		err = cfg.decode(doc)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: migrate: generated an invalid config:", err)
+		os.Exit(1)
+	}
+	for _, s := range m.skipped {
+		fmt.Fprintln(os.Stderr, "cafetch: migrate: skipped", s)
+	}
+	if *output == "" {
+		fmt.Print(out)
+		return
+	}
+	if err := os.WriteFile(expandHome(*output), []byte(out), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: migrate:", err)
+		os.Exit(1)
+	}
+}
+
+// neofetchInfo es una línea "info" de print_info, ej. info "OS" distro
+var neofetchInfo = regexp.MustCompile(`^info\s+(?:"([^"]*)"\s+|'([^']*)'\s+)?(\w+)`)
+
+// neofetchVar es una asignación de primer nivel, ej. separator=":" o colors=(4 6 1 8 8 6)
+var neofetchVar = regexp.MustCompile(`^(\w+)=(.*)$`)
+
+// fromNeofetch lee el config de neofetch, que es un script de bash: los
+// módulos son las líneas "info" de print_info (las comentadas no cuentan)
+// y las opciones son variables
+func (m *migration) fromNeofetch(data []byte) {
+	vars := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if match := neofetchInfo.FindStringSubmatch(line); match != nil {
+			label, fn := match[1]+match[2], match[3]
+			switch name, ok := neofetchModules[fn]; {
+			case ok:
+				m.addModule(name, label)
+			case fn == "underline" || fn == "cols":
+				// El subrayado del título y la paleta no existen en cafetch
+			default:
+				m.skipped = append(m.skipped, "info "+fn)
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "prin ") {
+			m.skipped = append(m.skipped, line)
+			continue
+		}
+		if match := neofetchVar.FindStringSubmatch(line); match != nil {
+			vars[match[1]] = unquoteShell(match[2])
+		}
+	}
+
+	if sep := vars["separator"]; sep != "" && sep != ":" {
+		m.set("separator", strconv.Quote(sep))
+	}
+	// colors=(title @ underline subtitle colon info): cafetch colorea el
+	// título y las etiquetas, que en neofetch son title y subtitle
+	if c := strings.Fields(strings.Trim(vars["colors"], "()")); len(c) >= 4 {
+		if _, ok := colorCode(c[0], false); ok {
+			m.colors["title"] = "bold " + c[0]
+		}
+		if _, ok := colorCode(c[3], false); ok {
+			m.sectionColor(c[3])
+		}
+	}
+	if disks := strings.Fields(strings.Trim(vars["disk_show"], "()")); len(disks) > 0 {
+		for i, d := range disks {
+			disks[i] = strings.Trim(d, `"'`)
+		}
+		m.set("disks", tomlList(disks))
+	}
+	if host := vars["public_ip_host"]; host != "" && strings.HasPrefix(host, "https://") {
+		m.set("public_ip_url", strconv.Quote(host))
+	}
+	switch src := vars["image_source"]; {
+	case vars["image_backend"] == "off":
+		m.set("logo", "false")
+	case strings.HasSuffix(strings.ToLower(src), ".png"):
+		m.set("logo_image", strconv.Quote(src))
+	case src != "" && src != "auto" && src != "ascii" && src != "wallpaper":
+		m.set("logo_file", strconv.Quote(src))
+	}
+}
+
+// unquoteShell quita las comillas de un valor de bash y los comentarios al final
+func unquoteShell(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') {
+		if end := strings.IndexByte(v[1:], v[0]); end >= 0 {
+			return v[1 : end+1]
+		}
+	}
+	if i := strings.Index(v, " #"); i >= 0 && !strings.HasPrefix(v, "(") {
+		v = v[:i]
+	}
+	return strings.Trim(v, `"'`)
+}
+
+// fastfetchConfig es lo que se usa del config de fastfetch
+type fastfetchConfig struct {
+	Logo    json.RawMessage `json:"logo"`
+	Display struct {
+		Separator string `json:"separator"`
+		Color     struct {
+			Keys  string `json:"keys"`
+			Title string `json:"title"`
+		} `json:"color"`
+	} `json:"display"`
+	Modules []json.RawMessage `json:"modules"`
+}
+
+// fastfetchModule es un módulo escrito como objeto, ej.
+// {"type": "command", "key": "K8s", "text": "kubectl config current-context"}
+type fastfetchModule struct {
+	Type    string `json:"type"`
+	Key     string `json:"key"`
+	Text    string `json:"text"`
+	Folders string `json:"folders"`
+}
+
+// fromFastfetch lee el config JSONC de fastfetch. Los módulos "command" se
+// convierten en [[custom]]
+func (m *migration) fromFastfetch(data []byte) error {
+	var cfg fastfetchConfig
+	if err := json.Unmarshal(stripJSONC(data), &cfg); err != nil {
+		return fmt.Errorf("invalid fastfetch config: %w", err)
+	}
+
+	for _, raw := range cfg.Modules {
+		var mod fastfetchModule
+		if json.Unmarshal(raw, &mod.Type) != nil && json.Unmarshal(raw, &mod) != nil {
+			continue
+		}
+		typ := strings.ToLower(mod.Type)
+		switch name, ok := fastfetchModules[typ]; {
+		case typ == "command" && mod.Text != "":
+			label := mod.Key
+			if label == "" {
+				label = "Command"
+			}
+			c := customCommand{Label: label, Command: mod.Text}
+			c.Name = strings.ReplaceAll(strings.ToLower(label), " ", "_")
+			if _, exists := modules[c.Name]; exists || m.hasModule[c.Name] {
+				c.Name = fmt.Sprintf("custom%d", len(m.custom)+1)
+			}
+			m.custom = append(m.custom, c)
+			m.addModule(c.Name, "")
+		case ok:
+			m.addModule(name, mod.Key)
+			if typ == "disk" && mod.Folders != "" {
+				m.set("disks", tomlList(strings.Split(mod.Folders, ":")))
+			}
+		case typ == "separator" || typ == "colors":
+		default:
+			m.skipped = append(m.skipped, "module "+mod.Type)
+		}
+	}
+
+	// fastfetch incluye el espacio antes del valor y cafetch lo agrega
+	if sep := strings.TrimRight(cfg.Display.Separator, " "); sep != "" && sep != ":" {
+		m.set("separator", strconv.Quote(sep))
+	}
+	if c := fastfetchColor(cfg.Display.Color.Keys); c != "" {
+		m.sectionColor(c)
+	} else if cfg.Display.Color.Keys != "" {
+		m.skipped = append(m.skipped, "key color "+cfg.Display.Color.Keys)
+	}
+	if c := fastfetchColor(cfg.Display.Color.Title); c != "" {
+		m.colors["title"] = c
+	} else if cfg.Display.Color.Title != "" {
+		m.skipped = append(m.skipped, "title color "+cfg.Display.Color.Title)
+	}
+
+	// logo es un nombre de distro o un objeto con source y type
+	var logo struct {
+		Source string `json:"source"`
+		Type   string `json:"type"`
+	}
+	if json.Unmarshal(cfg.Logo, &logo.Source) != nil {
+		json.Unmarshal(cfg.Logo, &logo)
+	}
+	switch {
+	case logo.Type == "none" || logo.Source == "none":
+		m.set("logo", "false")
+	case strings.HasSuffix(strings.ToLower(logo.Source), ".png"):
+		m.set("logo_image", strconv.Quote(logo.Source))
+	case logo.Type == "file" || logo.Type == "file-raw":
+		m.set("logo_file", strconv.Quote(logo.Source))
+	case logo.Source != "":
+		m.skipped = append(m.skipped, "logo "+logo.Source)
+	}
+	return nil
+}
+
+// fastfetchColor devuelve el color de fastfetch si cafetch lo entiende
+// igual. Los números de fastfetch son códigos SGR ("94") y no de la paleta
+// de 256, así que no se copian
+func fastfetchColor(c string) string {
+	for _, part := range strings.Fields(c) {
+		if _, err := strconv.Atoi(part); err == nil {
+			return ""
+		}
+	}
+	if _, ok := colorCode(c, false); !ok {
+		return ""
+	}
+	return c
+}
+
+// stripJSONC quita los comentarios // y /* */ y las comas antes de un
+// cierre, que JSONC permite y encoding/json no
+func stripJSONC(data []byte) []byte {
+	var out []byte
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			if c == '\\' && i+1 < len(data) {
+				out = append(out, c, data[i+1])
+				i++
+				continue
+			}
+			if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+			continue
+		case c == '}' || c == ']':
+			// Coma final: se borra la última coma si solo hay espacios después
+			trimmed := bytes.TrimRight(out, " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out = append(trimmed[:len(trimmed)-1], out[len(trimmed):]...)
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// tomlList escribe un array de strings de TOML
+func tomlList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, s := range items {
+		quoted[i] = strconv.Quote(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// toml escribe el config migrado
+func (m *migration) toml(tool, path string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# cafetch config migrated from %s (%s)\n\n", tool, path)
+	mods := m.modules
+	for len(mods) > 0 && mods[len(mods)-1] == "break" {
+		mods = mods[:len(mods)-1]
+	}
+	if len(mods) > 0 {
+		b.WriteString("modules = [\n")
+		for _, name := range mods {
+			fmt.Fprintf(&b, "    %q,\n", name)
+		}
+		b.WriteString("]\n")
+	}
+	for _, s := range m.settings {
+		fmt.Fprintf(&b, "%s = %s\n", s[0], s[1])
+	}
+	for _, table := range []struct {
+		name   string
+		values map[string]string
+	}{{"labels", m.labels}, {"colors", m.colors}} {
+		if len(table.values) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(table.values))
+		for k := range table.values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(&b, "\n[%s]\n", table.name)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s = %s\n", k, strconv.Quote(table.values[k]))
+		}
+	}
+	for _, c := range m.custom {
+		fmt.Fprintf(&b, "\n[[custom]]\nname = %s\nlabel = %s\ncommand = %s\n", strconv.Quote(c.Name), strconv.Quote(c.Label), strconv.Quote(c.Command))
+	}
+	return b.String()
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// module describe una línea de la salida
+type module struct {
+	Label   string                               // etiqueta por defecto, vacía para líneas sin etiqueta
+	Section string                               // sección que le da el color a la etiqueta (o a la línea si no tiene)
+	Value   func(info sysinfo.SystemInfo) string // valor a mostrar, "" oculta la línea
+
+	// Lines reemplaza a Value en módulos que pueden ocupar varias líneas
+	// (varias GPUs, baterías...). Si hay más de una se numeran las etiquetas
+	Lines func(info sysinfo.SystemInfo) []string
+
+	// Percents devuelve el porcentaje de uso de cada línea, para dibujar las
+	// barras cuando están activadas
+	Percents func(info sysinfo.SystemInfo) []float64
+
+	// Pairs reemplaza a Value y Lines en módulos donde cada línea trae su
+	// propia etiqueta (los plugins). Las que no traen usan Label
+	Pairs func(info sysinfo.SystemInfo) []labeledValue
+
+	// Details son las líneas extra que se ven al expandir el módulo en la
+	// vista interactiva (--tui)
+	Details func(info sysinfo.SystemInfo) []string
+}
+
+// labeledValue es una línea con su propia etiqueta
+type labeledValue struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// values devuelve las líneas no vacías del módulo, con su barra de uso si
+// el módulo tiene una y están activadas
+func (m module) values(info sysinfo.SystemInfo, cfg config) []string {
+	raw := []string{}
+	if m.Lines == nil {
+		raw = append(raw, m.Value(info))
+	} else {
+		raw = m.Lines(info)
+	}
+	var percents []float64
+	if cfg.Bars && m.Percents != nil {
+		percents = m.Percents(info)
+	}
+
+	var out []string
+	for n, v := range raw {
+		if v == "" {
+			continue
+		}
+		if n < len(percents) {
+			v += " " + cfg.bar(percents[n])
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// modules son todos los módulos disponibles por nombre
+var modules = map[string]module{
+	"title": {Section: "title", Value: func(i sysinfo.SystemInfo) string { return i.User + "@" + i.Host }},
+	"version": {Section: "version", Value: func(i sysinfo.SystemInfo) string {
+		b := currentBuild()
+		return "cafetch " + b.Version + " (Go " + b.Go + ")"
+	}},
+	"os":   {Label: "OS", Section: "system", Value: func(i sysinfo.SystemInfo) string { return i.OS }},
+	"host": {Label: "Host", Section: "system", Value: func(i sysinfo.SystemInfo) string { return i.Model }},
+	"virt": {Label: "Virtualization", Section: "system", Value: func(i sysinfo.SystemInfo) string { return i.Virt.VM }},
+	"container": {Label: "Container", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		return i.Virt.Container
+	}},
+	"containers": {Label: "Containers", Section: "system", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, c := range i.Containers {
+			lines = append(lines, formatContainers(c))
+		}
+		return lines
+	}},
+	"kernel": {Label: "Kernel", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		// Los motivos del taint se ven en los detalles de --tui y en kernel_build
+		if i.KernelInfo.Tainted != 0 {
+			return i.Kernel + " (tainted)"
+		}
+		return i.Kernel
+	}, Details: kernelDetails},
+	"kernel_build": {Label: "Kernel build", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		return formatKernelBuild(i.KernelInfo)
+	}},
+	"init": {Label: "Init", Section: "system", Value: func(i sysinfo.SystemInfo) string { return i.Init }},
+	"arch": {Label: "Arch", Section: "system", Value: func(i sysinfo.SystemInfo) string { return i.Arch }},
+	"security": {Label: "Security", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		return formatSecurity(i.Security)
+	}},
+	"firewall": {Label: "Firewall", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		return formatFirewall(i.Firewall)
+	}},
+	"uptime": {Label: "Uptime", Section: "system", Value: func(i sysinfo.SystemInfo) string { return formatUptimeAs(i.Uptime, uptimeFormat) }},
+	"boot":   {Label: "Boot", Section: "system", Value: func(i sysinfo.SystemInfo) string { return formatBootTime(i.Boot) }},
+	"load":   {Label: "Load", Section: "system", Value: func(i sysinfo.SystemInfo) string { return formatLoad(i.Load, i.Processes) }},
+	"procs": {Label: "Processes", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		return formatProcesses(i.Processes)
+	}},
+	"users": {Label: "Users", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		return formatSessions(i.Sessions)
+	}, Details: sessionDetails},
+	"packages": {Label: "Packages", Section: "system", Value: func(i sysinfo.SystemInfo) string {
+		return formatPackages(i.Packages)
+	}},
+	"cpu": {Label: "CPU", Section: "hardware", Value: func(i sysinfo.SystemInfo) string {
+		return formatCPU(i.CPU)
+	}, Details: cpuDetails},
+	"cpu_usage": {Label: "CPU usage", Section: "hardware", Value: func(i sysinfo.SystemInfo) string {
+		if i.CPUUsage == nil {
+			return ""
+		}
+		return fmt.Sprintf("%.1f%%", *i.CPUUsage)
+	}, Percents: func(i sysinfo.SystemInfo) []float64 {
+		if i.CPUUsage == nil {
+			return nil
+		}
+		return []float64{*i.CPUUsage}
+	}},
+	"board": {Label: "Board", Section: "hardware", Value: func(i sysinfo.SystemInfo) string {
+		return strings.TrimSpace(i.Board.Vendor + " " + i.Board.Name)
+	}},
+	"bios": {Label: "BIOS", Section: "hardware", Value: func(i sysinfo.SystemInfo) string { return formatBIOS(i.Board) }},
+	"gpu": {Label: "GPU", Section: "hardware", Lines: func(i sysinfo.SystemInfo) []string {
+		drivers := gpuDrivers(i)
+		lines := make([]string, len(i.GPUs))
+		for n, gpu := range i.GPUs {
+			lines[n] = formatGPU(gpu, drivers[n])
+		}
+		return lines
+	}, Details: gpuDetails},
+	"vram": {Label: "VRAM", Section: "hardware", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, d := range i.GPUDrivers {
+			if d.VRAM.Used > 0 {
+				lines = append(lines, formatUsage(d.VRAM))
+			}
+		}
+		return lines
+	}, Percents: func(i sysinfo.SystemInfo) []float64 {
+		var percents []float64
+		for _, d := range i.GPUDrivers {
+			if d.VRAM.Used > 0 {
+				percents = append(percents, d.VRAM.Percent())
+			}
+		}
+		return percents
+	}},
+	"display": {Label: "Display", Section: "hardware", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, d := range i.Displays {
+			lines = append(lines, formatDisplay(d))
+		}
+		return lines
+	}},
+	"sound": {Label: "Sound", Section: "hardware", Value: func(i sysinfo.SystemInfo) string {
+		return formatSound(i.Sound)
+	}},
+	"mem": {Label: "Mem", Section: "hardware", Value: func(i sysinfo.SystemInfo) string {
+		return formatUsage(i.Memory)
+	}, Percents: func(i sysinfo.SystemInfo) []float64 { return []float64{i.Memory.Percent()} }},
+	"swap": {Label: "Swap", Section: "hardware", Value: func(i sysinfo.SystemInfo) string {
+		// Sin swap la línea se oculta
+		if i.Swap.Total == 0 {
+			return ""
+		}
+		return formatUsage(i.Swap)
+	}},
+	"disk": {Label: "Disk", Section: "hardware", Lines: func(i sysinfo.SystemInfo) []string {
+		// Solo "/" se ve igual que siempre; con varios discos se agrega la ruta
+		showPath := len(i.Disks) > 1 || (len(i.Disks) == 1 && i.Disks[0].Path != "/")
+		var lines []string
+		for _, m := range i.Disks {
+			lines = append(lines, formatMount(m, showPath))
+		}
+		return lines
+	}, Percents: func(i sysinfo.SystemInfo) []float64 {
+		var percents []float64
+		for _, m := range i.Disks {
+			percents = append(percents, m.Percent())
+		}
+		return percents
+	}, Details: diskDetails},
+	"drives": {Label: "Drive", Section: "hardware", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, d := range i.Drives {
+			lines = append(lines, formatDrive(d))
+		}
+		return lines
+	}},
+	"battery": {Label: "Battery", Section: "hardware", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, b := range i.Batteries {
+			lines = append(lines, formatBattery(b))
+		}
+		return lines
+	}},
+	"net": {Label: "Net", Section: "network", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, ni := range i.Network {
+			lines = append(lines, formatInterface(ni))
+		}
+		return lines
+	}},
+	"bluetooth": {Label: "Bluetooth", Section: "hardware", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, d := range i.Bluetooth {
+			lines = append(lines, formatBluetooth(d))
+		}
+		return lines
+	}},
+	"temps": {Label: "Temp", Section: "hardware", Value: func(i sysinfo.SystemInfo) string {
+		return formatTemperatures(i.Temperatures)
+	}},
+	"wifi": {Label: "Wi-Fi", Section: "network", Lines: func(i sysinfo.SystemInfo) []string {
+		var lines []string
+		for _, w := range i.WiFi {
+			lines = append(lines, formatWiFi(w))
+		}
+		return lines
+	}},
+	"net_rate": {Label: "Traffic", Section: "network", Value: func(i sysinfo.SystemInfo) string {
+		return formatNetRate(i.NetRate)
+	}},
+	"ip":        {Label: "IP", Section: "network", Value: func(i sysinfo.SystemInfo) string { return i.IP }},
+	"ipv6":      {Label: "IPv6", Section: "network", Value: func(i sysinfo.SystemInfo) string { return i.IPv6 }},
+	"public_ip": {Label: "Public", Section: "network", Value: func(i sysinfo.SystemInfo) string { return i.PublicIP }},
+	"shell":     {Label: "Shell", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.Shell }},
+	"de":        {Label: "DE", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.DE }},
+	"wm":        {Label: "WM", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.WM }},
+	"theme":     {Label: "Theme", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return formatTheme(i.Theme) }},
+	"icons":     {Label: "Icons", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.Theme.Icons }},
+	"cursor":    {Label: "Cursor", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.Theme.Cursor }},
+	"font":      {Label: "Font", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.Theme.Font }},
+	"term":      {Label: "Term", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.Term }},
+	"time": {Label: "Time", Section: "desktop", Value: func(i sysinfo.SystemInfo) string {
+		// Con la abreviatura de la zona para que no sea ambigua
+		return time.Now().Format("2006-01-02 15:04:05 MST")
+	}},
+	"media": {Label: "Playing", Section: "desktop", Value: func(i sysinfo.SystemInfo) string {
+		return formatMedia(i.Media)
+	}},
+	"weather": {Label: "Weather", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.Weather }},
+	"timezone": {Label: "Timezone", Section: "desktop", Value: func(i sysinfo.SystemInfo) string {
+		return formatTimezone(i.Timezone, i.TZOffset)
+	}},
+	"locale": {Label: "Locale", Section: "desktop", Value: func(i sysinfo.SystemInfo) string { return i.Locale }},
+}
+
+// defaultModules es el orden por defecto de la salida
+var defaultModules = []string{
+	"title", "version", "break",
+	"os", "host", "virt", "container", "kernel", "init", "arch", "uptime", "load", "procs", "packages", "break",
+	"cpu", "gpu", "display", "sound", "mem", "swap", "disk", "battery", "temps", "break",
+	"net", "public_ip", "break",
+	"shell", "de", "wm", "theme", "icons", "cursor", "font", "term", "time", "timezone", "locale", "weather",
+}
+
+// entry es una línea ya resuelta, antes de alinear. color es la secuencia ANSI
+type entry struct {
+	label, color, value string
+}
+
+// entries resuelve las líneas del módulo name con la etiqueta y el color
+// del config. Con varias líneas sin etiqueta propia se numeran
+func (m module) entries(name string, info sysinfo.SystemInfo, cfg config) []entry {
+	label, color := cfg.label(name), cfg.color(name, m.Section)
+
+	var out []entry
+	if m.Pairs != nil {
+		for _, p := range m.Pairs(info) {
+			if p.Value == "" {
+				continue
+			}
+			e := entry{label: p.Label, color: color, value: cfg.redactText(p.Value)}
+			if e.label == "" {
+				e.label = label
+			}
+			out = append(out, e)
+		}
+		return out
+	}
+
+	values := m.values(info, cfg)
+	for n, value := range values {
+		e := entry{label: label, color: color, value: cfg.redactText(value)}
+		if len(values) > 1 && label != "" {
+			e.label = fmt.Sprintf("%s %d", label, n+1)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// entryGroups resuelve las líneas visibles de los módulos, agrupadas como
+// las separan los "break" (sin grupos vacíos)
+func entryGroups(info sysinfo.SystemInfo, cfg config) [][]entry {
+	var groups [][]entry
+	var group []entry
+	for _, name := range cfg.enabledModules() {
+		if name == "break" {
+			if len(group) > 0 {
+				groups = append(groups, group)
+			}
+			group = nil
+			continue
+		}
+		mod, ok := modules[name]
+		if !ok {
+			continue
+		}
+		es := mod.entries(name, info, cfg)
+		if len(es) == 0 {
+			debugLog.Debug("module hidden: no value", "module", name)
+		}
+		group = append(group, es...)
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// buildLines arma las líneas de info según el config. Las etiquetas se
+// alinean dentro de cada grupo (los grupos se separan con "break")
+func buildLines(info sysinfo.SystemInfo, cfg config) []string {
+	// Con una plantilla las líneas salen de ahí (ya se validó al leer el config)
+	if cfg.Format != "" {
+		parts, _ := parseFormat(cfg.Format)
+		var lines []string
+		for _, line := range expandFormat(parts, info, cfg) {
+			lines = append(lines, cfg.fit("", line)...)
+		}
+		return lines
+	}
+
+	reset := cfg.reset()
+	var lines []string
+	for gi, g := range entryGroups(info, cfg) {
+		if gi > 0 {
+			lines = append(lines, "")
+		}
+
+		// Ancho de la etiqueta más larga del grupo
+		width := 0
+		for _, e := range g {
+			if n := utf8.RuneCountInString(e.label); n > width {
+				width = n
+			}
+		}
+
+		for _, e := range g {
+			if e.label == "" {
+				lines = append(lines, cfg.fit("", e.color+e.value+reset)...)
+				continue
+			}
+			pad := strings.Repeat(" ", width-utf8.RuneCountInString(e.label))
+			lines = append(lines, cfg.fit(e.color+e.label+cfg.Separator+reset+pad+" ", e.value)...)
+		}
+	}
+	return lines
+}
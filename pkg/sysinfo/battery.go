@@ -0,0 +1,9 @@
+package sysinfo
+
+// Battery guarda el estado de una batería
+type Battery struct {
+	Name     string  `json:"name"`
+	Capacity int     `json:"capacity_percent"`
+	Status   string  `json:"status"`                   // Charging, Discharging, Full...
+	Health   float64 `json:"health_percent,omitempty"` // capacidad actual vs la de fábrica
+}
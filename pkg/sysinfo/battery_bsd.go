@@ -0,0 +1,67 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"runtime"
+	"strconv"
+)
+
+// getBatteries lee la batería combinada que informa ACPI (FreeBSD/DragonFly)
+// o apm (OpenBSD). En NetBSD los sensores están en envstat y no se leen
+func getBatteries(tr *tracer) []Battery {
+	switch runtime.GOOS {
+	case "freebsd", "dragonfly":
+		return acpiBattery()
+	case "openbsd":
+		return apmBattery(tr)
+	}
+	return nil
+}
+
+// acpiBattery usa hw.acpi.battery.life (-1 sin batería) y hw.acpi.battery.state,
+// una máscara donde 1 es descargando y 2 cargando
+func acpiBattery() []Battery {
+	b := sysctlRaw("hw.acpi.battery.life", 4)
+	if b == nil {
+		return nil
+	}
+	life := int32(binary.LittleEndian.Uint32(b[:4]))
+	if life < 0 || life > 100 {
+		return nil
+	}
+
+	status := "Full"
+	state := sysctlUint("hw.acpi.battery.state")
+	switch {
+	case state&2 != 0:
+		status = "Charging"
+	case state&1 != 0:
+		status = "Discharging"
+	case life < 100:
+		status = "Not charging"
+	}
+	return []Battery{{Name: "BAT0", Capacity: int(life), Status: status}}
+}
+
+// apmBattery usa apm: -l da el porcentaje, -b el estado (3 cargando,
+// 4 sin batería) y -a si está enchufada
+func apmBattery(tr *tracer) []Battery {
+	life, err := strconv.Atoi(tr.runCmd("apm", "-l"))
+	state := tr.runCmd("apm", "-b")
+	if err != nil || life < 0 || life > 100 || state == "4" {
+		return nil
+	}
+
+	status := "Discharging"
+	switch {
+	case state == "3":
+		status = "Charging"
+	case tr.runCmd("apm", "-a") == "1" && life == 100:
+		status = "Full"
+	case tr.runCmd("apm", "-a") == "1":
+		status = "Not charging"
+	}
+	return []Battery{{Name: "BAT0", Capacity: life, Status: status}}
+}
@@ -0,0 +1,57 @@
+//go:build darwin
+
+package sysinfo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pmsetLine matchea " -InternalBattery-0 (id=4653155)	87%; discharging; 4:35 remaining"
+var pmsetLine = regexp.MustCompile(`-(\S+).*?\t(\d+)%; ([^;]+)`)
+
+// ioregValue matchea líneas de ioreg como `"DesignCapacity" = 5103`
+var ioregValue = regexp.MustCompile(`"(\w+)" = (\d+)`)
+
+// getBatteries lee el estado de pmset y la salud de ioreg
+func getBatteries(tr *tracer) []Battery {
+	out := tr.runCmd("pmset", "-g", "batt")
+	if out == "N/A" {
+		return nil
+	}
+
+	var batteries []Battery
+	for _, line := range strings.Split(out, "\n") {
+		m := pmsetLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		capacity, _ := strconv.Atoi(m[2])
+		batteries = append(batteries, Battery{
+			Name:     m[1],
+			Capacity: capacity,
+			Status:   capitalize(strings.TrimSpace(m[3])),
+		})
+	}
+
+	// La salud es la capacidad máxima real contra la de diseño
+	if len(batteries) == 1 {
+		values := map[string]float64{}
+		for _, m := range ioregValue.FindAllStringSubmatch(tr.runCmd("ioreg", "-rn", "AppleSmartBattery"), -1) {
+			values[m[1]], _ = strconv.ParseFloat(m[2], 64)
+		}
+		if max, design := values["AppleRawMaxCapacity"], values["DesignCapacity"]; max > 0 && design > 0 {
+			batteries[0].Health = max / design * 100
+		}
+	}
+	return batteries
+}
+
+// capitalize pasa "discharging" a "Discharging" para que coincida con Linux
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
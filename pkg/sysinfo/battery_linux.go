@@ -0,0 +1,41 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// getBatteries lee todas las baterías de /sys/class/power_supply/BAT*.
+// En equipos sin batería devuelve nil
+func getBatteries(tr *tracer) []Battery {
+	paths, _ := filepath.Glob("/sys/class/power_supply/BAT*")
+	sort.Strings(paths)
+
+	var batteries []Battery
+	for _, path := range paths {
+		capacity, err := strconv.Atoi(tr.readTrim(filepath.Join(path, "capacity")))
+		if err != nil {
+			continue
+		}
+		bat := Battery{
+			Name:     filepath.Base(path),
+			Capacity: capacity,
+			Status:   tr.readTrim(filepath.Join(path, "status")),
+		}
+
+		// Según el driver la capacidad viene en energía (µWh) o en carga (µAh)
+		for _, prefix := range []string{"energy", "charge"} {
+			full := tr.readUint(filepath.Join(path, prefix+"_full"))
+			design := tr.readUint(filepath.Join(path, prefix+"_full_design"))
+			if full > 0 && design > 0 {
+				bat.Health = float64(full) / float64(design) * 100
+				break
+			}
+		}
+		batteries = append(batteries, bat)
+	}
+	return batteries
+}
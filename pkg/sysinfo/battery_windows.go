@@ -0,0 +1,38 @@
+//go:build windows
+
+package sysinfo
+
+import "unsafe"
+
+// systemPowerStatus es SYSTEM_POWER_STATUS de la API de Windows
+type systemPowerStatus struct {
+	ACLineStatus        byte
+	BatteryFlag         byte
+	BatteryLifePercent  byte
+	SystemStatusFlag    byte
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
+// getBatteries usa GetSystemPowerStatus, que informa una sola batería combinada
+func getBatteries(tr *tracer) []Battery {
+	var st systemPowerStatus
+	if ok, _, _ := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&st))); ok == 0 {
+		return nil
+	}
+	// 128 = sin batería, 255 = estado desconocido
+	if st.BatteryFlag&128 != 0 || st.BatteryFlag == 255 || st.BatteryLifePercent > 100 {
+		return nil
+	}
+
+	status := "Discharging"
+	switch {
+	case st.BatteryFlag&8 != 0:
+		status = "Charging"
+	case st.ACLineStatus == 1 && st.BatteryLifePercent == 100:
+		status = "Full"
+	case st.ACLineStatus == 1:
+		status = "Not charging"
+	}
+	return []Battery{{Name: "BAT0", Capacity: int(st.BatteryLifePercent), Status: status}}
+}
@@ -0,0 +1,7 @@
+package sysinfo
+
+// BluetoothDevice es un dispositivo Bluetooth conectado
+type BluetoothDevice struct {
+	Name    string `json:"name"`
+	Battery int    `json:"battery_percent,omitempty"` // 0 si el dispositivo no la informa
+}
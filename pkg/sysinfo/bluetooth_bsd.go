@@ -0,0 +1,9 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+// getBluetooth no está implementado en los BSD: no hay un servicio común
+// que sepa qué dispositivos están conectados
+func getBluetooth(tr *tracer) []BluetoothDevice {
+	return nil
+}
@@ -0,0 +1,44 @@
+//go:build darwin
+
+package sysinfo
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// getBluetooth lee los dispositivos conectados de "system_profiler
+// SPBluetoothDataType". Los AirPods informan la batería de cada auricular;
+// se muestra la más baja
+func getBluetooth(tr *tracer) []BluetoothDevice {
+	var profile struct {
+		Data []struct {
+			Connected []map[string]map[string]json.RawMessage `json:"device_connected"`
+		} `json:"SPBluetoothDataType"`
+	}
+	if json.Unmarshal([]byte(tr.runCmd("system_profiler", "-json", "SPBluetoothDataType")), &profile) != nil {
+		return nil
+	}
+
+	var devices []BluetoothDevice
+	for _, d := range profile.Data {
+		for _, entry := range d.Connected {
+			for name, props := range entry {
+				dev := BluetoothDevice{Name: name}
+				for key, v := range props {
+					// "device_batteryLevelMain", "device_batteryLevelLeft"... como "80%"
+					var s string
+					if !strings.HasPrefix(key, "device_batteryLevel") || json.Unmarshal(v, &s) != nil {
+						continue
+					}
+					if pct, err := strconv.Atoi(strings.TrimSuffix(s, "%")); err == nil && (dev.Battery == 0 || pct < dev.Battery) {
+						dev.Battery = pct
+					}
+				}
+				devices = append(devices, dev)
+			}
+		}
+	}
+	return devices
+}
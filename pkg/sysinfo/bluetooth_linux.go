@@ -0,0 +1,93 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// getBluetooth lista los dispositivos conectados según BlueZ. Sin
+// adaptador (nada en /sys/class/bluetooth) no corre ningún comando
+func getBluetooth(tr *tracer) []BluetoothDevice {
+	if adapters, _ := filepath.Glob("/sys/class/bluetooth/hci*"); len(adapters) == 0 {
+		return nil
+	}
+	if devices, ok := bluezDevices(tr); ok {
+		return devices
+	}
+	return bluetoothctlDevices(tr)
+}
+
+// busValue es un valor de D-Bus como lo imprime "busctl --json=short"
+type busValue struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// bluezDevices pide a BlueZ todos sus objetos por D-Bus con busctl. Cada
+// dispositivo es un /org/bluez/hciN/dev_XX con la interfaz Device1 y, si
+// informa la batería, Battery1
+func bluezDevices(tr *tracer) ([]BluetoothDevice, bool) {
+	out := tr.runCmd("busctl", "--system", "--json=short", "call", "org.bluez", "/",
+		"org.freedesktop.DBus.ObjectManager", "GetManagedObjects")
+	var reply struct {
+		Data []map[string]map[string]map[string]busValue `json:"data"`
+	}
+	if json.Unmarshal([]byte(out), &reply) != nil || len(reply.Data) == 0 {
+		return nil, false
+	}
+
+	var devices []BluetoothDevice
+	for _, ifaces := range reply.Data[0] {
+		dev, ok := ifaces["org.bluez.Device1"]
+		if !ok {
+			continue
+		}
+		var connected bool
+		json.Unmarshal(dev["Connected"].Data, &connected)
+		if !connected {
+			continue
+		}
+		// Alias es el nombre que eligió el usuario; si no, el del dispositivo
+		d := BluetoothDevice{}
+		json.Unmarshal(dev["Alias"].Data, &d.Name)
+		if d.Name == "" {
+			json.Unmarshal(dev["Name"].Data, &d.Name)
+		}
+		json.Unmarshal(ifaces["org.bluez.Battery1"]["Percentage"].Data, &d.Battery)
+		devices = append(devices, d)
+	}
+	// El orden de un mapa cambia en cada ejecución
+	sort.Slice(devices, func(a, b int) bool { return devices[a].Name < devices[b].Name })
+	return devices, true
+}
+
+// bluetoothctlDevices es la alternativa sin busctl. "devices Connected"
+// existe desde BlueZ 5.65 e imprime "Device AA:BB:CC:DD:EE:FF Nombre"
+func bluetoothctlDevices(tr *tracer) []BluetoothDevice {
+	out := tr.runCmd("bluetoothctl", "devices", "Connected")
+	if out == "N/A" {
+		return nil
+	}
+	var devices []BluetoothDevice
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, " ", 3)
+		if len(f) != 3 || f[0] != "Device" {
+			continue
+		}
+		d := BluetoothDevice{Name: f[2]}
+		// "Battery Percentage: 0x50 (80)"
+		for _, info := range strings.Split(tr.runCmd("bluetoothctl", "info", f[1]), "\n") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(info), "Battery Percentage: "); ok {
+				_, pct, _ := strings.Cut(v, "(")
+				d.Battery, _ = strconv.Atoi(strings.TrimSuffix(pct, ")"))
+			}
+		}
+		devices = append(devices, d)
+	}
+	return devices
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package sysinfo
+
+// getBluetooth no está implementado en Windows: la lista de dispositivos
+// conectados y su batería solo están en la API de WinRT
+func getBluetooth(tr *tracer) []BluetoothDevice {
+	return nil
+}
@@ -0,0 +1,27 @@
+package sysinfo
+
+import "time"
+
+// Board es la placa madre y el firmware según el SMBIOS. Los campos que el
+// fabricante deja con valores de relleno quedan vacíos
+type Board struct {
+	Vendor      string `json:"vendor,omitempty"`       // fabricante de la placa, ej. "ASUSTeK COMPUTER INC."
+	Name        string `json:"name,omitempty"`         // modelo de la placa, ej. "PRIME B550-PLUS"
+	BIOSVendor  string `json:"bios_vendor,omitempty"`  // ej. "American Megatrends Inc."
+	BIOSVersion string `json:"bios_version,omitempty"` // ej. "2803"
+	BIOSDate    string `json:"bios_date,omitempty"`    // fecha del firmware, ej. "2022-04-14"
+	Firmware    string `json:"firmware,omitempty"`     // "UEFI" o "BIOS", vacío si no se sabe
+}
+
+// newBoard arma el Board limpiando los valores de relleno. El SMBIOS
+// guarda la fecha como "04/14/2022"; se pasa a ISO si tiene esa forma
+func newBoard(vendor, name, biosVendor, biosVersion, biosDate string) Board {
+	b := Board{
+		Vendor: cleanDMI(vendor), Name: cleanDMI(name),
+		BIOSVendor: cleanDMI(biosVendor), BIOSVersion: cleanDMI(biosVersion), BIOSDate: cleanDMI(biosDate),
+	}
+	if t, err := time.Parse("01/02/2006", b.BIOSDate); err == nil {
+		b.BIOSDate = t.Format("2006-01-02")
+	}
+	return b
+}
@@ -0,0 +1,22 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import "runtime"
+
+// getBoard lee la placa y el firmware del SMBIOS: del kenv en FreeBSD y
+// DragonFly, por sysctl en NetBSD. OpenBSD no expone la placa
+func getBoard(tr *tracer) Board {
+	switch runtime.GOOS {
+	case "openbsd":
+		return Board{}
+	case "netbsd":
+		return newBoard(sysctlString("machdep.dmi.board-vendor"), sysctlString("machdep.dmi.board-product"),
+			sysctlString("machdep.dmi.bios-vendor"), sysctlString("machdep.dmi.bios-version"), sysctlString("machdep.dmi.bios-date"))
+	}
+	b := newBoard(kenv(tr, "smbios.planar.maker"), kenv(tr, "smbios.planar.product"),
+		kenv(tr, "smbios.bios.vendor"), kenv(tr, "smbios.bios.version"), kenv(tr, "smbios.bios.reldate"))
+	// FreeBSD informa cómo arrancó el sistema: "UEFI" o "BIOS"
+	b.Firmware = sysctlString("machdep.bootmethod")
+	return b
+}
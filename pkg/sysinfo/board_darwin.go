@@ -0,0 +1,9 @@
+//go:build darwin
+
+package sysinfo
+
+// getBoard devuelve vacío: los Mac no tienen SMBIOS y el modelo ya está en
+// getHostModel
+func getBoard(tr *tracer) Board {
+	return Board{}
+}
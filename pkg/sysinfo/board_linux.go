@@ -0,0 +1,19 @@
+//go:build linux
+
+package sysinfo
+
+import "os"
+
+// getBoard lee la placa y el firmware de DMI. Estos archivos se pueden leer
+// sin root (solo los números de serie no); en placas ARM sin DMI queda vacío
+func getBoard(tr *tracer) Board {
+	const dmi = "/sys/class/dmi/id/"
+	b := newBoard(tr.readTrim(dmi+"board_vendor"), tr.readTrim(dmi+"board_name"),
+		tr.readTrim(dmi+"bios_vendor"), tr.readTrim(dmi+"bios_version"), tr.readTrim(dmi+"bios_date"))
+	if _, err := os.Stat("/sys/firmware/efi"); err == nil {
+		b.Firmware = "UEFI"
+	} else if b.BIOSVendor != "" {
+		b.Firmware = "BIOS"
+	}
+	return b
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package sysinfo
+
+import "syscall"
+
+// getBoard lee la placa y el firmware de la copia del SMBIOS en el registro.
+// La clave de Secure Boot solo existe si el equipo arrancó por UEFI
+func getBoard(tr *tracer) Board {
+	b := newBoard(regString(biosKey, "BaseBoardManufacturer"), regString(biosKey, "BaseBoardProduct"),
+		regString(biosKey, "BIOSVendor"), regString(biosKey, "BIOSVersion"), regString(biosKey, "BIOSReleaseDate"))
+	if key, ok := openKey(secureBootKey); ok {
+		syscall.RegCloseKey(key)
+		b.Firmware = "UEFI"
+	} else if b.BIOSVendor != "" {
+		b.Firmware = "BIOS"
+	}
+	return b
+}
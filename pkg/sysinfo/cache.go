@@ -0,0 +1,97 @@
+package sysinfo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// staticCache son los datos que no cambian mientras no se reinicie el
+// equipo. Se guardan en Options.CacheDir para que las siguientes ejecuciones
+// no tengan que volver a correr lspci, getprop, etc.
+type staticCache struct {
+	Key string `json:"key"` // versión, kernel y boot id con los que se generó
+
+	OS     string   `json:"os"`
+	Kernel string   `json:"kernel"`
+	Model  string   `json:"model"`
+	Virt   Virt     `json:"virt"`
+	CPU    CPUInfo  `json:"cpu"`
+	GPUs   []string `json:"gpus"`
+	Board  Board    `json:"board"`
+}
+
+// staticCacheVersion es parte de la clave: cambia al agregar campos a
+// staticCache, para no usar una caché vieja donde faltan
+const staticCacheVersion = "2"
+
+// staticCacheFile es el nombre del archivo dentro de Options.CacheDir
+const staticCacheFile = "static.json"
+
+// cacheKey identifica el arranque actual. Si cambia el kernel o se
+// reinicia el equipo (puede haber hardware nuevo) la caché deja de valer.
+// También se usa la fecha de /etc/os-release, que cambia al actualizar la
+// distro y distingue a un contenedor (toolbox, distrobox) que comparte el
+// home y el kernel con el host
+func cacheKey(tr *tracer) string {
+	boot := bootID(tr)
+	if boot == "" {
+		return ""
+	}
+	key := staticCacheVersion + "|" + getKernel() + "|" + boot
+	if st, err := os.Stat("/etc/os-release"); err == nil {
+		key += "|" + strconv.FormatInt(st.ModTime().UnixNano(), 10)
+	}
+	return key
+}
+
+// loadStaticCache copia los datos de la caché a info si la caché existe y
+// corresponde a key
+func loadStaticCache(tr *tracer, dir, key string, info *SystemInfo) bool {
+	data, err := tr.readFile(filepath.Join(dir, staticCacheFile))
+	if err != nil {
+		return false
+	}
+	var c staticCache
+	if json.Unmarshal(data, &c) != nil || c.Key != key {
+		return false
+	}
+	info.OS, info.Kernel, info.Model, info.Virt = c.OS, c.Kernel, c.Model, c.Virt
+	info.CPU, info.GPUs, info.Board = c.CPU, c.GPUs, c.Board
+	return true
+}
+
+// saveStaticCache guarda los datos estáticos de info. Se escribe a un
+// temporal y se renombra para que dos ejecuciones a la vez no dejen un
+// archivo a medias. Los errores se ignoran: sin caché solo es más lento
+func saveStaticCache(dir, key string, info *SystemInfo) {
+	// Si algo no se pudo detectar (ej. por timeout) no se guarda, para
+	// reintentar la próxima vez
+	if info.OS == "N/A" || info.Kernel == "N/A" || info.CPU.Model == "N/A" {
+		return
+	}
+	data, err := json.Marshal(staticCache{
+		Key: key,
+		OS:  info.OS, Kernel: info.Kernel, Model: info.Model, Virt: info.Virt,
+		CPU: info.CPU, GPUs: info.GPUs, Board: info.Board,
+	})
+	if err != nil || os.MkdirAll(dir, 0o755) != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(dir, staticCacheFile+".*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if os.Rename(tmp.Name(), filepath.Join(dir, staticCacheFile)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,340 @@
+package sysinfo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout es lo que se espera por defecto a cada colector
+const DefaultTimeout = 3 * time.Second
+
+// task es un colector que corre en su propia goroutine. En vez de escribir
+// en SystemInfo devuelve una función que guarda el resultado, así solo el
+// agregador toca info y una tarea abandonada no puede pisar nada. ok dice
+// si consiguió el dato; si no, su Timing queda en TimingNoData
+type task struct {
+	name    string        // módulos que usan su dato, para Options.Timings
+	timeout time.Duration // 0 usa Options.Timeout
+	static  bool          // su dato se guarda en la caché entre ejecuciones
+	run     func(ctx context.Context, tr *tracer) (apply func(info *SystemInfo), ok bool)
+}
+
+// known dice si s es un valor detectado (ni vacío ni "N/A")
+func known(s string) bool {
+	return s != "" && s != "N/A"
+}
+
+// runTasks corre las tareas en paralelo, cada una con su propio deadline y
+// su tracer, y aplica los resultados de las que terminan a tiempo. Las que
+// se pasan se abandonan y su dato queda con el valor que ya tenía info. A
+// opts.Timings, si no es nil, se le pasa lo que tardó cada una con las
+// fuentes que le fallaron
+func runTasks(ctx context.Context, info *SystemInfo, opts Options, tasks []task) {
+	type result struct {
+		apply func(*SystemInfo)
+		Timing
+	}
+	results := make(chan result, len(tasks))
+	for _, t := range tasks {
+		go func(t task) {
+			d := t.timeout
+			if d == 0 {
+				d = opts.Timeout
+			}
+			tctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			tr := newTracer(opts.Logger, t.name)
+			start := time.Now()
+			type outcome struct {
+				apply func(*SystemInfo)
+				ok    bool
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				apply, ok := t.run(tctx, tr)
+				done <- outcome{apply, ok}
+			}()
+			var apply func(*SystemInfo)
+			var err error
+			status := TimingTimeout
+			select {
+			case o := <-done:
+				apply, status = o.apply, TimingOK
+				if !o.ok {
+					status = TimingNoData
+					err = failure(tr.sourceErrors())
+				}
+			case <-tctx.Done():
+				err = tctx.Err()
+			}
+			elapsed := time.Since(start)
+			errs := tr.sourceErrors()
+			if tr.log != nil {
+				tr.log.Debug("collector done", "status", status, "duration", elapsed, "failed_sources", len(errs), "err", err)
+			}
+			results <- result{apply, Timing{Name: t.name, Duration: elapsed, Status: status, Errors: errs, Err: err}}
+		}(t)
+	}
+
+	for range tasks {
+		r := <-results
+		if r.apply != nil {
+			r.apply(info)
+		}
+		if opts.Timings != nil {
+			opts.Timings(r.Timing)
+		}
+	}
+}
+
+// staticTasks son los colectores de datos que no cambian mientras el
+// sistema está encendido
+func staticTasks(opts Options, pc *procCache) []task {
+	tasks := []task{
+		{name: "os", static: true, run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			name := getOS(tr)
+			return func(i *SystemInfo) { i.OS = name }, known(name)
+		}},
+		{name: "kernel", static: true, run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			kernel := getKernel()
+			return func(i *SystemInfo) { i.Kernel = kernel }, known(kernel)
+		}},
+		{name: "title", run: func(ctx context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			host := getHostname(ctx, tr, opts.Hostname)
+			return func(i *SystemInfo) { i.Host = host }, known(host)
+		}},
+		{name: "host,virt,container", static: true, run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// VM y contenedor (vacíos en hardware real)
+			model := getHostModel(tr)
+			virt := getVirt(tr, pc, model)
+			return func(i *SystemInfo) { i.Model, i.Virt = model, virt }, known(model) || virt != (Virt{})
+		}},
+		{name: "board,bios", static: true, run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			board := getBoard(tr)
+			return func(i *SystemInfo) { i.Board = board }, board != (Board{})
+		}},
+		{name: "init,boot", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			init, boot := getInit(tr), getBootTime(tr)
+			return func(i *SystemInfo) { i.Init, i.Boot = init, boot }, known(init) || boot != (BootTime{})
+		}},
+		{name: "kernel_build,kernel", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// El taint puede cambiar al cargar un módulo, así que no va a la caché
+			kernel := getKernelInfo(tr)
+			return func(i *SystemInfo) { i.KernelInfo = kernel }, kernel.Version != ""
+		}},
+		{name: "security,firewall", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			security, firewall := getSecurity(tr), getFirewall(tr)
+			return func(i *SystemInfo) { i.Security, i.Firewall = security, firewall }, security != (Security{}) || firewall != (Firewall{})
+		}},
+		{name: "locale,timezone", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			locale := getLocale(tr)
+			tz, offset := getTimezone(tr)
+			return func(i *SystemInfo) { i.Locale, i.Timezone, i.TZOffset = locale, tz, offset }, known(locale) || known(tz)
+		}},
+		{name: "shell", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			shell := getShell(tr)
+			return func(i *SystemInfo) { i.Shell = shell }, known(shell)
+		}},
+		{name: "term", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			term := getTerminal(tr)
+			return func(i *SystemInfo) { i.Term = term }, known(term)
+		}},
+		{name: "cpu", static: true, run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			cpu := getCPU(tr, pc)
+			return func(i *SystemInfo) { i.CPU = cpu }, known(cpu.Model)
+		}},
+		{name: "gpu", static: true, run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			gpus := getGPUs(tr)
+			return func(i *SystemInfo) { i.GPUs = gpus }, len(gpus) > 0
+		}},
+		{name: "display", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			displays := getDisplays(tr)
+			return func(i *SystemInfo) { i.Displays = displays }, len(displays) > 0
+		}},
+		{name: "sound", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// La salida por defecto cambia al enchufar auriculares, no va a la caché
+			var sound Sound
+			if opts.Sound {
+				sound = getSound(tr)
+			}
+			return func(i *SystemInfo) { i.Sound = sound }, !opts.Sound || sound != (Sound{})
+		}},
+		{name: "packages", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			packages := getPackages(tr)
+			return func(i *SystemInfo) { i.Packages = packages }, len(packages) > 0
+		}},
+		{name: "de,wm", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// Escritorio y gestor de ventanas (vacíos en servidores)
+			procs := processNames(tr)
+			de, wm := getDE(procs), getWM(procs)
+			// En sway, i3, Hyprland... el "escritorio" es el propio WM
+			if de != "" && strings.HasPrefix(strings.ToLower(wm), strings.ToLower(de)) {
+				de = ""
+			}
+			return func(i *SystemInfo) { i.DE, i.WM = de, wm }, de != "" || wm != ""
+		}},
+		{name: "theme,icons,cursor,font", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			theme := getTheme(tr)
+			return func(i *SystemInfo) { i.Theme = theme }, theme != (Theme{})
+		}},
+		{name: "net,ip,ipv6", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// Red: la IPv6 solo si se pide
+			ifaces := getInterfaces(opts.IPv6)
+			return func(i *SystemInfo) {
+				i.Network = ifaces
+				i.IP = firstAddr(ifaces, false)
+				if opts.IPv6 {
+					i.IPv6 = firstAddr(ifaces, true)
+				}
+			}, len(ifaces) > 0
+		}},
+	}
+
+	// La IP pública solo si se pide; tiene su propio timeout de red
+	if opts.PublicIP {
+		tasks = append(tasks, task{name: "public_ip", timeout: publicIPTimeout + time.Second, run: func(ctx context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			ip := getPublicIP(ctx, tr, opts.PublicIPURL)
+			return func(i *SystemInfo) { i.PublicIP = ip }, known(ip)
+		}})
+	}
+	return tasks
+}
+
+// uncachedTasks devuelve las tareas cuyo dato no está en la caché
+func uncachedTasks(tasks []task) []task {
+	var out []task
+	for _, t := range tasks {
+		if !t.static {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// onlyTasks devuelve las tareas que recolectan alguno de los módulos y, si
+// static es true, también las estáticas
+func onlyTasks(tasks []task, modules []string, static bool) []task {
+	var out []task
+	for _, t := range tasks {
+		if static && t.static {
+			out = append(out, t)
+			continue
+		}
+		for _, name := range strings.Split(t.name, ",") {
+			if slices.Contains(modules, name) {
+				out = append(out, t)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// dynamicTasks son los colectores de datos que cambian mientras el sistema
+// está encendido (los que se vuelven a leer en Refresh)
+func dynamicTasks(opts Options, pc *procCache) []task {
+	tasks := []task{
+		{name: "uptime,load,procs", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			uptime := getUptime(tr, pc)
+			load, procs := getLoad(tr, pc)
+			return func(i *SystemInfo) { i.Uptime, i.Load, i.Processes = uptime, load, procs }, uptime > 0 || procs.Total > 0
+		}},
+		{name: "mem,swap", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			mem, swap := getMemory(tr, pc, opts.Memory), getSwap(tr, pc)
+			return func(i *SystemInfo) { i.Memory, i.Swap = mem, swap }, mem.Total > 0
+		}},
+		{name: "disk", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			disk, disks := getDisk(tr, "/"), getDisks(tr, opts.Disks, pc)
+			// Si "/" está en un pool, {disk.*} muestra lo mismo que el módulo
+			for _, m := range disks {
+				if m.Path == "/" {
+					disk = m.Usage
+				}
+			}
+			return func(i *SystemInfo) { i.Disk, i.Disks = disk, disks }, disk.Total > 0 || len(disks) > 0
+		}},
+		{name: "drives", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// Discos físicos: pueden aparecer y desaparecer (USB)
+			drives := getDrives(tr)
+			return func(i *SystemInfo) { i.Drives = drives }, len(drives) > 0
+		}},
+		{name: "battery", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// Batería (vacío en equipos de escritorio)
+			batteries := getBatteries(tr)
+			return func(i *SystemInfo) { i.Batteries = batteries }, len(batteries) > 0
+		}},
+		{name: "temps", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			// Temperaturas de los sensores elegidos
+			temps := getTemperatures(tr, opts.Sensors)
+			return func(i *SystemInfo) { i.Temperatures = temps }, len(temps) > 0
+		}},
+	}
+
+	// Lo que se mide en un intervalo solo si se pide, porque espera
+	var samplers []sampler
+	var names []string
+	if opts.CPUUsage {
+		samplers, names = append(samplers, sampleCPUUsage), append(names, "cpu_usage")
+	}
+	if opts.NetRate {
+		samplers, names = append(samplers, sampleNetRate), append(names, "net_rate")
+	}
+	if len(samplers) > 0 {
+		t := sampleTask(opts, samplers)
+		t.name = strings.Join(names, ",")
+		tasks = append(tasks, t)
+	}
+	// El clima solo si se pide; tiene su propio timeout de red
+	if opts.Weather {
+		tasks = append(tasks, task{name: "weather", timeout: weatherTimeout + time.Second, run: func(ctx context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			weather := getWeather(ctx, tr, opts.WeatherURL, opts.CacheDir)
+			return func(i *SystemInfo) { i.Weather = weather }, weather != ""
+		}})
+	}
+	// La señal del Wi-Fi cambia, pero solo se lee si se pide
+	if opts.WiFi {
+		tasks = append(tasks, task{name: "wifi", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			wifi := getWiFi(tr)
+			return func(i *SystemInfo) { i.WiFi = wifi }, len(wifi) > 0
+		}})
+	}
+	// La VRAM usada cambia; los nombres de las GPUs están en la caché
+	if opts.GPUDrivers {
+		tasks = append(tasks, task{name: "gpu,vram", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			drivers := getGPUDrivers(tr)
+			return func(i *SystemInfo) { i.GPUDrivers = drivers }, len(drivers) > 0
+		}})
+	}
+	// Los contenedores solo si se piden: un daemon colgado tarda en contestar
+	if opts.Containers {
+		tasks = append(tasks, task{name: "containers", run: func(ctx context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			containers := getContainers(ctx, tr)
+			return func(i *SystemInfo) { i.Containers = containers }, len(containers) > 0
+		}})
+	}
+	// Las sesiones solo si se piden: sin utmp hay que correr loginctl
+	if opts.Sessions {
+		tasks = append(tasks, task{name: "users", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			sessions := getSessions(tr)
+			return func(i *SystemInfo) { i.Sessions = sessions }, len(sessions) > 0
+		}})
+	}
+	// Los dispositivos Bluetooth solo si se piden: son comandos externos
+	if opts.Bluetooth {
+		tasks = append(tasks, task{name: "bluetooth", run: func(_ context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			devices := getBluetooth(tr)
+			return func(i *SystemInfo) { i.Bluetooth = devices }, len(devices) > 0
+		}})
+	}
+	// El reproductor solo si se pide: son varias llamadas por D-Bus
+	if opts.Media {
+		tasks = append(tasks, task{name: "media", run: func(ctx context.Context, tr *tracer) (func(*SystemInfo), bool) {
+			media := getMedia(ctx, tr)
+			return func(i *SystemInfo) { i.Media = media }, media != (Media{})
+		}})
+	}
+	return tasks
+}
@@ -0,0 +1,101 @@
+package sysinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContainerCount guarda cuántos contenedores tiene un runtime
+type ContainerCount struct {
+	Runtime string `json:"runtime"` // "docker" o "podman"
+	Running int    `json:"running"`
+	Total   int    `json:"total"`
+}
+
+// containerSockets son los sockets de la API de Docker (que Podman también
+// implementa), del sistema y del usuario (modo rootless)
+func containerSockets() []string {
+	sockets := []string{"/var/run/docker.sock", "/run/podman/podman.sock"}
+	if host, ok := strings.CutPrefix(os.Getenv("DOCKER_HOST"), "unix://"); ok {
+		sockets = append([]string{host}, sockets...)
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		sockets = append(sockets, filepath.Join(dir, "docker.sock"), filepath.Join(dir, "podman", "podman.sock"))
+	}
+	// Docker Desktop y Colima en macOS
+	if home, err := os.UserHomeDir(); err == nil {
+		sockets = append(sockets, filepath.Join(home, ".docker", "run", "docker.sock"), filepath.Join(home, ".colima", "default", "docker.sock"))
+	}
+	return sockets
+}
+
+// getContainers cuenta los contenedores de cada runtime que responde en
+// su socket. No se corre docker ni podman, que tardan bastante en arrancar;
+// un socket sin permiso (usuario fuera del grupo docker) se saltea
+func getContainers(ctx context.Context, tr *tracer) []ContainerCount {
+	var out []ContainerCount
+	seen := map[string]bool{}
+	for _, socket := range containerSockets() {
+		// Podman suele enlazar /var/run/docker.sock a su propio socket
+		real, err := filepath.EvalSymlinks(socket)
+		if err != nil || seen[real] {
+			continue
+		}
+		seen[real] = true
+
+		c, ok := countContainers(ctx, tr, real)
+		if !ok {
+			continue
+		}
+		c.Runtime = "docker"
+		if strings.Contains(real, "podman") {
+			c.Runtime = "podman"
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// countContainers pide GET /containers/json?all=true por el socket unix
+func countContainers(ctx context.Context, tr *tracer, socket string) (ContainerCount, bool) {
+	client := http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}}
+	// El host no importa, la conexión siempre va al socket
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/containers/json?all=true", nil)
+	if err != nil {
+		return ContainerCount{}, false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		tr.source("request", socket, err)
+		return ContainerCount{}, false
+	}
+	defer resp.Body.Close()
+	if tr.source("request", socket, statusError(resp)) != nil {
+		return ContainerCount{}, false
+	}
+
+	var containers []struct {
+		State string `json:"State"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		tr.parse("containers JSON", socket, err)
+		return ContainerCount{}, false
+	}
+	c := ContainerCount{Total: len(containers)}
+	for _, ct := range containers {
+		if ct.State == "running" {
+			c.Running++
+		}
+	}
+	return c, true
+}
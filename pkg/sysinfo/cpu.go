@@ -0,0 +1,14 @@
+package sysinfo
+
+// CPUInfo guarda el modelo, la cantidad de núcleos y la frecuencia de la CPU
+type CPUInfo struct {
+	Model      string  `json:"model"`
+	Cores      int     `json:"cores"`   // núcleos físicos
+	Threads    int     `json:"threads"` // CPUs lógicas
+	MaxMHz     float64 `json:"max_mhz,omitempty"`
+	CurrentMHz float64 `json:"current_mhz,omitempty"`
+
+	// CoreMHz es la frecuencia actual de cada CPU lógica, en orden. Solo se
+	// llena en Linux con cpufreq
+	CoreMHz []float64 `json:"core_mhz,omitempty"`
+}
@@ -0,0 +1,50 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"runtime"
+)
+
+// getCPU lee el modelo de hw.model. Los núcleos físicos solo los expone
+// FreeBSD (kern.smp.cores); en el resto se asume uno por hilo
+func getCPU(tr *tracer, pc *procCache) CPUInfo {
+	cpu := CPUInfo{
+		Model:   sysctlString("hw.model"),
+		Threads: runtime.NumCPU(),
+	}
+	if cpu.Model == "" {
+		cpu.Model = "N/A"
+	}
+	cpu.Cores = int(sysctlUint("kern.smp.cores"))
+	if cpu.Cores == 0 {
+		cpu.Cores = cpu.Threads
+	}
+
+	switch runtime.GOOS {
+	case "freebsd", "dragonfly":
+		// hw.clockrate es la frecuencia nominal y dev.cpu.0.freq la actual
+		cpu.MaxMHz = float64(sysctlUint("hw.clockrate"))
+		cpu.CurrentMHz = float64(sysctlUint("dev.cpu.0.freq"))
+	case "openbsd":
+		cpu.CurrentMHz = float64(sysctlUint("hw.cpuspeed"))
+	}
+	return cpu
+}
+
+// cpuTimes lee kern.cp_time: los ticks acumulados de todas las CPUs por
+// estado (user, nice, sys, intr, idle; OpenBSD agrega spin antes de intr).
+// En todos idle es el último
+func cpuTimes(tr *tracer) (idle, total uint64, ok bool) {
+	b := sysctlRaw("kern.cp_time", 40)
+	if len(b) < 40 || len(b)%8 != 0 {
+		return 0, 0, false
+	}
+	for off := 0; off < len(b); off += 8 {
+		v := binary.LittleEndian.Uint64(b[off : off+8])
+		total += v
+		idle = v
+	}
+	return idle, total, true
+}
@@ -0,0 +1,24 @@
+//go:build darwin
+
+package sysinfo
+
+// getCPU lee el modelo y los núcleos por sysctl. La frecuencia solo existe
+// en Macs Intel (en Apple Silicon no se expone)
+func getCPU(tr *tracer, pc *procCache) CPUInfo {
+	cpu := CPUInfo{
+		Model:   sysctlString("machdep.cpu.brand_string"),
+		Cores:   int(sysctlUint("hw.physicalcpu")),
+		Threads: int(sysctlUint("hw.logicalcpu")),
+		MaxMHz:  float64(sysctlUint("hw.cpufrequency_max")) / 1e6,
+	}
+	if cpu.Model == "" {
+		cpu.Model = "N/A"
+	}
+	return cpu
+}
+
+// cpuTimes no está implementado en macOS: los ticks por estado solo salen
+// de host_statistics, que es de Mach y necesita cgo
+func cpuTimes(tr *tracer) (idle, total uint64, ok bool) {
+	return 0, 0, false
+}
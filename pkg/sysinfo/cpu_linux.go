@@ -0,0 +1,122 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// getCPU obtiene el modelo, núcleos, hilos y frecuencias de la CPU
+func getCPU(tr *tracer, pc *procCache) CPUInfo {
+	cpu := CPUInfo{Model: "N/A"}
+	data := pc.Read(tr, "/proc/cpuinfo")
+	if data == nil {
+		return cpu
+	}
+
+	// Cada bloque de /proc/cpuinfo es una CPU lógica; los núcleos físicos
+	// se distinguen por el par (physical id, core id)
+	cores := map[string]bool{}
+	physID := ""
+	var mhzSum float64
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+
+		switch key {
+		case "processor":
+			cpu.Threads++
+		case "model name", "Hardware":
+			// Los kernels ARM viejos (y Android) ponen el SoC en "Hardware"
+			if cpu.Model == "N/A" {
+				cpu.Model = strings.Join(strings.Fields(val), " ")
+			}
+		case "physical id":
+			physID = val
+		case "core id":
+			cores[physID+"/"+val] = true
+		case "cpu MHz":
+			mhz, _ := strconv.ParseFloat(val, 64)
+			mhzSum += mhz
+		}
+	}
+
+	// En Android el nombre comercial del SoC es más claro que "Hardware"
+	if isAndroid() {
+		if soc := androidSoC(tr); soc != "" {
+			cpu.Model = soc
+		}
+	}
+
+	// En ARM no hay "core id", así que núcleos = hilos
+	cpu.Cores = len(cores)
+	if cpu.Cores == 0 {
+		cpu.Cores = cpu.Threads
+	}
+
+	// La frecuencia máxima solo está en cpufreq (en kHz)
+	maxFreqs, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*/cpufreq/cpuinfo_max_freq")
+	for _, path := range maxFreqs {
+		if mhz := float64(tr.readUint(path)) / 1000; mhz > cpu.MaxMHz {
+			cpu.MaxMHz = mhz
+		}
+	}
+
+	// Frecuencia actual: promedio de cpufreq o, si no hay, de /proc/cpuinfo
+	curFreqs, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*/cpufreq/scaling_cur_freq")
+	var curSum float64
+	for _, path := range curFreqs {
+		curSum += float64(tr.readUint(path)) / 1000
+	}
+	switch {
+	case len(curFreqs) > 0:
+		cpu.CurrentMHz = curSum / float64(len(curFreqs))
+	case cpu.Threads > 0:
+		cpu.CurrentMHz = mhzSum / float64(cpu.Threads)
+	}
+
+	// Frecuencia de cada CPU lógica (el glob ordena cpu10 antes que cpu2)
+	for n := 0; n < cpu.Threads && len(curFreqs) > 0; n++ {
+		khz := tr.readUint("/sys/devices/system/cpu/cpu" + strconv.Itoa(n) + "/cpufreq/scaling_cur_freq")
+		cpu.CoreMHz = append(cpu.CoreMHz, float64(khz)/1000)
+	}
+	return cpu
+}
+
+// cpuTimes lee de la línea "cpu" de /proc/stat el tiempo ocioso y el total
+// de todas las CPUs, en ticks. Se lee el archivo cada vez (no procCache)
+// porque se compara con una lectura anterior. iowait cuenta como ocioso
+func cpuTimes(tr *tracer) (idle, total uint64, ok bool) {
+	data, err := tr.readFile("/proc/stat")
+	if err != nil {
+		return 0, 0, false
+	}
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	fields := strings.Fields(string(line))
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, false
+	}
+	// user nice system idle iowait irq softirq steal (guest ya está en user)
+	for n, f := range fields[1:] {
+		if n >= 8 {
+			break
+		}
+		v, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		total += v
+		if n == 3 || n == 4 {
+			idle += v
+		}
+	}
+	return idle, total, true
+}
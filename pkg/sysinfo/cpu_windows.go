@@ -0,0 +1,61 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// cpuKey es donde Windows guarda el nombre y la frecuencia de la CPU
+const cpuKey = `HARDWARE\DESCRIPTION\System\CentralProcessor\0`
+
+// processorInfo es SYSTEM_LOGICAL_PROCESSOR_INFORMATION (la unión final ocupa 16 bytes)
+type processorInfo struct {
+	ProcessorMask uintptr
+	Relationship  uint32
+	_             [16]byte
+}
+
+// getCPU lee el modelo y la frecuencia del registro y cuenta los núcleos
+// físicos con GetLogicalProcessorInformation
+func getCPU(tr *tracer, pc *procCache) CPUInfo {
+	cpu := CPUInfo{
+		Model:   regString(cpuKey, "ProcessorNameString"),
+		Threads: runtime.NumCPU(),
+		MaxMHz:  float64(regDword(cpuKey, "~MHz")),
+	}
+	if cpu.Model == "" {
+		cpu.Model = "N/A"
+	}
+
+	var size uint32
+	procGetLogicalProcessorInformation.Call(0, uintptr(unsafe.Pointer(&size)))
+	if size > 0 {
+		infos := make([]processorInfo, size/uint32(unsafe.Sizeof(processorInfo{}))+1)
+		ok, _, _ := procGetLogicalProcessorInformation.Call(uintptr(unsafe.Pointer(&infos[0])), uintptr(unsafe.Pointer(&size)))
+		if ok != 0 {
+			for _, info := range infos[:size/uint32(unsafe.Sizeof(processorInfo{}))] {
+				// RelationProcessorCore = 0
+				if info.Relationship == 0 {
+					cpu.Cores++
+				}
+			}
+		}
+	}
+	if cpu.Cores == 0 {
+		cpu.Cores = cpu.Threads
+	}
+	return cpu
+}
+
+// cpuTimes usa GetSystemTimes, en unidades de 100 ns. El tiempo de kernel
+// ya incluye el ocioso, así que el total es kernel + user
+func cpuTimes(tr *tracer) (idle, total uint64, ok bool) {
+	var idleTime, kernelTime, userTime uint64
+	r, _, _ := procGetSystemTimes.Call(uintptr(unsafe.Pointer(&idleTime)), uintptr(unsafe.Pointer(&kernelTime)), uintptr(unsafe.Pointer(&userTime)))
+	if r == 0 {
+		return 0, 0, false
+	}
+	return idleTime, kernelTime + userTime, true
+}
@@ -0,0 +1,568 @@
+package sysinfo
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errNoSessionBus es que no hay bus de sesión (un servidor por ssh, cron)
+var errNoSessionBus = errors.New("no D-Bus session bus")
+
+// dbusCallTimeout es lo máximo que se espera la respuesta de un método,
+// para que un reproductor colgado no se lleve todo el timeout del colector
+const dbusCallTimeout = 500 * time.Millisecond
+
+// errDBusNoReply es una llamada sin respuesta a tiempo. La conexión se
+// puede seguir usando: la respuesta que llegue tarde se descarta
+var errDBusNoReply = fmt.Errorf("no reply in %s", dbusCallTimeout)
+
+// dbusConn es una conexión al bus de sesión de D-Bus por su socket unix.
+// Solo implementa lo necesario para leer propiedades: llamar a un método
+// con argumentos string y decodificar la respuesta
+type dbusConn struct {
+	conn     net.Conn
+	r        *bufio.Reader
+	serial   uint32
+	deadline time.Time // el del contexto, que ninguna llamada puede pasar
+	tr       *tracer   // registra cada llamada
+}
+
+// sessionBusAddress devuelve el socket del bus de sesión para net.Dial ("@"
+// adelante es un socket abstracto de Linux). Sin DBUS_SESSION_BUS_ADDRESS
+// se usa el de systemd en XDG_RUNTIME_DIR
+func sessionBusAddress() (string, error) {
+	env := os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+	for _, addr := range strings.Split(env, ";") {
+		transport, params, _ := strings.Cut(addr, ":")
+		if transport != "unix" {
+			continue
+		}
+		for _, p := range strings.Split(params, ",") {
+			key, value, _ := strings.Cut(p, "=")
+			if v, err := url.PathUnescape(value); err == nil {
+				value = v
+			}
+			switch key {
+			case "path":
+				return value, nil
+			case "abstract":
+				return "@" + value, nil
+			}
+		}
+	}
+	if env != "" {
+		return "", fmt.Errorf("unsupported D-Bus address %q", env)
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		path := filepath.Join(dir, "bus")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errNoSessionBus
+}
+
+// dialSessionBus se conecta al bus de sesión, se autentica y se presenta
+// con Hello, que el bus exige antes de cualquier otra llamada
+func dialSessionBus(ctx context.Context, tr *tracer) (*dbusConn, error) {
+	addr, err := sessionBusAddress()
+	if err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", addr)
+	if err != nil {
+		return nil, tr.source("request", addr, err)
+	}
+	c := &dbusConn{conn: conn, r: bufio.NewReader(conn), tr: tr}
+	c.deadline, _ = ctx.Deadline()
+	if err := c.auth(); err != nil {
+		conn.Close()
+		return nil, tr.source("request", addr, err)
+	}
+	if _, err := c.call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *dbusConn) Close() error {
+	return c.conn.Close()
+}
+
+// setDeadline limita la próxima operación a dbusCallTimeout, sin pasarse
+// del deadline del contexto
+func (c *dbusConn) setDeadline() {
+	deadline := time.Now().Add(dbusCallTimeout)
+	if !c.deadline.IsZero() && c.deadline.Before(deadline) {
+		deadline = c.deadline
+	}
+	c.conn.SetDeadline(deadline)
+}
+
+// auth se autentica con EXTERNAL: el bus ve el uid del otro lado del
+// socket y solo hay que decirle el mismo, en hexadecimal
+func (c *dbusConn) auth() error {
+	c.setDeadline()
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if _, err := io.WriteString(c.conn, "\x00AUTH EXTERNAL "+uid+"\r\n"); err != nil {
+		return err
+	}
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("authentication rejected: %s", strings.TrimSpace(line))
+	}
+	_, err = io.WriteString(c.conn, "BEGIN\r\n")
+	return err
+}
+
+// Tipos de mensaje y campos del encabezado que se usan
+const (
+	dbusMethodCall   = 1
+	dbusMethodReturn = 2
+	dbusError        = 3
+
+	dbusFieldPath        = 1
+	dbusFieldInterface   = 2
+	dbusFieldMember      = 3
+	dbusFieldErrorName   = 4
+	dbusFieldReplySerial = 5
+	dbusFieldDestination = 6
+	dbusFieldSignature   = 8
+)
+
+// call llama a un método con argumentos string y devuelve los valores de
+// la respuesta. Los mensajes que llegan en el medio (señales como
+// NameAcquired) se descartan
+func (c *dbusConn) call(dest, path, iface, member string, args ...string) ([]any, error) {
+	source := dest + " " + iface + "." + member
+	c.serial++
+	var body dbusEncoder
+	for _, a := range args {
+		body.string(a)
+	}
+
+	var m dbusEncoder
+	m.buf = append(m.buf, 'l', dbusMethodCall, 0, 1) // little endian, sin flags, versión 1
+	m.uint32(uint32(len(body.buf)))
+	m.uint32(c.serial)
+	start := m.arrayStart(8)
+	m.field(dbusFieldPath, "o", path)
+	m.field(dbusFieldInterface, "s", iface)
+	m.field(dbusFieldMember, "s", member)
+	m.field(dbusFieldDestination, "s", dest)
+	if len(args) > 0 {
+		m.field(dbusFieldSignature, "g", strings.Repeat("s", len(args)))
+	}
+	m.arrayEnd(start)
+	m.align(8)
+	m.buf = append(m.buf, body.buf...)
+
+	c.setDeadline()
+	if _, err := c.conn.Write(m.buf); err != nil {
+		return nil, c.tr.source("request", source, err)
+	}
+	for {
+		reply, err := c.readMessage()
+		if err != nil {
+			return nil, c.tr.source("request", source, err)
+		}
+		if reply.replySerial != c.serial || (reply.typ != dbusMethodReturn && reply.typ != dbusError) {
+			continue
+		}
+		values, err := reply.values()
+		if err == nil && reply.typ == dbusError {
+			err = errors.New(reply.errorName)
+			if len(values) > 0 {
+				err = fmt.Errorf("%s: %v", reply.errorName, values[0])
+			}
+		}
+		if err != nil {
+			return nil, c.tr.source("request", source, err)
+		}
+		return values, c.tr.source("request", source, nil)
+	}
+}
+
+// dbusMessage es un mensaje recibido, con el cuerpo sin decodificar
+type dbusMessage struct {
+	typ         byte
+	replySerial uint32
+	errorName   string
+	signature   string
+	body        dbusDecoder
+}
+
+// readMessage lee un mensaje entero del socket
+func (c *dbusConn) readMessage() (dbusMessage, error) {
+	head := make([]byte, 16)
+	if n, err := io.ReadFull(c.r, head); err != nil {
+		if n == 0 && os.IsTimeout(err) {
+			return dbusMessage{}, errDBusNoReply
+		}
+		return dbusMessage{}, err
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if head[0] == 'B' {
+		order = binary.BigEndian
+	}
+	bodyLen, fieldsLen := order.Uint32(head[4:8]), order.Uint32(head[12:16])
+	// El protocolo limita los mensajes a 128MiB; acá nada se acerca
+	if bodyLen > 1<<24 || fieldsLen > 1<<24 {
+		return dbusMessage{}, errors.New("message too large")
+	}
+	bodyStart := (16 + int(fieldsLen) + 7) &^ 7
+	buf := make([]byte, bodyStart+int(bodyLen))
+	copy(buf, head)
+	if _, err := io.ReadFull(c.r, buf[16:]); err != nil {
+		return dbusMessage{}, err
+	}
+
+	m := dbusMessage{typ: head[1], body: dbusDecoder{buf: buf, pos: bodyStart, order: order}}
+	d := dbusDecoder{buf: buf[:16+fieldsLen], pos: 12, order: order}
+	fields, err := d.value("a(yv)")
+	if err != nil {
+		return dbusMessage{}, err
+	}
+	for _, f := range fields.([]any) {
+		field := f.([]any)
+		switch field[0].(byte) {
+		case dbusFieldErrorName:
+			m.errorName, _ = field[1].(string)
+		case dbusFieldReplySerial:
+			m.replySerial, _ = field[1].(uint32)
+		case dbusFieldSignature:
+			m.signature, _ = field[1].(string)
+		}
+	}
+	return m, nil
+}
+
+// values decodifica el cuerpo según la firma del mensaje
+func (m dbusMessage) values() ([]any, error) {
+	var out []any
+	for sig := m.signature; sig != ""; {
+		n, err := dbusTypeLen(sig)
+		if err != nil {
+			return nil, err
+		}
+		v, err := m.body.value(sig[:n])
+		if err != nil {
+			return nil, err
+		}
+		out, sig = append(out, v), sig[n:]
+	}
+	return out, nil
+}
+
+// dbusEncoder arma un mensaje little endian. Las alineaciones se cuentan
+// desde el principio de buf, que es el del mensaje (o el del cuerpo, que
+// empieza alineado a 8)
+type dbusEncoder struct {
+	buf []byte
+}
+
+func (e *dbusEncoder) align(n int) {
+	for len(e.buf)%n != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *dbusEncoder) uint32(v uint32) {
+	e.align(4)
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+// string escribe un string o un object path: largo, bytes y un 0
+func (e *dbusEncoder) string(s string) {
+	e.uint32(uint32(len(s)))
+	e.buf = append(append(e.buf, s...), 0)
+}
+
+// signature escribe una firma, que lleva el largo en un byte
+func (e *dbusEncoder) signature(s string) {
+	e.buf = append(append(append(e.buf, byte(len(s))), s...), 0)
+}
+
+// arrayStart deja el lugar del largo de un array cuyos elementos se
+// alinean a elemAlign y devuelve dónde empiezan, para arrayEnd
+func (e *dbusEncoder) arrayStart(elemAlign int) int {
+	e.uint32(0)
+	e.align(elemAlign)
+	return len(e.buf)
+}
+
+// arrayEnd completa el largo del array que empezó en start
+func (e *dbusEncoder) arrayEnd(start int) {
+	binary.LittleEndian.PutUint32(e.buf[start-4:], uint32(len(e.buf)-start))
+}
+
+// field escribe un campo del encabezado: un struct (byte, variant)
+func (e *dbusEncoder) field(code byte, sig, value string) {
+	e.align(8)
+	e.buf = append(e.buf, code)
+	e.signature(sig)
+	if sig == "g" {
+		e.signature(value)
+	} else {
+		e.string(value)
+	}
+}
+
+// dbusDecoder lee valores de un mensaje. pos se cuenta desde el principio
+// del mensaje, porque de ahí salen las alineaciones
+type dbusDecoder struct {
+	buf   []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+var errDBusShort = errors.New("truncated D-Bus message")
+
+func (d *dbusDecoder) align(n int) error {
+	pos := (d.pos + n - 1) / n * n
+	if pos > len(d.buf) {
+		return errDBusShort
+	}
+	d.pos = pos
+	return nil
+}
+
+// next alinea a n y devuelve los n bytes siguientes
+func (d *dbusDecoder) next(n int) ([]byte, error) {
+	if err := d.align(n); err != nil {
+		return nil, err
+	}
+	if d.pos+n > len(d.buf) {
+		return nil, errDBusShort
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// bytes devuelve n bytes sin alinear (el contenido de un string)
+func (d *dbusDecoder) bytes(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.buf) {
+		return nil, errDBusShort
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// value decodifica un valor del tipo completo sig. Los enteros quedan con
+// su tipo de Go (byte, int16, uint32...), los arrays como []any, los
+// diccionarios como map[string]any, los structs como []any y los variants
+// como el valor que contienen
+func (d *dbusDecoder) value(sig string) (any, error) {
+	switch sig[0] {
+	case 'y':
+		b, err := d.next(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case 'n', 'q':
+		b, err := d.next(2)
+		if err != nil {
+			return nil, err
+		}
+		if sig[0] == 'n' {
+			return int16(d.order.Uint16(b)), nil
+		}
+		return d.order.Uint16(b), nil
+	case 'b', 'i', 'u', 'h':
+		b, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		switch v := d.order.Uint32(b); sig[0] {
+		case 'b':
+			return v != 0, nil
+		case 'i':
+			return int32(v), nil
+		default:
+			return v, nil
+		}
+	case 'x', 't', 'd':
+		b, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		switch v := d.order.Uint64(b); sig[0] {
+		case 'x':
+			return int64(v), nil
+		case 'd':
+			return math.Float64frombits(v), nil
+		default:
+			return v, nil
+		}
+	case 's', 'o':
+		b, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		s, err := d.bytes(int(d.order.Uint32(b)) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return string(s[:len(s)-1]), nil
+	case 'g':
+		return d.signature()
+	case 'v':
+		inner, err := d.signature()
+		if err != nil {
+			return nil, err
+		}
+		if n, err := dbusTypeLen(inner); err != nil || n != len(inner) {
+			return nil, fmt.Errorf("invalid variant signature %q", inner)
+		}
+		return d.value(inner)
+	case 'a':
+		return d.array(sig[1:])
+	case '(':
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		var fields []any
+		for inner := sig[1 : len(sig)-1]; inner != ""; {
+			n, err := dbusTypeLen(inner)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.value(inner[:n])
+			if err != nil {
+				return nil, err
+			}
+			fields, inner = append(fields, v), inner[n:]
+		}
+		return fields, nil
+	}
+	return nil, fmt.Errorf("unsupported D-Bus type %q", sig)
+}
+
+// signature lee una firma: largo en un byte, bytes y un 0
+func (d *dbusDecoder) signature() (string, error) {
+	n, err := d.bytes(1)
+	if err != nil {
+		return "", err
+	}
+	s, err := d.bytes(int(n[0]) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(s[:len(s)-1]), nil
+}
+
+// array lee un array de elementos del tipo elem. Un diccionario
+// (elem "{kv}") vuelve como map[string]any con las claves como texto
+func (d *dbusDecoder) array(elem string) (any, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return nil, err
+	}
+	size := int(d.order.Uint32(b))
+	// El relleno hasta el primer elemento no cuenta en el largo
+	if err := d.align(dbusAlignment(elem[0])); err != nil {
+		return nil, err
+	}
+	end := d.pos + size
+	if size < 0 || end > len(d.buf) {
+		return nil, errDBusShort
+	}
+
+	if elem[0] == '{' {
+		keySig, valueSig := elem[1:2], elem[2:len(elem)-1]
+		dict := map[string]any{}
+		for d.pos < end {
+			if err := d.align(8); err != nil {
+				return nil, err
+			}
+			k, err := d.value(keySig)
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.value(valueSig)
+			if err != nil {
+				return nil, err
+			}
+			dict[fmt.Sprint(k)] = v
+		}
+		return dict, nil
+	}
+	items := []any{}
+	for d.pos < end {
+		v, err := d.value(elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+// dbusAlignment es la alineación de un tipo por su primer carácter
+func dbusAlignment(c byte) int {
+	switch c {
+	case 'y', 'g', 'v':
+		return 1
+	case 'n', 'q':
+		return 2
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	}
+	return 4
+}
+
+// dbusTypeLen devuelve el largo del primer tipo completo de sig, ej. 5
+// para "a{sv}s"
+func dbusTypeLen(sig string) (int, error) {
+	if sig == "" {
+		return 0, errors.New("empty D-Bus signature")
+	}
+	switch c := sig[0]; {
+	case c == 'a':
+		n, err := dbusTypeLen(sig[1:])
+		return n + 1, err
+	case c == '(' || c == '{':
+		end := byte(')')
+		if c == '{' {
+			end = '}'
+		}
+		i, count := 1, 0
+		for i < len(sig) && sig[i] != end {
+			n, err := dbusTypeLen(sig[i:])
+			if err != nil {
+				return 0, err
+			}
+			i, count = i+n, count+1
+		}
+		// Un struct necesita algún campo y un diccionario una clave simple
+		// y un valor
+		if i >= len(sig) || count == 0 || (c == '{' && (count != 2 || strings.IndexByte("ybnqiuxtdhsog", sig[1]) < 0)) {
+			return 0, fmt.Errorf("invalid D-Bus signature %q", sig)
+		}
+		return i + 1, nil
+	case strings.IndexByte("ybnqiuxtdhsogv", c) >= 0:
+		return 1, nil
+	}
+	return 0, fmt.Errorf("invalid D-Bus signature %q", sig)
+}
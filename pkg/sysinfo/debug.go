@@ -0,0 +1,121 @@
+package sysinfo
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SourceError es una fuente de datos que falló: un archivo que no se pudo
+// leer, un comando que salió con error, un valor que no se pudo parsear o
+// una petición de red
+type SourceError struct {
+	Op     string // "read", "exec", "parse" o "request"
+	Source string // ruta, comando, valor o URL
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	return e.Op + " " + e.Source + ": " + e.Err.Error()
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// tracer registra lo que hace un colector y junta los errores de sus
+// fuentes para Timing.Errors. Cada tarea tiene el suyo, así lo que lee y
+// ejecuta se le atribuye aunque corran en paralelo. Los métodos aceptan un
+// tracer nil (fuera de una tarea): ahí solo se arma el *SourceError
+type tracer struct {
+	log    *slog.Logger // nil si no hay Options.Logger
+	mu     sync.Mutex
+	errors []error
+}
+
+// newTracer crea el tracer de la tarea name, que registra con log si no es nil
+func newTracer(log *slog.Logger, name string) *tracer {
+	if log != nil {
+		log = log.With("collector", name)
+	}
+	return &tracer{log: log}
+}
+
+// sourceErrors devuelve los errores de las fuentes que fallaron hasta ahora
+func (t *tracer) sourceErrors() []error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Clone(t.errors)
+}
+
+// source registra una fuente de datos usada por el colector y, si falló,
+// devuelve el error como *SourceError (nil si no falló)
+func (t *tracer) source(op, source string, err error, attrs ...any) error {
+	if err != nil {
+		// La ruta ya está en Source: "read /x: no such file", no "read /x: open /x: ..."
+		var pe *fs.PathError
+		if errors.As(err, &pe) && pe.Path == source {
+			err = pe.Err
+		}
+		err = &SourceError{Op: op, Source: source, Err: err}
+	}
+	if t == nil {
+		return err
+	}
+	if err != nil {
+		t.mu.Lock()
+		t.errors = append(t.errors, err)
+		t.mu.Unlock()
+	}
+	if t.log == nil {
+		return err
+	}
+	attrs = append([]any{"op", op, "source", source}, attrs...)
+	if err != nil {
+		t.log.Debug("source failed", append(attrs, "err", errors.Unwrap(err).Error())...)
+		return err
+	}
+	t.log.Debug("source", attrs...)
+	return nil
+}
+
+// parse registra un valor que no se pudo parsear
+func (t *tracer) parse(what, value string, err error) {
+	if err != nil {
+		t.source("parse", what, err, "value", value)
+	}
+}
+
+// readFile es os.ReadFile registrando la lectura
+func (t *tracer) readFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, t.source("read", path, err)
+	}
+	return data, t.source("read", path, nil, "bytes", len(data))
+}
+
+// openFile es os.Open registrando la apertura
+func (t *tracer) openFile(path string) (*os.File, error) {
+	f, err := os.Open(path)
+	return f, t.source("read", path, err)
+}
+
+// cmd registra un comando ya ejecutado con lo que tardó
+func (t *tracer) cmd(name string, args []string, start time.Time, err error) error {
+	return t.source("exec", strings.Join(append([]string{name}, args...), " "), err, "duration", time.Since(start))
+}
+
+// statusError es el error de una respuesta HTTP que no es 200
+func statusError(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package sysinfo
+
+import (
+	"os"
+	"strings"
+)
+
+// desktopProcesses relaciona procesos con el entorno de escritorio que los lanza
+var desktopProcesses = map[string]string{
+	"gnome-shell":    "GNOME",
+	"plasmashell":    "KDE Plasma",
+	"xfce4-session":  "XFCE",
+	"mate-session":   "MATE",
+	"cinnamon":       "Cinnamon",
+	"lxqt-session":   "LXQt",
+	"lxsession":      "LXDE",
+	"budgie-wm":      "Budgie",
+	"cosmic-session": "COSMIC",
+	"enlightenment":  "Enlightenment",
+}
+
+// wmProcesses relaciona procesos con el nombre del gestor de ventanas
+var wmProcesses = map[string]string{
+	"gnome-shell":   "Mutter",
+	"mutter":        "Mutter",
+	"kwin_wayland":  "KWin",
+	"kwin_x11":      "KWin",
+	"xfwm4":         "Xfwm4",
+	"marco":         "Marco",
+	"muffin":        "Muffin",
+	"cinnamon":      "Muffin",
+	"openbox":       "Openbox",
+	"i3":            "i3",
+	"sway":          "Sway",
+	"Hyprland":      "Hyprland",
+	"bspwm":         "bspwm",
+	"awesome":       "awesome",
+	"dwm":           "dwm",
+	"xmonad":        "xmonad",
+	"herbstluftwm":  "herbstluftwm",
+	"qtile":         "Qtile",
+	"fluxbox":       "Fluxbox",
+	"icewm":         "IceWM",
+	"river":         "river",
+	"wayfire":       "Wayfire",
+	"labwc":         "labwc",
+	"niri":          "niri",
+	"weston":        "Weston",
+	"enlightenment": "Enlightenment",
+	"fvwm":          "FVWM",
+	"spectrwm":      "spectrwm",
+}
+
+// getDE detecta el entorno de escritorio por variables de sesión y, si no
+// hay, buscando procesos conocidos. Devuelve "" fuera de una sesión gráfica
+func getDE(procs []string) string {
+	// XDG_CURRENT_DESKTOP puede traer varios valores, ej. "ubuntu:GNOME"
+	if de := os.Getenv("XDG_CURRENT_DESKTOP"); de != "" {
+		parts := strings.Split(de, ":")
+		return normalizeDE(parts[len(parts)-1])
+	}
+	if de := os.Getenv("DESKTOP_SESSION"); de != "" && !strings.Contains(de, "/") {
+		return normalizeDE(de)
+	}
+	for _, p := range procs {
+		if de, ok := desktopProcesses[p]; ok {
+			return de
+		}
+	}
+	return ""
+}
+
+// normalizeDE unifica los nombres que exportan los distintos escritorios
+func normalizeDE(de string) string {
+	switch strings.ToLower(de) {
+	case "kde", "plasma", "plasmawayland":
+		return "KDE Plasma"
+	case "gnome", "gnome-xorg", "gnome-classic":
+		return "GNOME"
+	case "xfce", "xfce4":
+		return "XFCE"
+	case "x-cinnamon", "cinnamon":
+		return "Cinnamon"
+	case "mate":
+		return "MATE"
+	case "lxqt":
+		return "LXQt"
+	}
+	return de
+}
+
+// getWM detecta el gestor de ventanas y el tipo de sesión (X11/Wayland)
+func getWM(procs []string) string {
+	wm := ""
+	switch {
+	case os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "":
+		wm = "Hyprland"
+	case os.Getenv("SWAYSOCK") != "":
+		wm = "Sway"
+	case os.Getenv("I3SOCK") != "":
+		wm = "i3"
+	default:
+		for _, p := range procs {
+			if name, ok := wmProcesses[p]; ok {
+				wm = name
+				break
+			}
+		}
+	}
+	if wm == "" {
+		return ""
+	}
+
+	if session := sessionType(); session != "" {
+		wm += " (" + session + ")"
+	}
+	return wm
+}
+
+// sessionType devuelve "Wayland", "X11" o "" según las variables de la sesión
+func sessionType() string {
+	switch strings.ToLower(os.Getenv("XDG_SESSION_TYPE")) {
+	case "wayland":
+		return "Wayland"
+	case "x11":
+		return "X11"
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		return "Wayland"
+	}
+	if os.Getenv("DISPLAY") != "" {
+		return "X11"
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package sysinfo
+
+import "strings"
+
+// Mount guarda el uso de un sistema de archivos montado
+type Mount struct {
+	Path   string `json:"mountpoint"`
+	Device string `json:"device"`
+	FSType string `json:"fstype"`
+	Pool   string `json:"pool,omitempty"` // pool ZFS o btrfs cuyo uso se muestra
+	Usage
+}
+
+// Drive es un disco físico (o el disco virtual de una VM), sin importar
+// cómo esté particionado. Lo listan Drives; el uso de espacio está en Mount
+type Drive struct {
+	Name       string `json:"name"`            // nombre del dispositivo, ej. "nvme0n1" o "sda"
+	Model      string `json:"model,omitempty"` // ej. "Samsung SSD 980 PRO 1TB"
+	Size       uint64 `json:"size_bytes"`
+	Type       string `json:"type,omitempty"` // "NVMe", "SSD", "HDD", "eMMC", "SD" o "virtual"
+	Rotational bool   `json:"rotational"`
+	Removable  bool   `json:"removable"`
+}
+
+// DefaultDisks son los puntos de montaje que se leen si Options no dice
+// otra cosa. Con ["auto"] se descubren todos los sistemas de archivos reales
+var DefaultDisks = []string{"/"}
+
+// pseudoFilesystems no representan espacio en disco real
+var pseudoFilesystems = map[string]bool{
+	"squashfs": true, "overlay": true, "tmpfs": true, "devtmpfs": true, "ramfs": true,
+	"iso9660": true, "efivarfs": true,
+}
+
+// mountEntry es una línea de /proc/mounts
+type mountEntry struct {
+	device, path, fstype string
+}
+
+// realFilesystem indica si el montaje corresponde a un disco de verdad
+func realFilesystem(e mountEntry) bool {
+	if pseudoFilesystems[e.fstype] {
+		return false
+	}
+	// ZFS usa el nombre del dataset en vez de un /dev, y Windows letras de unidad
+	if e.fstype == "zfs" || (len(e.device) >= 2 && e.device[1] == ':') {
+		return true
+	}
+	return strings.HasPrefix(e.device, "/dev/") && !strings.HasPrefix(e.device, "/dev/loop")
+}
+
+// getDisks devuelve el uso de los puntos de montaje pedidos, o de todos
+// los discos reales si paths es ["auto"]. Los bind mounts y los subvolúmenes
+// de btrfs comparten dispositivo, así que se muestra solo el primero; en ZFS
+// y btrfs se muestra el uso del pool, una vez por pool
+func getDisks(tr *tracer, paths []string, pc *procCache) []Mount {
+	entries := readMounts(tr, pc)
+
+	// Dispositivo de cada punto de montaje (el último montaje gana)
+	byPath := map[string]mountEntry{}
+	for _, e := range entries {
+		byPath[e.path] = e
+	}
+
+	var selected []mountEntry
+	if len(paths) == 1 && paths[0] == "auto" {
+		for _, e := range entries {
+			if realFilesystem(e) {
+				selected = append(selected, e)
+			}
+		}
+	} else {
+		for _, p := range paths {
+			e, ok := byPath[p]
+			if !ok {
+				// Sin /proc/mounts (u otro SO) igual se puede hacer statfs
+				e = mountEntry{path: p}
+			}
+			selected = append(selected, e)
+		}
+	}
+
+	var mounts []Mount
+	var pools poolUsages
+	seen := map[string]bool{}
+	for _, e := range selected {
+		if e.device != "" && seen[e.device] {
+			continue
+		}
+		m := Mount{Path: e.path, Device: e.device, FSType: e.fstype}
+		if pool, usage, ok := pools.usage(tr, e); ok {
+			if seen["pool:"+pool] {
+				continue
+			}
+			seen["pool:"+pool] = true
+			m.Pool, m.Usage = pool, usage
+		} else if m.Usage = getDisk(tr, e.path); m.Usage.Total == 0 {
+			continue
+		}
+		seen[e.device] = true
+		mounts = append(mounts, m)
+	}
+	return mounts
+}
+
+// cString convierte un array de C terminado en NUL a string
+func cString(b []int8) string {
+	out := make([]byte, 0, len(b))
+	for _, c := range b {
+		if c == 0 {
+			break
+		}
+		out = append(out, byte(c))
+	}
+	return string(out)
+}
@@ -0,0 +1,116 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// readMounts parsea /proc/mounts. Las rutas traen los espacios como \040
+func readMounts(tr *tracer, pc *procCache) []mountEntry {
+	var entries []mountEntry
+	scanner := bufio.NewScanner(bytes.NewReader(pc.Read(tr, "/proc/mounts")))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		entries = append(entries, mountEntry{
+			device: unescapeMount(fields[0]),
+			path:   unescapeMount(fields[1]),
+			fstype: fields[2],
+		})
+	}
+	return entries
+}
+
+// unescapeMount decodifica los escapes octales de /proc/mounts (\040, \011...)
+func unescapeMount(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			var ch byte
+			if _, err := fmt.Sscanf(s[i+1:i+4], "%03o", &ch); err == nil {
+				b.WriteByte(ch)
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// virtualBlocks son prefijos de /sys/block que no son discos: loops, RAM,
+// device mapper (LVM, LUKS), RAID por software, lectoras y disquetes
+var virtualBlocks = []string{"loop", "ram", "zram", "dm-", "md", "sr", "fd", "nbd"}
+
+// getDrives lista los discos de /sys/block con su modelo, tamaño y tipo
+func getDrives(tr *tracer) []Drive {
+	entries, err := os.ReadDir("/sys/block")
+	if err != nil {
+		return nil
+	}
+	var drives []Drive
+	for _, e := range entries {
+		name := e.Name()
+		if hasAnyPrefix(name, virtualBlocks) {
+			continue
+		}
+		base := filepath.Join("/sys/block", name)
+		// size está en sectores de 512 bytes sin importar el disco; 0 es
+		// una lectora de tarjetas vacía
+		sectors := tr.readUint(filepath.Join(base, "size"))
+		if sectors == 0 {
+			continue
+		}
+		d := Drive{
+			Name:       name,
+			Model:      strings.Join(strings.Fields(tr.readTrim(filepath.Join(base, "device", "model"))), " "),
+			Size:       sectors * 512,
+			Rotational: tr.readTrim(filepath.Join(base, "queue", "rotational")) == "1",
+			Removable:  tr.readTrim(filepath.Join(base, "removable")) == "1",
+		}
+		d.Type = driveType(tr, name, base, d.Rotational)
+		drives = append(drives, d)
+	}
+	return drives
+}
+
+// driveType deduce el tipo por el nombre del dispositivo y, para SATA/SCSI,
+// por el flag rotational
+func driveType(tr *tracer, name, base string, rotational bool) string {
+	switch {
+	case strings.HasPrefix(name, "nvme"):
+		return "NVMe"
+	case strings.HasPrefix(name, "mmcblk"):
+		// "MMC" para eMMC soldada, "SD" para tarjetas
+		if tr.readTrim(filepath.Join(base, "device", "type")) == "SD" {
+			return "SD"
+		}
+		return "eMMC"
+	case hasAnyPrefix(name, []string{"vd", "xvd"}):
+		return "virtual"
+	case rotational:
+		return "HDD"
+	}
+	return "SSD"
+}
+
+// hasAnyPrefix indica si s empieza con alguno de los prefijos
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
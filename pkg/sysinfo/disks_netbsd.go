@@ -0,0 +1,59 @@
+//go:build netbsd
+
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// getDisk usa "df -k -P" porque el paquete syscall no expone statvfs en
+// NetBSD
+func getDisk(tr *tracer, path string) Usage {
+	out := tr.runCmd("df", "-k", "-P", path)
+	lines := strings.Split(out, "\n")
+	if out == "N/A" || len(lines) < 2 {
+		return Usage{}
+	}
+
+	// Filesystem 1024-blocks Used Available Capacity Mounted on
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return Usage{}
+	}
+	total, err1 := strconv.ParseUint(fields[1], 10, 64)
+	avail, err2 := strconv.ParseUint(fields[3], 10, 64)
+	if err1 != nil || err2 != nil || avail > total {
+		return Usage{}
+	}
+	return Usage{Total: total * 1024, Used: (total - avail) * 1024}
+}
+
+// readMounts parsea la salida de mount, con líneas como
+// "/dev/wd0a on / type ffs (local)"
+func readMounts(tr *tracer, pc *procCache) []mountEntry {
+	out := tr.runCmd("mount")
+	if out == "N/A" {
+		return nil
+	}
+
+	var entries []mountEntry
+	for _, line := range strings.Split(out, "\n") {
+		device, rest, ok := strings.Cut(line, " on ")
+		if !ok {
+			continue
+		}
+		path, rest, ok := strings.Cut(rest, " type ")
+		if !ok {
+			continue
+		}
+		fstype, _, _ := strings.Cut(rest, " ")
+		entries = append(entries, mountEntry{device: device, path: path, fstype: fstype})
+	}
+	return entries
+}
+
+// getDrives devuelve nil: por ahora los discos físicos solo se listan en Linux
+func getDrives(tr *tracer) []Drive {
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build openbsd
+
+package sysinfo
+
+import "syscall"
+
+// getDisk obtiene el espacio total y usado del disco. En OpenBSD los campos
+// de Statfs_t llevan el prefijo F_
+func getDisk(tr *tracer, path string) Usage {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return Usage{}
+	}
+	totalBytes := stat.F_blocks * uint64(stat.F_bsize)
+	freeBytes := uint64(stat.F_bavail) * uint64(stat.F_bsize)
+	return Usage{Total: totalBytes, Used: totalBytes - freeBytes}
+}
+
+// readMounts lista los sistemas de archivos montados con getfsstat(2)
+func readMounts(tr *tracer, pc *procCache) []mountEntry {
+	n, err := syscall.Getfsstat(nil, 2) // MNT_NOWAIT
+	if err != nil || n == 0 {
+		return nil
+	}
+	buf := make([]syscall.Statfs_t, n)
+	n, err = syscall.Getfsstat(buf, 2)
+	if err != nil {
+		return nil
+	}
+
+	entries := make([]mountEntry, 0, n)
+	for _, st := range buf[:n] {
+		entries = append(entries, mountEntry{
+			device: cString(st.F_mntfromname[:]),
+			path:   cString(st.F_mntonname[:]),
+			fstype: cString(st.F_fstypename[:]),
+		})
+	}
+	return entries
+}
+
+// getDrives devuelve nil: por ahora los discos físicos solo se listan en Linux
+func getDrives(tr *tracer) []Drive {
+	return nil
+}
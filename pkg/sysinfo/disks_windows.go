@@ -0,0 +1,54 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// driveFixed es DRIVE_FIXED de GetDriveType: discos locales
+const driveFixed = 3
+
+// readMounts lista las unidades locales (C:\, D:\...) con su sistema de archivos
+func readMounts(tr *tracer, pc *procCache) []mountEntry {
+	buf := make([]uint16, 256)
+	n, _, _ := procGetLogicalDriveStringsW.Call(uintptr(len(buf)), uintptr(unsafe.Pointer(&buf[0])))
+	if n == 0 || int(n) > len(buf) {
+		return nil
+	}
+
+	// El buffer trae "C:\\\x00D:\\\x00\x00"
+	var entries []mountEntry
+	for _, drive := range splitUTF16(buf[:n]) {
+		p, _ := syscall.UTF16PtrFromString(drive)
+		if t, _, _ := procGetDriveTypeW.Call(uintptr(unsafe.Pointer(p))); t != driveFixed {
+			continue
+		}
+		fs := make([]uint16, 64)
+		procGetVolumeInformationW.Call(uintptr(unsafe.Pointer(p)), 0, 0, 0, 0, 0,
+			uintptr(unsafe.Pointer(&fs[0])), uintptr(len(fs)))
+		entries = append(entries, mountEntry{device: drive, path: drive, fstype: syscall.UTF16ToString(fs)})
+	}
+	return entries
+}
+
+// splitUTF16 separa una lista de strings UTF-16 terminados en NUL
+func splitUTF16(buf []uint16) []string {
+	var out []string
+	start := 0
+	for i, c := range buf {
+		if c == 0 {
+			if i > start {
+				out = append(out, syscall.UTF16ToString(buf[start:i]))
+			}
+			start = i + 1
+		}
+	}
+	return out
+}
+
+// getDrives devuelve nil: por ahora los discos físicos solo se listan en Linux
+func getDrives(tr *tracer) []Drive {
+	return nil
+}
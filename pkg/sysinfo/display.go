@@ -0,0 +1,108 @@
+package sysinfo
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Display guarda un monitor conectado
+type Display struct {
+	Name    string  `json:"name"`
+	Width   int     `json:"width"`
+	Height  int     `json:"height"`
+	Refresh float64 `json:"refresh_hz,omitempty"`
+}
+
+// displaysFromXrandr parsea "xrandr --current": el modo activo lleva un "*"
+func displaysFromXrandr(tr *tracer) []Display {
+	if _, err := exec.LookPath("xrandr"); err != nil {
+		return nil
+	}
+	out := tr.runCmd("xrandr", "--current")
+	if out == "N/A" {
+		return nil
+	}
+
+	var displays []Display
+	current := ""
+	for _, line := range strings.Split(out, "\n") {
+		// "HDMI-1 connected primary 1920x1080+0+0 ..."
+		if !strings.HasPrefix(line, " ") {
+			fields := strings.Fields(line)
+			current = ""
+			if len(fields) >= 2 && fields[1] == "connected" {
+				current = fields[0]
+			}
+			continue
+		}
+		// "   1920x1080     60.00*+  50.00"
+		if current == "" || !strings.Contains(line, "*") {
+			continue
+		}
+		fields := strings.Fields(line)
+		w, h, ok := parseResolution(fields[0])
+		if !ok {
+			continue
+		}
+		d := Display{Name: current, Width: w, Height: h}
+		for _, f := range fields[1:] {
+			if strings.Contains(f, "*") {
+				d.Refresh, _ = strconv.ParseFloat(strings.TrimRight(f, "*+"), 64)
+			}
+		}
+		displays = append(displays, d)
+		current = ""
+	}
+	return displays
+}
+
+// displaysFromWlrRandr parsea wlr-randr (sway, Hyprland, river...)
+func displaysFromWlrRandr(tr *tracer) []Display {
+	if _, err := exec.LookPath("wlr-randr"); err != nil {
+		return nil
+	}
+	out := tr.runCmd("wlr-randr")
+	if out == "N/A" {
+		return nil
+	}
+
+	var displays []Display
+	current := ""
+	for _, line := range strings.Split(out, "\n") {
+		// 'HDMI-A-1 "Dell Inc. DELL U2419H"' arranca cada salida
+		if !strings.HasPrefix(line, " ") {
+			current = strings.Fields(line + " ")[0]
+			continue
+		}
+		// "    1920x1080 px, 60.000000 Hz (preferred, current)"
+		if current == "" || !strings.Contains(line, "current") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		w, h, ok := parseResolution(fields[0])
+		if !ok {
+			continue
+		}
+		d := Display{Name: current, Width: w, Height: h}
+		d.Refresh, _ = strconv.ParseFloat(fields[2], 64)
+		displays = append(displays, d)
+		current = ""
+	}
+	return displays
+}
+
+// parseResolution convierte "1920x1080" (o "1920x1080i") en ancho y alto
+func parseResolution(s string) (w, h int, ok bool) {
+	ws, hs, found := strings.Cut(s, "x")
+	if !found {
+		return 0, 0, false
+	}
+	hs = strings.TrimRight(hs, "ip")
+	w, err1 := strconv.Atoi(ws)
+	h, err2 := strconv.Atoi(hs)
+	return w, h, err1 == nil && err2 == nil
+}
@@ -0,0 +1,52 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// getDisplays detecta los monitores conectados. En una sesión gráfica
+// pregunta a wlr-randr/xrandr (dan la frecuencia); si no, lee /sys/class/drm
+func getDisplays(tr *tracer) []Display {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if displays := displaysFromWlrRandr(tr); len(displays) > 0 {
+			return displays
+		}
+	}
+	if os.Getenv("DISPLAY") != "" {
+		if displays := displaysFromXrandr(tr); len(displays) > 0 {
+			return displays
+		}
+	}
+	return displaysFromDRM(tr)
+}
+
+// displaysFromDRM lee el modo preferido de cada conector conectado
+func displaysFromDRM(tr *tracer) []Display {
+	connectors, _ := filepath.Glob("/sys/class/drm/card[0-9]*-*")
+	sort.Strings(connectors)
+
+	var displays []Display
+	for _, conn := range connectors {
+		if tr.readTrim(filepath.Join(conn, "status")) != "connected" {
+			continue
+		}
+		// La primera línea de modes es el modo preferido
+		modes := strings.SplitN(tr.readTrim(filepath.Join(conn, "modes")), "\n", 2)
+		w, h, ok := parseResolution(modes[0])
+		if !ok {
+			continue
+		}
+		// card0-eDP-1 -> eDP-1
+		name := filepath.Base(conn)
+		if _, after, found := strings.Cut(name, "-"); found {
+			name = after
+		}
+		displays = append(displays, Display{Name: name, Width: w, Height: h})
+	}
+	return displays
+}
@@ -0,0 +1,32 @@
+package sysinfo
+
+import (
+	"os"
+	"strings"
+)
+
+// Firewall es el firewall activo. Contar las reglas suele necesitar root,
+// así que sin privilegios Rules queda en 0
+type Firewall struct {
+	Name   string `json:"name,omitempty"`   // ej. "ufw", "firewalld", "nftables", "pf"
+	Status string `json:"status,omitempty"` // "active" o "inactive"; vacío si no se sabe
+	Rules  int    `json:"rules,omitempty"`  // cantidad de reglas, 0 si no se pudieron contar
+}
+
+// isRoot indica si se puede consultar lo que solo ve root. Sin root no se
+// corren esos comandos, para no llenar la salida de errores ni pedir sudo
+func isRoot() bool {
+	return os.Geteuid() == 0
+}
+
+// countOutputLines cuenta las líneas no vacías de la salida de un comando
+// que empiezan con prefix
+func countOutputLines(out, prefix string) int {
+	n := 0
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" && strings.HasPrefix(line, prefix) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,32 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"os"
+	"strings"
+)
+
+// getFirewall detecta pf (por /dev/pf) o ipfw (FreeBSD y DragonFly). El
+// estado y las reglas de pf solo los puede leer root
+func getFirewall(tr *tracer) Firewall {
+	if _, err := os.Stat("/dev/pf"); err == nil {
+		fw := Firewall{Name: "pf"}
+		if isRoot() {
+			// "Status: Enabled for 3 days 02:11:45"
+			if strings.Contains(tr.runCmd("pfctl", "-s", "info"), "Status: Enabled") {
+				fw.Status = "active"
+			} else {
+				fw.Status = "inactive"
+			}
+			if rules := tr.runCmd("pfctl", "-s", "rules"); rules != "N/A" {
+				fw.Rules = countOutputLines(rules, "")
+			}
+		}
+		return fw
+	}
+	if sysctlUint("net.inet.ip.fw.enable") == 1 {
+		return Firewall{Name: "ipfw", Status: "active"}
+	}
+	return Firewall{}
+}
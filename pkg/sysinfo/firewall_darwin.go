@@ -0,0 +1,22 @@
+//go:build darwin
+
+package sysinfo
+
+import "strings"
+
+// socketfilterfw es la herramienta del firewall de aplicaciones de macOS
+const socketfilterfw = "/usr/libexec/ApplicationFirewall/socketfilterfw"
+
+// getFirewall informa el firewall de aplicaciones de macOS, que se puede
+// consultar sin root. pf viene cargado pero casi siempre sin reglas propias
+func getFirewall(tr *tracer) Firewall {
+	// "Firewall is enabled. (State = 1)"
+	out := tr.runCmd(socketfilterfw, "--getglobalstate")
+	switch {
+	case strings.Contains(out, "enabled"):
+		return Firewall{Name: "Application Firewall", Status: "active"}
+	case strings.Contains(out, "disabled"):
+		return Firewall{Name: "Application Firewall", Status: "inactive"}
+	}
+	return Firewall{}
+}
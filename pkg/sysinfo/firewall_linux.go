@@ -0,0 +1,68 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+)
+
+// getFirewall busca primero los frontends (firewalld, ufw), que es lo que
+// el usuario configura, y si no hay ninguno activo el backend del kernel
+// (nftables o iptables). Las reglas se cuentan solo corriendo como root
+func getFirewall(tr *tracer) Firewall {
+	if slices.Contains(processNames(tr), "firewalld") {
+		return Firewall{Name: "firewalld", Status: "active", Rules: countNftRules(tr)}
+	}
+	ufw := ufwEnabled(tr)
+	if ufw == "yes" {
+		return Firewall{Name: "ufw", Status: "active", Rules: countNftRules(tr)}
+	}
+	if _, err := os.Stat("/sys/module/nf_tables"); err == nil {
+		return Firewall{Name: "nftables", Rules: countNftRules(tr)}
+	}
+	if _, err := os.Stat("/sys/module/ip_tables"); err == nil {
+		return Firewall{Name: "iptables", Rules: countIptablesRules(tr)}
+	}
+	if ufw == "no" {
+		return Firewall{Name: "ufw", Status: "inactive"}
+	}
+	return Firewall{}
+}
+
+// ufwEnabled lee ENABLED de la configuración de ufw (se puede leer sin
+// root): "yes", "no" o "" si ufw no está instalado
+func ufwEnabled(tr *tracer) string {
+	return strings.ToLower(readAssignment(tr, "/etc/ufw/ufw.conf", "ENABLED"))
+}
+
+// countNftRules cuenta las reglas de "nft -j list ruleset". Con iptables-nft
+// las reglas de iptables también están ahí
+func countNftRules(tr *tracer) int {
+	if !isRoot() {
+		return 0
+	}
+	var ruleset struct {
+		Nftables []map[string]json.RawMessage `json:"nftables"`
+	}
+	if json.Unmarshal([]byte(tr.runCmd("nft", "-j", "list", "ruleset")), &ruleset) != nil {
+		return countIptablesRules(tr)
+	}
+	n := 0
+	for _, obj := range ruleset.Nftables {
+		if _, ok := obj["rule"]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// countIptablesRules cuenta las reglas de "iptables -S" (las líneas -A)
+func countIptablesRules(tr *tracer) int {
+	if !isRoot() {
+		return 0
+	}
+	return countOutputLines(tr.runCmd("iptables", "-S"), "-A ")
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package sysinfo
+
+// firewallPolicy es donde Windows guarda si el firewall está prendido en
+// cada perfil de red
+const firewallPolicy = `SYSTEM\CurrentControlSet\Services\SharedAccess\Parameters\FirewallPolicy\`
+
+// getFirewall informa si Windows Defender Firewall está activo en algún
+// perfil (dominio, privado o público)
+func getFirewall(tr *tracer) Firewall {
+	fw := Firewall{Name: "Windows Defender Firewall", Status: "inactive"}
+	for _, profile := range []string{"DomainProfile", "StandardProfile", "PublicProfile"} {
+		if regDword(firewallPolicy+profile, "EnableFirewall") == 1 {
+			fw.Status = "active"
+		}
+	}
+	return fw
+}
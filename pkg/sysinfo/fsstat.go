@@ -0,0 +1,34 @@
+//go:build darwin || freebsd || dragonfly
+
+package sysinfo
+
+import "syscall"
+
+// readMounts lista los sistemas de archivos montados con getfsstat(2)
+func readMounts(tr *tracer, pc *procCache) []mountEntry {
+	n, err := syscall.Getfsstat(nil, 2) // MNT_NOWAIT
+	if err != nil || n == 0 {
+		return nil
+	}
+	buf := make([]syscall.Statfs_t, n)
+	n, err = syscall.Getfsstat(buf, 2)
+	if err != nil {
+		return nil
+	}
+
+	entries := make([]mountEntry, 0, n)
+	for _, st := range buf[:n] {
+		// Los nombres de los campos coinciden en macOS, FreeBSD y DragonFly
+		entries = append(entries, mountEntry{
+			device: cString(st.Mntfromname[:]),
+			path:   cString(st.Mntonname[:]),
+			fstype: cString(st.Fstypename[:]),
+		})
+	}
+	return entries
+}
+
+// getDrives devuelve nil: por ahora los discos físicos solo se listan en Linux
+func getDrives(tr *tracer) []Drive {
+	return nil
+}
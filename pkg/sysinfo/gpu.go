@@ -0,0 +1,72 @@
+package sysinfo
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// gpusFromLspci parsea la salida de "lspci -mm" buscando controladoras de video
+func gpusFromLspci(tr *tracer) []string {
+	if _, err := exec.LookPath("lspci"); err != nil {
+		return nil
+	}
+	out := tr.runCmd("lspci", "-mm")
+	if out == "N/A" {
+		return nil
+	}
+
+	var gpus []string
+	for _, line := range strings.Split(out, "\n") {
+		// Formato: slot "clase" "vendor" "dispositivo" ...
+		fields := quotedFields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		class := fields[0]
+		if !strings.Contains(class, "VGA") && !strings.Contains(class, "3D") && !strings.Contains(class, "Display") {
+			continue
+		}
+		gpus = append(gpus, shortVendor(fields[1])+" "+fields[2])
+	}
+	return gpus
+}
+
+// quotedFields devuelve los campos entre comillas de una línea de lspci -mm
+func quotedFields(line string) []string {
+	var fields []string
+	for {
+		start := strings.IndexByte(line, '"')
+		if start < 0 {
+			return fields
+		}
+		end := strings.IndexByte(line[start+1:], '"')
+		if end < 0 {
+			return fields
+		}
+		fields = append(fields, line[start+1:start+1+end])
+		line = line[start+end+2:]
+	}
+}
+
+// shortVendor acorta nombres como "Advanced Micro Devices, Inc. [AMD/ATI]"
+func shortVendor(vendor string) string {
+	lower := strings.ToLower(vendor)
+	switch {
+	case strings.Contains(lower, "nvidia"):
+		return "NVIDIA"
+	case strings.Contains(lower, "intel"):
+		return "Intel"
+	case strings.Contains(lower, "advanced micro devices"), strings.Contains(lower, "ati technologies"):
+		return "AMD"
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(vendor, " Corporation"), ", Inc.")
+}
+
+// GPUDriver es el driver de una GPU y su memoria de video. Va aparte de
+// GPUs porque la VRAM usada cambia y los nombres se guardan en la caché
+type GPUDriver struct {
+	Vendor  string `json:"vendor"`            // ej. "NVIDIA", para asociarlo a su línea de GPUs
+	Driver  string `json:"driver,omitempty"`  // módulo del kernel, ej. "nvidia", "amdgpu" o "i915"
+	Version string `json:"version,omitempty"` // ej. "550.67" o "Mesa 24.0.5"
+	VRAM    Usage  `json:"vram"`              // vacío en las integradas, que usan la RAM
+}
@@ -0,0 +1,78 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// getGPUs usa pciconf en FreeBSD/DragonFly y lspci (de pciutils) si está instalado
+func getGPUs(tr *tracer) []string {
+	if gpus := gpusFromPciconf(tr); len(gpus) > 0 {
+		return gpus
+	}
+	return gpusFromLspci(tr)
+}
+
+// gpusFromPciconf parsea "pciconf -lv", que lista cada dispositivo así:
+//
+//	vgapci0@pci0:0:2:0:	class=0x030000 ...
+//	    vendor     = 'Intel Corporation'
+//	    device     = 'HD Graphics 620'
+//	    class      = display
+func gpusFromPciconf(tr *tracer) []string {
+	if _, err := exec.LookPath("pciconf"); err != nil {
+		return nil
+	}
+	out := tr.runCmd("pciconf", "-lv")
+	if out == "N/A" {
+		return nil
+	}
+
+	var gpus []string
+	var vendor, device string
+	for _, line := range strings.Split(out, "\n") {
+		// Una línea sin sangría empieza un dispositivo nuevo
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			vendor, device = "", ""
+			continue
+		}
+		key, val, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		val = strings.Trim(strings.TrimSpace(val), "'")
+		switch strings.TrimSpace(key) {
+		case "vendor":
+			vendor = val
+		case "device":
+			device = val
+		case "class":
+			if val == "display" && device != "" {
+				gpus = append(gpus, shortVendor(vendor)+" "+device)
+			}
+		}
+	}
+	return gpus
+}
+
+// getDisplays usa wlr-randr o xrandr según la sesión (no hay DRM en /sys)
+func getDisplays(tr *tracer) []Display {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if displays := displaysFromWlrRandr(tr); len(displays) > 0 {
+			return displays
+		}
+	}
+	if os.Getenv("DISPLAY") != "" {
+		return displaysFromXrandr(tr)
+	}
+	return nil
+}
+
+// getGPUDrivers no está implementado en los BSD: los drivers de drm-kmod no
+// exponen la VRAM de una forma común
+func getGPUDrivers(tr *tracer) []GPUDriver {
+	return nil
+}
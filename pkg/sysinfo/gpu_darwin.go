@@ -0,0 +1,146 @@
+//go:build darwin
+
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// spDisplays guarda la salida de system_profiler, que tarda bastante y la
+// usan tanto el módulo de GPU como el de pantallas
+var spDisplays struct {
+	once sync.Once
+	out  string
+}
+
+// displaysProfile ejecuta "system_profiler SPDisplaysDataType" una sola vez
+func displaysProfile(tr *tracer) string {
+	spDisplays.once.Do(func() {
+		spDisplays.out = tr.runCmd("system_profiler", "SPDisplaysDataType")
+	})
+	return spDisplays.out
+}
+
+// getGPUs toma los "Chipset Model" de system_profiler
+func getGPUs(tr *tracer) []string {
+	var gpus []string
+	for _, line := range strings.Split(displaysProfile(tr), "\n") {
+		if model, ok := strings.CutPrefix(strings.TrimSpace(line), "Chipset Model:"); ok {
+			gpus = append(gpus, strings.TrimSpace(model))
+		}
+	}
+	return gpus
+}
+
+// getGPUDrivers toma de system_profiler el fabricante, la versión de Metal y
+// la VRAM total de cada GPU. Las de Apple Silicon comparten la RAM y no
+// tienen "VRAM"; macOS no informa la usada
+//
+//	Chipset Model: AMD Radeon Pro 5500M
+//	VRAM (Total): 4 GB
+//	Vendor: AMD (0x1002)
+//	Metal Support: Metal 3
+func getGPUDrivers(tr *tracer) []GPUDriver {
+	var drivers []GPUDriver
+	for _, line := range strings.Split(displaysProfile(tr), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok {
+			continue
+		}
+		if key == "Chipset Model" {
+			drivers = append(drivers, GPUDriver{})
+			continue
+		}
+		if len(drivers) == 0 {
+			continue
+		}
+		d := &drivers[len(drivers)-1]
+		switch {
+		case key == "Vendor":
+			d.Vendor, _, _ = strings.Cut(value, " (")
+		case key == "Metal Support" || key == "Metal Family":
+			d.Version = value
+		case strings.HasPrefix(key, "VRAM"):
+			d.VRAM.Total = parseProfileSize(value)
+		}
+	}
+	return drivers
+}
+
+// parseProfileSize convierte tamaños de system_profiler como "4 GB" o
+// "1536 MB" (potencias de 1024) a bytes
+func parseProfileSize(s string) uint64 {
+	f := strings.Fields(s)
+	if len(f) != 2 {
+		return 0
+	}
+	n, err := strconv.ParseUint(f[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	switch f[1] {
+	case "GB":
+		return n << 30
+	case "MB":
+		return n << 20
+	}
+	return 0
+}
+
+// getDisplays parsea la sección "Displays:" de system_profiler. Cada monitor
+// es una línea "Nombre:" seguida de su "Resolution:" y, en versiones nuevas,
+// "UI Looks like: 1512 x 982 @ 120.00Hz"
+func getDisplays(tr *tracer) []Display {
+	var displays []Display
+	inDisplays := false
+	for _, line := range strings.Split(displaysProfile(tr), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "Displays:":
+			inDisplays = true
+		case !inDisplays || trimmed == "":
+		case strings.HasSuffix(trimmed, ":") && !strings.Contains(trimmed, ": "):
+			displays = append(displays, Display{Name: strings.TrimSuffix(trimmed, ":")})
+		case len(displays) > 0:
+			d := &displays[len(displays)-1]
+			key, val, _ := strings.Cut(trimmed, ": ")
+			switch key {
+			case "Resolution":
+				// "3024 x 1964 Retina" o "2560 x 1440 (QHD) @ 60.00Hz"
+				fields := strings.Fields(val)
+				if len(fields) >= 3 {
+					d.Width, _ = strconv.Atoi(fields[0])
+					d.Height, _ = strconv.Atoi(fields[2])
+				}
+				if d.Refresh == 0 {
+					d.Refresh = refreshFromProfile(val)
+				}
+			case "UI Looks like":
+				if hz := refreshFromProfile(val); hz > 0 {
+					d.Refresh = hz
+				}
+			}
+		}
+	}
+
+	// Descarta entradas sin resolución (ej. subsecciones sin monitor)
+	out := displays[:0]
+	for _, d := range displays {
+		if d.Width > 0 {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// refreshFromProfile saca los Hz de un texto como "... @ 120.00Hz"
+func refreshFromProfile(s string) float64 {
+	_, after, ok := strings.Cut(s, "@")
+	if !ok {
+		return 0
+	}
+	hz, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(after), "Hz"), 64)
+	return hz
+}
@@ -0,0 +1,211 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gpuVendors traduce los IDs de vendor PCI más comunes
+var gpuVendors = map[string]string{
+	"0x8086": "Intel",
+	"0x10de": "NVIDIA",
+	"0x1002": "AMD",
+	"0x1af4": "Virtio",
+	"0x15ad": "VMware",
+	"0x1234": "QEMU",
+	"0x80ee": "VirtualBox",
+}
+
+// getGPUs detecta las tarjetas gráficas. Prueba primero lspci (da nombres
+// legibles) y si no está lee /sys/class/drm directamente
+func getGPUs(tr *tracer) []string {
+	if gpus := gpusFromLspci(tr); len(gpus) > 0 {
+		return gpus
+	}
+	return gpusFromDRM(tr)
+}
+
+// gpusFromDRM lee vendor, device y driver de cada /sys/class/drm/cardN
+func gpusFromDRM(tr *tracer) []string {
+	cards, _ := filepath.Glob("/sys/class/drm/card[0-9]*")
+	sort.Strings(cards)
+
+	var gpus []string
+	for _, card := range cards {
+		// card0-DP-1 y similares son conectores, no tarjetas
+		if strings.Contains(filepath.Base(card), "-") {
+			continue
+		}
+		dev := filepath.Join(card, "device")
+		vendorID := tr.readTrim(filepath.Join(dev, "vendor"))
+		deviceID := tr.readTrim(filepath.Join(dev, "device"))
+		if vendorID == "" {
+			continue
+		}
+
+		name := gpuVendors[vendorID]
+		if name == "" {
+			name = "GPU"
+		}
+		// El driver propietario de NVIDIA expone el modelo en /proc
+		if vendorID == "0x10de" {
+			if model := nvidiaModel(tr); model != "" {
+				name = model
+			}
+		}
+
+		desc := name + " [" + strings.TrimPrefix(vendorID, "0x") + ":" + strings.TrimPrefix(deviceID, "0x") + "]"
+		if driver := ueventValue(tr, filepath.Join(dev, "uevent"), "DRIVER"); driver != "" {
+			desc += " (" + driver + ")"
+		}
+		gpus = append(gpus, desc)
+	}
+	return gpus
+}
+
+// nvidiaModel lee el modelo desde /proc/driver/nvidia si el driver propietario está cargado
+func nvidiaModel(tr *tracer) string {
+	infos, _ := filepath.Glob("/proc/driver/nvidia/gpus/*/information")
+	for _, path := range infos {
+		data, err := tr.readFile(path)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if model, ok := strings.CutPrefix(line, "Model:"); ok {
+				return strings.TrimSpace(model)
+			}
+		}
+	}
+	return ""
+}
+
+// gpuBackend sabe leer la versión del driver y la VRAM de una familia de
+// drivers. dev es el directorio del dispositivo PCI de la tarjeta
+type gpuBackend interface {
+	version(tr *tracer) string
+	vram(tr *tracer, dev string) Usage
+}
+
+// gpuBackends elige el backend según el driver del kernel. Los que no están
+// (virtio_gpu, nouveau...) usan Mesa sin VRAM
+var gpuBackends = map[string]gpuBackend{
+	"nvidia": nvidiaBackend{},
+	"amdgpu": amdgpuBackend{},
+}
+
+// getGPUDrivers arma el driver de cada /sys/class/drm/cardN, en el orden
+// de su dirección PCI (el mismo que usa lspci)
+func getGPUDrivers(tr *tracer) []GPUDriver {
+	cards, _ := filepath.Glob("/sys/class/drm/card[0-9]*")
+	var devs []string
+	for _, card := range cards {
+		if strings.Contains(filepath.Base(card), "-") {
+			continue
+		}
+		if dev, err := filepath.EvalSymlinks(filepath.Join(card, "device")); err == nil {
+			devs = append(devs, dev)
+		}
+	}
+	sort.Slice(devs, func(a, b int) bool { return filepath.Base(devs[a]) < filepath.Base(devs[b]) })
+
+	var drivers []GPUDriver
+	for _, dev := range devs {
+		driver := ueventValue(tr, filepath.Join(dev, "uevent"), "DRIVER")
+		vendor := gpuVendors[tr.readTrim(filepath.Join(dev, "vendor"))]
+		if driver == "" || vendor == "" {
+			continue
+		}
+		backend, ok := gpuBackends[driver]
+		if !ok {
+			backend = mesaBackend{}
+		}
+		drivers = append(drivers, GPUDriver{Vendor: vendor, Driver: driver, Version: backend.version(tr), VRAM: backend.vram(tr, dev)})
+	}
+	return drivers
+}
+
+// nvidiaBackend es el driver propietario de NVIDIA. La VRAM sale de NVML;
+// como usarla directo necesita cgo se le pregunta a nvidia-smi, que la usa
+type nvidiaBackend struct{}
+
+func (nvidiaBackend) version(tr *tracer) string {
+	return tr.readTrim("/sys/module/nvidia/version")
+}
+
+// vram le pregunta a nvidia-smi por esta tarjeta según su dirección PCI,
+// ej. "0000:01:00.0". Responde en MiB: "8192, 1234"
+func (nvidiaBackend) vram(tr *tracer, dev string) Usage {
+	out := tr.runCmd("nvidia-smi", "--id="+filepath.Base(dev), "--query-gpu=memory.total,memory.used", "--format=csv,noheader,nounits")
+	total, used, ok := strings.Cut(out, ", ")
+	if !ok {
+		return Usage{}
+	}
+	t, err1 := strconv.ParseUint(total, 10, 64)
+	u, err2 := strconv.ParseUint(used, 10, 64)
+	if err1 != nil || err2 != nil {
+		return Usage{}
+	}
+	return Usage{Total: t << 20, Used: u << 20}
+}
+
+// amdgpuBackend es el driver de AMD: el kernel expone la VRAM en sysfs y la
+// parte de usuario es Mesa
+type amdgpuBackend struct{}
+
+func (amdgpuBackend) version(tr *tracer) string {
+	return mesaBackend{}.version(tr)
+}
+
+func (amdgpuBackend) vram(tr *tracer, dev string) Usage {
+	return Usage{
+		Total: tr.readUint(filepath.Join(dev, "mem_info_vram_total")),
+		Used:  tr.readUint(filepath.Join(dev, "mem_info_vram_used")),
+	}
+}
+
+// mesaBackend son los drivers libres sin VRAM propia que se pueda leer
+// (Intel integradas, nouveau, virtio)
+type mesaBackend struct{}
+
+func (mesaBackend) version(tr *tracer) string {
+	if v := mesaVersion(tr); v != "" {
+		return "Mesa " + v
+	}
+	return ""
+}
+
+func (mesaBackend) vram(*tracer, string) Usage {
+	return Usage{}
+}
+
+// mesa guarda la versión de Mesa, que es la misma para todas las tarjetas
+var mesa struct {
+	once    sync.Once
+	version string
+}
+
+// mesaVersion la saca de "glxinfo -B", que solo funciona en una sesión
+// gráfica: "OpenGL version string: 4.6 (Compatibility Profile) Mesa 24.0.5-1ubuntu1"
+func mesaVersion(tr *tracer) string {
+	mesa.once.Do(func() {
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return
+		}
+		for _, line := range strings.Split(tr.runCmd("glxinfo", "-B"), "\n") {
+			if !strings.HasPrefix(line, "OpenGL version string:") {
+				continue
+			}
+			if _, v, ok := strings.Cut(line, "Mesa "); ok && v != "" {
+				mesa.version, _, _ = strings.Cut(strings.Fields(v)[0], "-")
+			}
+		}
+	})
+	return mesa.version
+}
@@ -0,0 +1,120 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// displayClassKey es la clase de dispositivos "Display adapters" en el registro
+const displayClassKey = `SYSTEM\CurrentControlSet\Control\Class\{4d36e968-e325-11ce-bfc1-08002be10318}`
+
+// getGPUs lee DriverDesc de cada adaptador de video del registro
+func getGPUs(tr *tracer) []string {
+	var gpus []string
+	seen := map[string]bool{}
+	for _, sub := range regSubkeys(displayClassKey) {
+		desc := regString(displayClassKey+`\`+sub, "DriverDesc")
+		// Se saltan duplicados y el adaptador genérico de Remote Desktop
+		if desc == "" || seen[desc] || desc == "Microsoft Basic Display Adapter" {
+			continue
+		}
+		seen[desc] = true
+		gpus = append(gpus, desc)
+	}
+	return gpus
+}
+
+// getGPUDrivers lee el fabricante, la versión del driver y la memoria de
+// cada adaptador del registro. Windows no guarda la VRAM usada, solo el total
+// (qwMemorySize desde Windows 10; MemorySize se queda en 4 GiB)
+func getGPUDrivers(tr *tracer) []GPUDriver {
+	var drivers []GPUDriver
+	seen := map[string]bool{}
+	for _, sub := range regSubkeys(displayClassKey) {
+		key := displayClassKey + `\` + sub
+		desc := regString(key, "DriverDesc")
+		if desc == "" || seen[desc] || desc == "Microsoft Basic Display Adapter" {
+			continue
+		}
+		seen[desc] = true
+		vram := regQword(key, "HardwareInformation.qwMemorySize")
+		if vram == 0 {
+			vram = regQword(key, "HardwareInformation.MemorySize")
+		}
+		drivers = append(drivers, GPUDriver{
+			Vendor:  shortVendor(regString(key, "ProviderName")),
+			Version: regString(key, "DriverVersion"),
+			VRAM:    Usage{Total: vram},
+		})
+	}
+	return drivers
+}
+
+// displayDevice es DISPLAY_DEVICEW
+type displayDevice struct {
+	Cb           uint32
+	DeviceName   [32]uint16
+	DeviceString [128]uint16
+	StateFlags   uint32
+	DeviceID     [128]uint16
+	DeviceKey    [128]uint16
+}
+
+// devMode es DEVMODEW con la unión de impresora/pantalla como bytes
+type devMode struct {
+	DeviceName       [32]uint16
+	SpecVersion      uint16
+	DriverVersion    uint16
+	Size             uint16
+	DriverExtra      uint16
+	Fields           uint32
+	_                [16]byte
+	Color            int16
+	Duplex           int16
+	YResolution      int16
+	TTOption         int16
+	Collate          int16
+	FormName         [32]uint16
+	LogPixels        uint16
+	BitsPerPel       uint32
+	PelsWidth        uint32
+	PelsHeight       uint32
+	DisplayFlags     uint32
+	DisplayFrequency uint32
+	_                [8]uint32
+}
+
+// enumCurrentSettings es ENUM_CURRENT_SETTINGS
+const enumCurrentSettings = 0xFFFFFFFF
+
+// getDisplays recorre los monitores conectados al escritorio y pide su modo actual
+func getDisplays(tr *tracer) []Display {
+	var displays []Display
+	for i := uint32(0); ; i++ {
+		var dev displayDevice
+		dev.Cb = uint32(unsafe.Sizeof(dev))
+		if ok, _, _ := procEnumDisplayDevicesW.Call(0, uintptr(i), uintptr(unsafe.Pointer(&dev)), 0); ok == 0 {
+			break
+		}
+		// DISPLAY_DEVICE_ATTACHED_TO_DESKTOP = 1
+		if dev.StateFlags&1 == 0 {
+			continue
+		}
+
+		var mode devMode
+		mode.Size = uint16(unsafe.Sizeof(mode))
+		ok, _, _ := procEnumDisplaySettingsW.Call(uintptr(unsafe.Pointer(&dev.DeviceName[0])), enumCurrentSettings, uintptr(unsafe.Pointer(&mode)))
+		if ok == 0 {
+			continue
+		}
+		displays = append(displays, Display{
+			Name:    syscall.UTF16ToString(dev.DeviceName[:]),
+			Width:   int(mode.PelsWidth),
+			Height:  int(mode.PelsHeight),
+			Refresh: float64(mode.DisplayFrequency),
+		})
+	}
+	return displays
+}
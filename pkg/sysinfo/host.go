@@ -0,0 +1,42 @@
+package sysinfo
+
+import "strings"
+
+// dmiPlaceholders son valores de relleno que dejan muchos fabricantes en el
+// firmware y que no dicen nada del equipo
+var dmiPlaceholders = []string{
+	"to be filled by o.e.m.", "system product name", "system manufacturer",
+	"system version", "default string", "not applicable", "not specified",
+	"none", "o.e.m.", "oem", "123456789", "x.x",
+}
+
+// cleanDMI devuelve el valor sin espacios, o "" si es un valor de relleno
+func cleanDMI(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	lower := strings.ToLower(s)
+	for _, p := range dmiPlaceholders {
+		if lower == p {
+			return ""
+		}
+	}
+	return s
+}
+
+// joinModel arma el modelo a partir de fabricante, producto y versión del
+// firmware, ej. "Dell Inc. XPS 13 9310". Lenovo guarda el nombre comercial
+// en la versión ("ThinkPad X1 Carbon Gen 9") y un código en el producto,
+// así que en ese caso se usa la versión
+func joinModel(vendor, product, version string) string {
+	vendor, product, version = cleanDMI(vendor), cleanDMI(product), cleanDMI(version)
+	if strings.Contains(version, " ") && !strings.Contains(product, " ") {
+		product = version
+	}
+	if product == "" {
+		return ""
+	}
+	// Muchos fabricantes ya incluyen la marca en el producto
+	if vendor == "" || strings.HasPrefix(strings.ToLower(product), strings.ToLower(strings.Fields(vendor)[0])) {
+		return product
+	}
+	return vendor + " " + product
+}
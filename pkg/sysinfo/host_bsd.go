@@ -0,0 +1,33 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// getHostModel devuelve el modelo del equipo según el SMBIOS. OpenBSD y
+// NetBSD lo exponen por sysctl; FreeBSD y DragonFly lo dejan en el kenv
+func getHostModel(tr *tracer) string {
+	switch runtime.GOOS {
+	case "openbsd":
+		return joinModel(sysctlString("hw.vendor"), sysctlString("hw.product"), sysctlString("hw.version"))
+	case "netbsd":
+		return joinModel(sysctlString("machdep.dmi.system-vendor"), sysctlString("machdep.dmi.system-product"),
+			sysctlString("machdep.dmi.system-version"))
+	}
+	return joinModel(kenv(tr, "smbios.system.maker"), kenv(tr, "smbios.system.product"), kenv(tr, "smbios.system.version"))
+}
+
+// kenv lee una variable del entorno del kernel, "" si no existe
+func kenv(tr *tracer, name string) string {
+	if _, err := exec.LookPath("kenv"); err != nil {
+		return ""
+	}
+	val := tr.runCmd("kenv", "-q", name)
+	if val == "N/A" {
+		return ""
+	}
+	return val
+}
@@ -0,0 +1,8 @@
+//go:build darwin
+
+package sysinfo
+
+// getHostModel devuelve el identificador del Mac, ej. "MacBookPro18,3"
+func getHostModel(tr *tracer) string {
+	return sysctlString("hw.model")
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package sysinfo
+
+import "strings"
+
+// getHostModel devuelve el modelo del equipo. En PCs y laptops se lee de
+// DMI; en placas ARM (Raspberry Pi, etc.) del device tree. En Android lo
+// informa getprop
+func getHostModel(tr *tracer) string {
+	if isAndroid() {
+		return androidModel(tr)
+	}
+	const dmi = "/sys/class/dmi/id/"
+	if model := joinModel(tr.readTrim(dmi+"sys_vendor"), tr.readTrim(dmi+"product_name"), tr.readTrim(dmi+"product_version")); model != "" {
+		return model
+	}
+	// El device tree termina el string con un byte 0
+	for _, path := range []string{"/proc/device-tree/model", "/sys/firmware/devicetree/base/model"} {
+		if model := strings.TrimRight(tr.readTrim(path), "\x00"); model != "" {
+			return model
+		}
+	}
+	return ""
+}
@@ -0,0 +1,12 @@
+//go:build windows
+
+package sysinfo
+
+// biosKey guarda los datos SMBIOS que Windows copia al registro al arrancar
+const biosKey = `HARDWARE\DESCRIPTION\System\BIOS`
+
+// getHostModel devuelve fabricante y modelo del equipo
+func getHostModel(tr *tracer) string {
+	return joinModel(regString(biosKey, "SystemManufacturer"), regString(biosKey, "SystemProductName"),
+		regString(biosKey, "SystemVersion"))
+}
@@ -0,0 +1,138 @@
+package sysinfo
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Temperature guarda la lectura de un sensor ya clasificado
+type Temperature struct {
+	Sensor  string  `json:"sensor"` // cpu, gpu o nvme
+	Celsius float64 `json:"celsius"`
+}
+
+// DefaultSensors son los sensores que se leen si Options no dice otra cosa
+var DefaultSensors = []string{"cpu", "gpu", "nvme"}
+
+// hwmonChip es un dispositivo de /sys/class/hwmon con sus temperaturas
+type hwmonChip struct {
+	name  string
+	temps []hwmonTemp
+}
+
+// hwmonTemp es una entrada temp*_input con su etiqueta (si tiene)
+type hwmonTemp struct {
+	label   string
+	celsius float64
+}
+
+// hwmonSensors dice a qué sensor corresponde cada driver de hwmon y qué
+// etiquetas preferir (las etiquetas cambian muchísimo según el hardware)
+var hwmonSensors = map[string]struct {
+	sensor    string
+	preferred []string
+}{
+	"coretemp":    {"cpu", []string{"Package id 0"}},
+	"k10temp":     {"cpu", []string{"Tctl", "Tdie"}},
+	"zenpower":    {"cpu", []string{"Tdie", "Tctl"}},
+	"cpu_thermal": {"cpu", nil},
+	"cpu-thermal": {"cpu", nil},
+	"soc_thermal": {"cpu", nil},
+	"amdgpu":      {"gpu", []string{"edge"}},
+	"radeon":      {"gpu", nil},
+	"nouveau":     {"gpu", nil},
+	"nvme":        {"nvme", []string{"Composite"}},
+}
+
+// readHwmon lee todos los chips de /sys/class/hwmon
+func readHwmon(tr *tracer) []hwmonChip {
+	dirs, _ := filepath.Glob("/sys/class/hwmon/hwmon*")
+	sort.Strings(dirs)
+
+	var chips []hwmonChip
+	for _, dir := range dirs {
+		chip := hwmonChip{name: tr.readTrim(filepath.Join(dir, "name"))}
+		inputs, _ := filepath.Glob(filepath.Join(dir, "temp*_input"))
+		sort.Strings(inputs)
+		for _, input := range inputs {
+			milli, err := strconv.Atoi(tr.readTrim(input))
+			if err != nil {
+				continue
+			}
+			prefix := strings.TrimSuffix(input, "_input")
+			chip.temps = append(chip.temps, hwmonTemp{
+				label:   tr.readTrim(prefix + "_label"),
+				celsius: float64(milli) / 1000,
+			})
+		}
+		if len(chip.temps) > 0 {
+			chips = append(chips, chip)
+		}
+	}
+	return chips
+}
+
+// getTemperatures devuelve una lectura por chip para los sensores pedidos
+func getTemperatures(tr *tracer, sensors []string) []Temperature {
+	wanted := map[string]bool{}
+	order := map[string]int{}
+	for i, s := range sensors {
+		wanted[s] = true
+		order[s] = i
+	}
+
+	var temps []Temperature
+	acpi := -1.0
+	for _, chip := range readHwmon(tr) {
+		known, ok := hwmonSensors[chip.name]
+		if !ok {
+			// acpitz sirve de respaldo para la CPU en equipos sin coretemp/k10temp
+			if chip.name == "acpitz" && acpi < 0 {
+				acpi = chip.temps[0].celsius
+			}
+			continue
+		}
+		if !wanted[known.sensor] {
+			continue
+		}
+		temps = append(temps, Temperature{Sensor: known.sensor, Celsius: pickTemp(chip, known.preferred)})
+	}
+
+	if acpi >= 0 && wanted["cpu"] && !hasSensor(temps, "cpu") {
+		temps = append(temps, Temperature{Sensor: "cpu", Celsius: acpi})
+	}
+
+	// Se muestran en el orden en que se pidieron
+	sort.SliceStable(temps, func(a, b int) bool { return order[temps[a].Sensor] < order[temps[b].Sensor] })
+	return temps
+}
+
+// pickTemp elige la temperatura con la etiqueta preferida o, si no hay, la más alta
+func pickTemp(chip hwmonChip, preferred []string) float64 {
+	for _, label := range preferred {
+		for _, t := range chip.temps {
+			if t.label == label {
+				return t.celsius
+			}
+		}
+	}
+	max := chip.temps[0].celsius
+	for _, t := range chip.temps[1:] {
+		if t.celsius > max {
+			max = t.celsius
+		}
+	}
+	return max
+}
+
+// hasSensor indica si ya hay una lectura de ese sensor
+func hasSensor(temps []Temperature, sensor string) bool {
+	for _, t := range temps {
+		if t.Sensor == sensor {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package sysinfo
+
+import "strings"
+
+// initNames son los nombres del proceso 1 de cada sistema de init. "init"
+// no está porque lo usan varios (SysV, OpenRC, runit en algunas distros) y
+// se resuelve mirando los directorios que deja cada uno
+var initNames = map[string]string{
+	"systemd":     "systemd",
+	"openrc-init": "OpenRC",
+	"runit":       "runit",
+	"runit-init":  "runit",
+	"s6-svscan":   "s6",
+	"s6-linux-in": "s6", // comm se corta en 15 caracteres
+	"dinit":       "dinit",
+	"finit":       "finit",
+	"shepherd":    "GNU Shepherd",
+	"launchd":     "launchd",
+}
+
+// systemdVersion agrega la versión a "systemd", ej. "systemd 252". La
+// primera línea de "systemctl --version" es "systemd 252 (252.22-1~deb12u1)"
+func systemdVersion(tr *tracer) string {
+	first, _, _ := strings.Cut(tr.runCmd("systemctl", "--version"), "\n")
+	fields := strings.Fields(first)
+	if len(fields) < 2 || fields[0] != "systemd" {
+		return "systemd"
+	}
+	return "systemd " + fields[1]
+}
@@ -0,0 +1,13 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+// getInit devuelve el init de los BSD: /sbin/init arranca los scripts de
+// rc(8). Algunos sistemas basados en FreeBSD lo reemplazan, así que se mira
+// el nombre del proceso 1
+func getInit(tr *tracer) string {
+	if name, ok := initNames[tr.runCmd("ps", "-o", "comm=", "-p", "1")]; ok {
+		return name
+	}
+	return "BSD init (rc)"
+}
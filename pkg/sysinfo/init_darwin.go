@@ -0,0 +1,8 @@
+//go:build darwin
+
+package sysinfo
+
+// getInit devuelve el init de macOS, que siempre es launchd
+func getInit(tr *tracer) string {
+	return "launchd"
+}
@@ -0,0 +1,45 @@
+//go:build linux
+
+package sysinfo
+
+import "os"
+
+// initDirs son los directorios que deja cada init en /run, para cuando el
+// proceso 1 se llama simplemente "init"
+var initDirs = []struct{ path, name string }{
+	{"/run/systemd/system", "systemd"},
+	{"/run/openrc", "OpenRC"},
+	{"/run/runit", "runit"},
+	{"/etc/runit/runsvdir/current", "runit"},
+	{"/run/s6", "s6"},
+	{"/run/s6-rc", "s6"},
+}
+
+// getInit detecta el sistema de init por el nombre del proceso 1 y, si es
+// un "init" genérico, por los directorios de cada uno. Sin ninguna pista
+// pero con /etc/inittab es SysV. En un contenedor el proceso 1 suele ser
+// otra cosa (un shell, la app) y el init queda vacío
+func getInit(tr *tracer) string {
+	comm := tr.readTrim("/proc/1/comm")
+	name := initNames[comm]
+	if comm == "init" {
+		name = initFromDirs()
+	}
+	if name == "systemd" {
+		return systemdVersion(tr)
+	}
+	return name
+}
+
+// initFromDirs busca el init por lo que deja en el sistema de archivos
+func initFromDirs() string {
+	for _, d := range initDirs {
+		if _, err := os.Stat(d.path); err == nil {
+			return d.name
+		}
+	}
+	if _, err := os.Stat("/etc/inittab"); err == nil {
+		return "SysV init"
+	}
+	return ""
+}
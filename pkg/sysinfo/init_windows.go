@@ -0,0 +1,8 @@
+//go:build windows
+
+package sysinfo
+
+// getInit devuelve "" porque Windows no tiene un init que mostrar
+func getInit(tr *tracer) string {
+	return ""
+}
@@ -0,0 +1,71 @@
+package sysinfo
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// KernelInfo amplía Kernel (el release, ej. "6.1.0-18-amd64") con la
+// versión completa y si el kernel está "tainted"
+type KernelInfo struct {
+	Version   string   `json:"version,omitempty"`    // como uname -v, ej. "#1 SMP PREEMPT_DYNAMIC Debian 6.1.76-1 (2024-02-01)"
+	BuildDate string   `json:"build_date,omitempty"` // fecha de compilación sacada de Version, ej. "2024-02-01"
+	Tainted   uint64   `json:"tainted"`              // valor crudo de /proc/sys/kernel/tainted, 0 si está limpio
+	Taints    []string `json:"taints,omitempty"`     // motivos decodificados de Tainted
+}
+
+// taintFlags son los motivos de cada bit de /proc/sys/kernel/tainted, como
+// los describe Documentation/admin-guide/tainted-kernels.rst
+var taintFlags = []string{
+	"proprietary module",    // P
+	"forced module load",    // F
+	"unsafe SMP",            // S
+	"forced module unload",  // R
+	"machine check",         // M
+	"bad page",              // B
+	"user taint",            // U
+	"kernel died",           // D
+	"ACPI table overridden", // A
+	"kernel warning",        // W
+	"staging driver",        // C
+	"firmware workaround",   // I
+	"out-of-tree module",    // O
+	"unsigned module",       // E
+	"soft lockup",           // L
+	"live patched",          // K
+	"auxiliary taint",       // X
+	"randstruct build",      // T
+	"in-kernel test",        // N
+}
+
+// decodeTaint devuelve los motivos de los bits prendidos de tainted
+func decodeTaint(tainted uint64) []string {
+	var out []string
+	for bit, name := range taintFlags {
+		if tainted&(1<<bit) != 0 {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// isoDate busca una fecha ya escrita como "2024-02-01" (Debian la agrega así)
+var isoDate = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+// parseBuildDate saca la fecha de compilación de la versión del kernel. La
+// mayoría termina con la fecha de date(1), ej. "... Thu Jan 11 12:00:00 UTC 2024"
+func parseBuildDate(version string) string {
+	if d := isoDate.FindString(version); d != "" {
+		return d
+	}
+	fields := strings.Fields(version)
+	if len(fields) < 6 {
+		return ""
+	}
+	t, err := time.Parse(time.UnixDate, strings.Join(fields[len(fields)-6:], " "))
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,27 @@
+//go:build darwin || freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import "strings"
+
+// getKernel obtiene la versión del kernel por sysctl, sin correr uname
+func getKernel() string {
+	if release := sysctlString("kern.osrelease"); release != "" {
+		return release
+	}
+	return "N/A"
+}
+
+// getKernelInfo lee la versión completa de kern.version. Es una línea
+// larga, ej. "FreeBSD 14.0-RELEASE #0 releng/14.0-n265380: Fri Nov 10 ..."
+// (en macOS "Darwin Kernel Version 23.2.0: Wed Nov 15 ...; root:xnu..."),
+// y la fecha de compilación está en el medio
+func getKernelInfo(tr *tracer) KernelInfo {
+	version, _, _ := strings.Cut(sysctlString("kern.version"), "\n")
+	k := KernelInfo{Version: strings.TrimSpace(version)}
+	if _, after, ok := strings.Cut(k.Version, ": "); ok {
+		date, _, _ := strings.Cut(after, ";")
+		k.BuildDate = parseBuildDate(date)
+	}
+	return k
+}
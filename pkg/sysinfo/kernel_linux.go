@@ -0,0 +1,44 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"strconv"
+	"syscall"
+)
+
+// utsString convierte un campo de syscall.Utsname (un array de int8 o uint8
+// según la arquitectura) a string
+func utsString[T int8 | uint8](field []T) string {
+	b := make([]byte, 0, len(field))
+	for _, c := range field {
+		if c == 0 {
+			break
+		}
+		b = append(b, byte(c))
+	}
+	return string(b)
+}
+
+// getKernel obtiene la versión del kernel con uname(2), sin correr uname
+func getKernel() string {
+	var u syscall.Utsname
+	if syscall.Uname(&u) != nil {
+		return "N/A"
+	}
+	return utsString(u.Release[:])
+}
+
+// getKernelInfo lee la versión completa con uname(2) y el estado de taint
+// de /proc (se puede leer sin root)
+func getKernelInfo(tr *tracer) KernelInfo {
+	var k KernelInfo
+	var u syscall.Utsname
+	if syscall.Uname(&u) == nil {
+		k.Version = utsString(u.Version[:])
+		k.BuildDate = parseBuildDate(k.Version)
+	}
+	k.Tainted, _ = strconv.ParseUint(tr.readTrim("/proc/sys/kernel/tainted"), 10, 64)
+	k.Taints = decodeTaint(k.Tainted)
+	return k
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package sysinfo
+
+// getKernelInfo devuelve la etiqueta de compilación de Windows, ej.
+// "22621.1.amd64fre.ni_release.220506-1250". Windows no tiene taint
+func getKernelInfo(tr *tracer) KernelInfo {
+	return KernelInfo{Version: regString(currentVersionKey, "BuildLabEx")}
+}
@@ -0,0 +1,33 @@
+package sysinfo
+
+import "strings"
+
+// Load guarda la carga promedio de 1, 5 y 15 minutos
+type Load struct {
+	One     float64 `json:"1m"`
+	Five    float64 `json:"5m"`
+	Fifteen float64 `json:"15m"`
+}
+
+// Processes guarda cuántos procesos hay y cuántos están corriendo
+type Processes struct {
+	Total   int `json:"total"`
+	Running int `json:"running"`
+}
+
+// psProcesses cuenta los procesos (y los que están corriendo) con ps, para
+// los sistemas que no tienen /proc
+func psProcesses(tr *tracer) Processes {
+	var procs Processes
+	out := tr.runCmd("ps", "-axo", "stat=")
+	if out == "N/A" {
+		return procs
+	}
+	for _, state := range strings.Fields(out) {
+		procs.Total++
+		if strings.HasPrefix(state, "R") {
+			procs.Running++
+		}
+	}
+	return procs
+}
@@ -0,0 +1,24 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// getLoad lee la carga promedio con "sysctl -n vm.loadavg" (el struct
+// loadavg cambia de tamaño entre BSDs y OpenBSD no lo acepta por nombre) y
+// cuenta los procesos con ps
+func getLoad(tr *tracer, pc *procCache) (Load, Processes) {
+	var load Load
+
+	// FreeBSD lo imprime como "{ 0.10 0.20 0.15 }", el resto sin llaves
+	out := strings.Trim(tr.runCmd("sysctl", "-n", "vm.loadavg"), "{} ")
+	if fields := strings.Fields(out); len(fields) >= 3 {
+		load.One, _ = strconv.ParseFloat(fields[0], 64)
+		load.Five, _ = strconv.ParseFloat(fields[1], 64)
+		load.Fifteen, _ = strconv.ParseFloat(fields[2], 64)
+	}
+	return load, psProcesses(tr)
+}
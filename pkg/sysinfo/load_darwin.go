@@ -0,0 +1,21 @@
+//go:build darwin
+
+package sysinfo
+
+import "encoding/binary"
+
+// getLoad lee vm.loadavg (struct loadavg: 3 x uint32 en punto fijo + fscale)
+// y cuenta los procesos con ps
+func getLoad(tr *tracer, pc *procCache) (Load, Processes) {
+	var load Load
+
+	if b := sysctlRaw("vm.loadavg", 24); b != nil {
+		scale := float64(binary.LittleEndian.Uint64(b[16:24]))
+		if scale > 0 {
+			load.One = float64(binary.LittleEndian.Uint32(b[0:4])) / scale
+			load.Five = float64(binary.LittleEndian.Uint32(b[4:8])) / scale
+			load.Fifteen = float64(binary.LittleEndian.Uint32(b[8:12])) / scale
+		}
+	}
+	return load, psProcesses(tr)
+}
@@ -0,0 +1,30 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// getLoad lee /proc/loadavg: "0.52 0.58 0.59 2/1234 5678"
+func getLoad(tr *tracer, pc *procCache) (Load, Processes) {
+	var load Load
+	var procs Processes
+
+	fields := strings.Fields(string(pc.Read(tr, "/proc/loadavg")))
+	if len(fields) < 4 {
+		return load, procs
+	}
+	load.One, _ = strconv.ParseFloat(fields[0], 64)
+	load.Five, _ = strconv.ParseFloat(fields[1], 64)
+	load.Fifteen, _ = strconv.ParseFloat(fields[2], 64)
+
+	// El total de loadavg cuenta hilos, así que los procesos se cuentan en /proc
+	running, _, _ := strings.Cut(fields[3], "/")
+	procs.Running, _ = strconv.Atoi(running)
+	pids, _ := filepath.Glob("/proc/[0-9]*")
+	procs.Total = len(pids)
+	return load, procs
+}
@@ -0,0 +1,69 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// localeFiles son los archivos donde las distros guardan el locale del
+// sistema: el de systemd (lo que escribe localectl) y el de Debian
+var localeFiles = []string{"/etc/locale.conf", "/etc/default/locale"}
+
+// getLocale devuelve el locale, ej. "es_AR.UTF-8". Primero el de la sesión
+// (LC_ALL y LANG, como lo resuelve libc) y si no el del sistema
+func getLocale(tr *tracer) string {
+	for _, key := range []string{"LC_ALL", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	for _, path := range localeFiles {
+		if v := readAssignment(tr, path, "LANG"); v != "" {
+			return v
+		}
+	}
+	// En macOS el idioma elegido en Ajustes, ej. "es_AR"
+	if runtime.GOOS == "darwin" {
+		if v := tr.runCmd("defaults", "read", "-g", "AppleLocale"); v != "N/A" {
+			return v
+		}
+	}
+	return ""
+}
+
+// getTimezone devuelve el nombre de la zona horaria (ej.
+// "America/Argentina/Buenos_Aires") y su diferencia con UTC en segundos.
+// Si no se encuentra el nombre se usa la abreviatura, ej. "-03"
+func getTimezone(tr *tracer) (string, int) {
+	abbr, offset := time.Now().Zone()
+	if tz := strings.TrimPrefix(os.Getenv("TZ"), ":"); tz != "" && !filepath.IsAbs(tz) {
+		return tz, offset
+	}
+	if tz := tr.readTrim("/etc/timezone"); tz != "" {
+		return tz, offset
+	}
+	// /etc/localtime es un enlace a .../zoneinfo/<zona> en Linux y macOS
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if _, tz, ok := strings.Cut(target, "zoneinfo/"); ok {
+			return tz, offset
+		}
+	}
+	return abbr, offset
+}
+
+// readAssignment busca CLAVE=valor en un archivo tipo shell, "" si no está
+func readAssignment(tr *tracer, path, key string) string {
+	data, err := tr.readFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), key+"="); ok {
+			return strings.Trim(v, `"'`)
+		}
+	}
+	return ""
+}
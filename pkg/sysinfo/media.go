@@ -0,0 +1,128 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Media es lo que está sonando en un reproductor con MPRIS (Spotify, mpv,
+// Firefox...). Vacío si no hay ninguno abierto
+type Media struct {
+	Player string `json:"player,omitempty"` // nombre del reproductor, ej. "Spotify"
+	Status string `json:"status,omitempty"` // "Playing" o "Paused"
+	Artist string `json:"artist,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Album  string `json:"album,omitempty"`
+}
+
+// Nombres de MPRIS en el bus de sesión
+const (
+	mprisPrefix = "org.mpris.MediaPlayer2."
+	mprisPath   = "/org/mpris/MediaPlayer2"
+	mprisPlayer = "org.mpris.MediaPlayer2.Player"
+)
+
+// getMedia pregunta a los reproductores por D-Bus y se queda con uno que
+// esté reproduciendo antes que con uno en pausa. playerctl solo se usa si
+// el bus no es un socket unix al que se pueda conectar
+func getMedia(ctx context.Context, tr *tracer) Media {
+	bus, err := dialSessionBus(ctx, tr)
+	if errors.Is(err, errNoSessionBus) {
+		return Media{}
+	}
+	if err != nil {
+		return playerctlMedia(tr)
+	}
+	defer bus.Close()
+
+	var paused Media
+	for _, name := range mprisPlayers(bus) {
+		m, err := mprisMedia(bus, name)
+		if errors.Is(err, errDBusNoReply) {
+			continue
+		}
+		if err != nil {
+			// La conexión quedó a mitad de un mensaje o se cortó
+			break
+		}
+		if m.Status == "Playing" {
+			return m
+		}
+		if m.Status == "Paused" && paused.Player == "" {
+			paused = m
+		}
+	}
+	return paused
+}
+
+// mprisPlayers lista los nombres del bus de los reproductores abiertos
+func mprisPlayers(bus *dbusConn) []string {
+	v, err := bus.call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "ListNames")
+	if err != nil || len(v) == 0 {
+		return nil
+	}
+	names, _ := v[0].([]any)
+	var players []string
+	for _, n := range names {
+		if name, _ := n.(string); strings.HasPrefix(name, mprisPrefix) {
+			players = append(players, name)
+		}
+	}
+	return players
+}
+
+// busProperties pide todas las propiedades de una interfaz del objeto
+// MPRIS de name
+func busProperties(bus *dbusConn, name, iface string) (map[string]any, error) {
+	v, err := bus.call(name, mprisPath, "org.freedesktop.DBus.Properties", "GetAll", iface)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+	props, _ := v[0].(map[string]any)
+	return props, nil
+}
+
+// mprisMedia arma la info de un reproductor a partir de sus propiedades
+func mprisMedia(bus *dbusConn, name string) (Media, error) {
+	m := Media{Player: strings.TrimPrefix(name, mprisPrefix)}
+	// Las instancias se llaman ej. "firefox.instance_1_42"
+	m.Player, _, _ = strings.Cut(m.Player, ".")
+	root, err := busProperties(bus, name, "org.mpris.MediaPlayer2")
+	if err != nil {
+		return m, err
+	}
+	if identity, _ := root["Identity"].(string); identity != "" {
+		m.Player = identity
+	}
+	player, err := busProperties(bus, name, mprisPlayer)
+	if err != nil {
+		return m, err
+	}
+	m.Status, _ = player["PlaybackStatus"].(string)
+
+	// Metadata es un a{sv}; xesam:artist es una lista
+	meta, _ := player["Metadata"].(map[string]any)
+	m.Title, _ = meta["xesam:title"].(string)
+	m.Album, _ = meta["xesam:album"].(string)
+	artists, _ := meta["xesam:artist"].([]any)
+	var names []string
+	for _, a := range artists {
+		if s, _ := a.(string); s != "" {
+			names = append(names, s)
+		}
+	}
+	m.Artist = strings.Join(names, ", ")
+	return m, nil
+}
+
+// playerctlMedia es la alternativa para un bus que no es un socket unix
+// (ej. una dirección tcp:), que playerctl sí sabe usar
+func playerctlMedia(tr *tracer) Media {
+	out := tr.runCmd("playerctl", "metadata", "--format", "{{playerName}}\t{{status}}\t{{artist}}\t{{title}}\t{{album}}")
+	f := strings.Split(out, "\t")
+	if len(f) != 5 || f[3] == "" {
+		return Media{}
+	}
+	return Media{Player: f[0], Status: f[1], Artist: f[2], Title: f[3], Album: f[4]}
+}
@@ -0,0 +1,117 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultPublicIPURL es el endpoint estilo ipify que devuelve la IP pública en texto plano
+const DefaultPublicIPURL = "https://api.ipify.org"
+
+// publicIPTimeout limita cuánto puede tardar la consulta de la IP pública
+const publicIPTimeout = 3 * time.Second
+
+// NetInterface guarda una interfaz de red activa y sus direcciones
+type NetInterface struct {
+	Name string `json:"name"`
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
+// getInterfaces lista las interfaces levantadas que no son loopback, con su
+// primera IPv4 y (si withIPv6) su primera IPv6 de alcance global
+func getInterfaces(withIPv6 bool) []NetInterface {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	var out []NetInterface
+	for _, iface := range ifaces {
+		// Sin carrier (ej. docker0 sin contenedores) cuenta como caída
+		if iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagRunning == 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+
+		ni := NetInterface{Name: iface.Name}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			if ip4 := ipnet.IP.To4(); ip4 != nil {
+				if ni.IPv4 == "" {
+					ni.IPv4 = ip4.String()
+				}
+				continue
+			}
+			// IsGlobalUnicast descarta link-local (fe80::/10) pero deja pasar
+			// las ULA (fc00::/7), que tampoco son de alcance global
+			if withIPv6 && ni.IPv6 == "" && ipnet.IP.IsGlobalUnicast() && !ipnet.IP.IsPrivate() {
+				ni.IPv6 = ipnet.IP.String()
+			}
+		}
+
+		// Interfaces sin direcciones (bridges vacíos, túneles caídos) no aportan nada
+		if ni.IPv4 != "" || ni.IPv6 != "" {
+			out = append(out, ni)
+		}
+	}
+	return out
+}
+
+// firstAddr devuelve la primera IPv4 (o IPv6 si v6) de las interfaces
+func firstAddr(ifaces []NetInterface, v6 bool) string {
+	for _, ni := range ifaces {
+		if v6 && ni.IPv6 != "" {
+			return ni.IPv6
+		}
+		if !v6 && ni.IPv4 != "" {
+			return ni.IPv4
+		}
+	}
+	return "N/A"
+}
+
+// getPublicIP consulta la IP pública con un único GET. Cualquier error
+// (sin red, timeout, respuesta rara) devuelve "N/A" para no colgar cafetch
+func getPublicIP(ctx context.Context, tr *tracer, endpoint string) string {
+	ctx, cancel := context.WithTimeout(ctx, publicIPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "N/A"
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		tr.source("request", endpoint, err)
+		return "N/A"
+	}
+	defer resp.Body.Close()
+
+	if tr.source("request", endpoint, statusError(resp)) != nil {
+		return "N/A"
+	}
+
+	// Una IP nunca ocupa más de unas decenas de bytes
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return "N/A"
+	}
+	ip := strings.TrimSpace(string(body))
+	if net.ParseIP(ip) == nil {
+		tr.parse("public IP", ip, errors.New("not an IP address"))
+		return "N/A"
+	}
+	return ip
+}
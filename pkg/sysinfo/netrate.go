@@ -0,0 +1,45 @@
+package sysinfo
+
+import "time"
+
+// NetRate es el tráfico actual de la interfaz activa, medido en
+// Options.SampleInterval
+type NetRate struct {
+	Interface string  `json:"interface"`
+	RX        float64 `json:"rx_bytes_per_second"`
+	TX        float64 `json:"tx_bytes_per_second"`
+}
+
+// sampleNetRate mide los bytes recibidos y enviados por la interfaz activa
+// entre dos lecturas de sus contadores
+func sampleNetRate(tr *tracer) func(time.Duration) func(*SystemInfo) {
+	iface := activeInterface(tr)
+	if iface == "" {
+		return nil
+	}
+	rx1, tx1, ok := netCounters(tr, iface)
+	if !ok {
+		return nil
+	}
+	return func(elapsed time.Duration) func(*SystemInfo) {
+		rx2, tx2, ok := netCounters(tr, iface)
+		// Un contador que vuelve atrás se reinició (o dio la vuelta)
+		if !ok || rx2 < rx1 || tx2 < tx1 || elapsed <= 0 {
+			return nil
+		}
+		secs := elapsed.Seconds()
+		rate := NetRate{Interface: iface, RX: float64(rx2-rx1) / secs, TX: float64(tx2-tx1) / secs}
+		return func(i *SystemInfo) { i.NetRate = rate }
+	}
+}
+
+// firstInterface es la primera interfaz levantada con IPv4, para los
+// sistemas donde no se lee la tabla de rutas
+func firstInterface() string {
+	for _, ni := range getInterfaces(false) {
+		if ni.IPv4 != "" {
+			return ni.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+//go:build darwin || freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// activeInterface es la primera interfaz levantada con IPv4
+func activeInterface(tr *tracer) string {
+	return firstInterface()
+}
+
+// netCounters parsea "netstat -ibn". Las columnas cambian entre sistemas y
+// Address a veces está vacía, así que Ibytes y Obytes se ubican contando
+// desde el final de la línea según el encabezado. Se usa la fila <Link#N>,
+// que tiene los contadores de toda la interfaz
+func netCounters(tr *tracer, iface string) (rx, tx uint64, ok bool) {
+	lines := strings.Split(tr.runCmd("netstat", "-ibn", "-I", iface), "\n")
+	if len(lines) < 2 {
+		return 0, 0, false
+	}
+	header := strings.Fields(lines[0])
+	rxCol, txCol := -1, -1
+	for n, name := range header {
+		switch name {
+		case "Ibytes":
+			rxCol = len(header) - n
+		case "Obytes":
+			txCol = len(header) - n
+		}
+	}
+	if rxCol < 0 || txCol < 0 {
+		return 0, 0, false
+	}
+	for _, line := range lines[1:] {
+		f := strings.Fields(line)
+		if len(f) < rxCol || len(f) < 3 || f[0] != iface || !strings.HasPrefix(f[2], "<Link") {
+			continue
+		}
+		rx, err1 := strconv.ParseUint(f[len(f)-rxCol], 10, 64)
+		tx, err2 := strconv.ParseUint(f[len(f)-txCol], 10, 64)
+		return rx, tx, err1 == nil && err2 == nil
+	}
+	return 0, 0, false
+}
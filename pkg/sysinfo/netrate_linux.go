@@ -0,0 +1,50 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// activeInterface es la interfaz de la ruta por defecto (destino 00000000
+// en /proc/net/route); sin ruta por defecto, la primera con IPv4
+func activeInterface(tr *tracer) string {
+	data, err := tr.readFile("/proc/net/route")
+	if err == nil {
+		for _, line := range strings.Split(string(data), "\n")[1:] {
+			f := strings.Fields(line)
+			if len(f) >= 2 && f[1] == "00000000" {
+				return f[0]
+			}
+		}
+	}
+	return firstInterface()
+}
+
+// netCounters lee los bytes recibidos y enviados de /proc/net/dev:
+//
+//	eth0: 1234567 8901 0 0 0 0 0 0 7654321 5432 0 0 0 0 0 0
+//
+// (el primer campo es rx_bytes y el noveno tx_bytes). Se lee el archivo
+// cada vez porque se compara con la lectura anterior
+func netCounters(tr *tracer, iface string) (rx, tx uint64, ok bool) {
+	data, err := tr.readFile("/proc/net/dev")
+	if err != nil {
+		return 0, 0, false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		name, counters, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(name) != iface {
+			continue
+		}
+		f := strings.Fields(counters)
+		if len(f) < 9 {
+			return 0, 0, false
+		}
+		rx, err1 := strconv.ParseUint(f[0], 10, 64)
+		tx, err2 := strconv.ParseUint(f[8], 10, 64)
+		return rx, tx, err1 == nil && err2 == nil
+	}
+	return 0, 0, false
+}
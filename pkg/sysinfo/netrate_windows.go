@@ -0,0 +1,56 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"net"
+	"unsafe"
+)
+
+// mibIfRow es MIB_IFROW; de los contadores solo se usan los bytes
+type mibIfRow struct {
+	Name            [256]uint16
+	Index           uint32
+	Type            uint32
+	MTU             uint32
+	Speed           uint32
+	PhysAddrLen     uint32
+	PhysAddr        [8]byte
+	AdminStatus     uint32
+	OperStatus      uint32
+	LastChange      uint32
+	InOctets        uint32
+	InUcastPkts     uint32
+	InNUcastPkts    uint32
+	InDiscards      uint32
+	InErrors        uint32
+	InUnknownProtos uint32
+	OutOctets       uint32
+	OutUcastPkts    uint32
+	OutNUcastPkts   uint32
+	OutDiscards     uint32
+	OutErrors       uint32
+	OutQLen         uint32
+	DescrLen        uint32
+	Descr           [256]byte
+}
+
+// activeInterface es la primera interfaz levantada con IPv4
+func activeInterface(tr *tracer) string {
+	return firstInterface()
+}
+
+// netCounters lee los bytes de la interfaz con GetIfEntry. Los contadores
+// de MIB_IFROW son de 32 bits: si dan la vuelta durante el intervalo la
+// segunda lectura es menor y sampleNetRate la descarta
+func netCounters(tr *tracer, iface string) (rx, tx uint64, ok bool) {
+	ni, err := net.InterfaceByName(iface)
+	if err != nil {
+		return 0, 0, false
+	}
+	row := mibIfRow{Index: uint32(ni.Index)}
+	if r, _, _ := procGetIfEntry.Call(uintptr(unsafe.Pointer(&row))); r != 0 {
+		return 0, 0, false
+	}
+	return uint64(row.InOctets), uint64(row.OutOctets), true
+}
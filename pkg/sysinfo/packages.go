@@ -0,0 +1,211 @@
+package sysinfo
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PackageCount guarda cuántos paquetes tiene instalados un gestor
+type PackageCount struct {
+	Manager string `json:"manager"`
+	Count   int    `json:"count"`
+}
+
+// packageManager sabe contar los paquetes de un gestor. count devuelve 0
+// si el gestor no está instalado
+type packageManager struct {
+	name  string
+	count func(tr *tracer) int
+}
+
+// packageManagers son los gestores soportados. Siempre que se puede se lee
+// la base de datos directamente en vez de ejecutar el gestor, que es lento
+var packageManagers = []packageManager{
+	{"dpkg", countDpkg},
+	{"rpm", countRpm},
+	{"pacman", func(*tracer) int { return countDirs(termuxPrefix() + "/var/lib/pacman/local/*") }},
+	{"apk", countApk},
+	{"xbps", countXbps},
+	{"portage", func(*tracer) int { return countDirs("/var/db/pkg/*/*") }},
+	{"nix", countNix},
+	{"brew", countBrew},
+	{"flatpak", countFlatpak},
+	{"snap", countSnap},
+	{"appimage", countAppImages},
+}
+
+// getPackages cuenta los paquetes de todos los gestores presentes
+func getPackages(tr *tracer) []PackageCount {
+	var out []PackageCount
+	for _, pm := range packageManagers {
+		if n := pm.count(tr); n > 0 {
+			out = append(out, PackageCount{Manager: pm.name, Count: n})
+		}
+	}
+	return out
+}
+
+// countDirs cuenta los directorios que coinciden con el patrón
+func countDirs(pattern string) int {
+	matches, _ := filepath.Glob(pattern)
+	n := 0
+	for _, m := range matches {
+		if fi, err := os.Stat(m); err == nil && fi.IsDir() {
+			n++
+		}
+	}
+	return n
+}
+
+// countLines cuenta las líneas de un archivo que empiezan con prefix
+func countLines(tr *tracer, path, prefix string) int {
+	data, err := tr.readFile(path)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if bytes.HasPrefix(line, []byte(prefix)) {
+			n++
+		}
+	}
+	return n
+}
+
+// countDpkg cuenta los paquetes instalados en /var/lib/dpkg/status (dentro
+// del prefijo en Termux)
+func countDpkg(tr *tracer) int {
+	return countLines(tr, termuxPrefix()+"/var/lib/dpkg/status", "Status: install ok installed")
+}
+
+// countApk cuenta las entradas "P:" de la base de datos de Alpine
+func countApk(tr *tracer) int {
+	return countLines(tr, "/lib/apk/db/installed", "P:")
+}
+
+// countXbps cuenta los paquetes del pkgdb de Void
+func countXbps(tr *tracer) int {
+	dbs, _ := filepath.Glob("/var/db/xbps/pkgdb-*.plist")
+	if len(dbs) == 0 {
+		return 0
+	}
+	data, err := tr.readFile(dbs[0])
+	if err != nil {
+		return 0
+	}
+	return bytes.Count(data, []byte("<key>pkgver</key>"))
+}
+
+// countRpm usa rpm porque su base de datos es sqlite/berkeley db
+func countRpm(tr *tracer) int {
+	if _, err := os.Stat("/var/lib/rpm"); err != nil {
+		if _, err := os.Stat("/usr/lib/sysimage/rpm"); err != nil {
+			return 0
+		}
+	}
+	return countCmdLines(tr, "rpm", "-qa")
+}
+
+// countNix cuenta las dependencias del perfil del sistema y del usuario
+func countNix(tr *tracer) int {
+	if _, err := os.Stat("/nix/store"); err != nil {
+		return 0
+	}
+	profiles := []string{"/run/current-system/sw"}
+	if home, err := os.UserHomeDir(); err == nil {
+		profiles = append(profiles, filepath.Join(home, ".nix-profile"))
+	}
+
+	n := 0
+	for _, profile := range profiles {
+		if _, err := os.Stat(profile); err == nil {
+			n += countCmdLines(tr, "nix-store", "-q", "--requisites", profile)
+		}
+	}
+	return n
+}
+
+// countBrew cuenta fórmulas y casks en los prefijos conocidos de Homebrew
+func countBrew(tr *tracer) int {
+	for _, prefix := range []string{"/opt/homebrew", "/usr/local", "/home/linuxbrew/.linuxbrew"} {
+		if _, err := os.Stat(filepath.Join(prefix, "Cellar")); err != nil {
+			continue
+		}
+		return countDirs(filepath.Join(prefix, "Cellar", "*")) + countDirs(filepath.Join(prefix, "Caskroom", "*"))
+	}
+	return 0
+}
+
+// countFlatpak cuenta las apps y runtimes instalados en el sistema y en el
+// usuario. Cada runtime es nombre/arquitectura/rama; en las apps se cuenta
+// una por nombre porque app/<nombre>/current es un enlace a la rama activa
+func countFlatpak(tr *tracer) int {
+	roots := []string{"/var/lib/flatpak"}
+	if home, err := os.UserHomeDir(); err == nil {
+		roots = append(roots, filepath.Join(home, ".local", "share", "flatpak"))
+	}
+	n := 0
+	for _, root := range roots {
+		n += countDirs(filepath.Join(root, "app", "*")) + countDirs(filepath.Join(root, "runtime", "*", "*", "*"))
+	}
+	return n
+}
+
+// countSnap cuenta los snaps de /snap, un directorio por snap con sus
+// revisiones adentro. /snap/bin son los comandos, no un snap
+func countSnap(tr *tracer) int {
+	if _, err := os.Stat("/snap/bin"); err != nil {
+		return 0
+	}
+	return countDirs("/snap/*") - 1
+}
+
+// appImageDirs son donde se suelen guardar las AppImage (AppImageLauncher
+// usa ~/Applications); no se busca en todo el disco porque sería lento
+var appImageDirs = []string{"Applications", "AppImages", ".local/bin", "bin"}
+
+// countAppImages cuenta los archivos .AppImage de appImageDirs en el home y
+// de /opt. No tienen una base de datos: cada una es un ejecutable suelto
+func countAppImages(tr *tracer) int {
+	var dirs []string
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, d := range appImageDirs {
+			dirs = append(dirs, filepath.Join(home, d))
+		}
+	}
+	dirs = append(dirs, "/opt")
+
+	n := 0
+	for _, dir := range dirs {
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if !e.IsDir() && strings.EqualFold(filepath.Ext(e.Name()), ".appimage") {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// countCmdLines ejecuta un comando y cuenta las líneas no vacías de su salida
+func countCmdLines(tr *tracer, name string, args ...string) int {
+	if _, err := exec.LookPath(name); err != nil {
+		return 0
+	}
+	start := time.Now()
+	out, err := exec.Command(name, args...).Output()
+	if tr.cmd(name, args, start, err) != nil {
+		return 0
+	}
+	n := 0
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,108 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// En ZFS y btrfs el statfs de cada montaje engaña: en ZFS cada dataset ve
+// como total su propio uso más el espacio libre del pool, y en btrfs con
+// varios discos el libre no tiene en cuenta el perfil (RAID1 guarda todo
+// dos veces). Para esos montajes se muestra el uso del pool entero
+
+// poolUsages busca el uso de los pools a medida que aparecen montajes que
+// los usan, así "zfs list" corre una sola vez y solo si hay ZFS
+type poolUsages struct {
+	zfs map[string]Usage // por nombre de pool; nil si todavía no se leyó
+}
+
+// usage devuelve el nombre del pool (o del sistema de archivos btrfs) del
+// montaje y su uso. ok es false si no es ZFS ni btrfs o no se pudo leer
+func (p *poolUsages) usage(tr *tracer, e mountEntry) (name string, u Usage, ok bool) {
+	switch e.fstype {
+	case "zfs":
+		if p.zfs == nil {
+			p.zfs = zfsPools(tr)
+		}
+		// El dispositivo es el dataset, ej. "rpool/ROOT/ubuntu"
+		name, _, _ = strings.Cut(e.device, "/")
+		u, ok = p.zfs[name]
+		return name, u, ok
+	case "btrfs":
+		return btrfsUsage(tr, e.device)
+	}
+	return "", Usage{}, false
+}
+
+// zfsPools lee el espacio de cada pool del dataset raíz. Se usa "zfs list"
+// y no "zpool list" porque este último cuenta la paridad de raidz como
+// espacio: used + avail del dataset raíz es lo que de verdad entra
+func zfsPools(tr *tracer) map[string]Usage {
+	pools := map[string]Usage{}
+	out := tr.runCmd("zfs", "list", "-Hp", "-d", "0", "-o", "name,used,avail")
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Split(line, "\t")
+		if len(f) != 3 {
+			continue
+		}
+		used, err1 := strconv.ParseUint(f[1], 10, 64)
+		avail, err2 := strconv.ParseUint(f[2], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		pools[f[0]] = Usage{Total: used + avail, Used: used}
+	}
+	return pools
+}
+
+// btrfsUsage calcula el uso de un btrfs desde /sys/fs/btrfs/<uuid>, que se
+// puede leer sin root (a diferencia de "btrfs filesystem usage"). El
+// espacio crudo de los discos se divide por las copias del perfil de datos
+func btrfsUsage(tr *tracer, device string) (string, Usage, bool) {
+	fs := btrfsSysfs(device)
+	if fs == "" {
+		return "", Usage{}, false
+	}
+
+	var rawTotal, rawUsed uint64
+	devices, _ := os.ReadDir(filepath.Join(fs, "devices"))
+	for _, d := range devices {
+		rawTotal += tr.readUint(filepath.Join("/sys/class/block", d.Name(), "size")) * 512
+	}
+	for _, kind := range []string{"data", "metadata", "system"} {
+		rawUsed += tr.readUint(filepath.Join(fs, "allocation", kind, "disk_used"))
+	}
+	if rawTotal == 0 {
+		return "", Usage{}, false
+	}
+
+	// Copias de cada dato: 1 en single, 2 en RAID1/DUP, 3 en RAID1C3...
+	copies := 1.0
+	if logical := tr.readUint(filepath.Join(fs, "allocation", "data", "total_bytes")); logical > 0 {
+		copies = float64(tr.readUint(filepath.Join(fs, "allocation", "data", "disk_total"))) / float64(logical)
+	}
+	if copies < 1 {
+		copies = 1
+	}
+
+	name := tr.readTrim(filepath.Join(fs, "label"))
+	if name == "" {
+		name = filepath.Base(fs)
+	}
+	return name, Usage{Total: uint64(float64(rawTotal) / copies), Used: uint64(float64(rawUsed) / copies)}, true
+}
+
+// btrfsSysfs busca el directorio /sys/fs/btrfs/<uuid> del sistema de
+// archivos que incluye device (puede ser un enlace, ej. /dev/mapper/root)
+func btrfsSysfs(device string) string {
+	if real, err := filepath.EvalSymlinks(device); err == nil {
+		device = real
+	}
+	matches, _ := filepath.Glob(filepath.Join("/sys/fs/btrfs/*/devices", filepath.Base(device)))
+	if len(matches) == 0 {
+		return ""
+	}
+	return filepath.Dir(filepath.Dir(matches[0]))
+}
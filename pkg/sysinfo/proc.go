@@ -0,0 +1,86 @@
+package sysinfo
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// procCache guarda el contenido de los archivos de /proc leídos durante una
+// ejecución, así cada archivo se abre una sola vez y todos los parsers ven
+// los mismos datos
+type procCache struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// newProcCache crea una caché vacía
+func newProcCache() *procCache {
+	return &procCache{files: make(map[string][]byte)}
+}
+
+// Read devuelve el contenido del archivo, leyéndolo solo la primera vez.
+// Si no se puede leer devuelve nil (y también lo recuerda)
+func (p *procCache) Read(tr *tracer, path string) []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if data, ok := p.files[path]; ok {
+		return data
+	}
+	data, err := tr.readFile(path)
+	if err != nil {
+		data = nil
+	}
+	p.files[path] = data
+	return data
+}
+
+// processNames devuelve el nombre (comm) de todos los procesos visibles en /proc
+func processNames(tr *tracer) []string {
+	dirs, _ := filepath.Glob("/proc/[0-9]*")
+	names := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if comm := tr.readTrim(filepath.Join(dir, "comm")); comm != "" {
+			names = append(names, comm)
+		}
+	}
+	return names
+}
+
+// readUint lee un número entero de un archivo de /sys, 0 si no se puede
+func (t *tracer) readUint(path string) uint64 {
+	s := t.readTrim(path)
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		if s != "" {
+			t.parse(path, s, err)
+		}
+		return 0
+	}
+	return n
+}
+
+// readTrim lee un archivo pequeño (típicamente de /sys) sin espacios alrededor
+func (t *tracer) readTrim(path string) string {
+	data, err := t.readFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// ueventValue busca una clave CLAVE=valor en un archivo uevent de /sys
+func ueventValue(tr *tracer, path, key string) string {
+	data, err := tr.readFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if val, ok := strings.CutPrefix(line, key+"="); ok {
+			return val
+		}
+	}
+	return ""
+}
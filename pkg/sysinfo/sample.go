@@ -0,0 +1,69 @@
+package sysinfo
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultSampleInterval es cuánto se espera entre las dos lecturas de los
+// datos que se calculan como una diferencia (uso de CPU, tráfico de red)
+const DefaultSampleInterval = 200 * time.Millisecond
+
+// sampler toma la primera lectura de un contador y devuelve la función que
+// toma la segunda y arma el resultado con el tiempo que pasó entre las dos.
+// Devuelve nil si el contador no se puede leer en este sistema
+type sampler func(tr *tracer) func(elapsed time.Duration) func(*SystemInfo)
+
+// sampleTask junta todos los samplers en una sola ventana: primero la
+// primera lectura de todos, después una única espera y al final la segunda
+// lectura de todos. Así pedir más datos no suma más esperas
+func sampleTask(opts Options, samplers []sampler) task {
+	return task{timeout: opts.SampleInterval + opts.Timeout, run: func(ctx context.Context, tr *tracer) (func(*SystemInfo), bool) {
+		var seconds []func(time.Duration) func(*SystemInfo)
+		for _, s := range samplers {
+			if second := s(tr); second != nil {
+				seconds = append(seconds, second)
+			}
+		}
+		if len(seconds) == 0 {
+			return nil, false
+		}
+
+		start := time.Now()
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(opts.SampleInterval):
+		}
+		elapsed := time.Since(start)
+
+		var applies []func(*SystemInfo)
+		for _, second := range seconds {
+			if apply := second(elapsed); apply != nil {
+				applies = append(applies, apply)
+			}
+		}
+		return func(i *SystemInfo) {
+			for _, apply := range applies {
+				apply(i)
+			}
+		}, len(applies) > 0
+	}}
+}
+
+// sampleCPUUsage mide el porcentaje de CPU ocupada (todas las CPUs juntas)
+// entre dos lecturas de los tiempos acumulados
+func sampleCPUUsage(tr *tracer) func(time.Duration) func(*SystemInfo) {
+	idle1, total1, ok := cpuTimes(tr)
+	if !ok {
+		return nil
+	}
+	return func(time.Duration) func(*SystemInfo) {
+		idle2, total2, ok := cpuTimes(tr)
+		if !ok || total2 <= total1 {
+			return nil
+		}
+		usage := 100 * (1 - float64(idle2-idle1)/float64(total2-total1))
+		return func(i *SystemInfo) { i.CPUUsage = &usage }
+	}
+}
@@ -0,0 +1,9 @@
+package sysinfo
+
+// Security resume las protecciones activas del sistema, para una revisión
+// rápida de un servidor. Lo que no aplica o no se puede leer queda vacío
+type Security struct {
+	MAC        string `json:"mac,omitempty"`         // control de acceso obligatorio, ej. "SELinux (enforcing)", "AppArmor" o "SIP"
+	SecureBoot string `json:"secure_boot,omitempty"` // "enabled" o "disabled"; vacío sin UEFI
+	Lockdown   string `json:"lockdown,omitempty"`    // modo de lockdown del kernel (Linux) o securelevel (BSD)
+}
@@ -0,0 +1,19 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"strconv"
+)
+
+// getSecurity informa el securelevel del kernel, lo más parecido al
+// lockdown de Linux (-1 y 0 no restringen nada)
+func getSecurity(tr *tracer) Security {
+	raw := sysctlRaw("kern.securelevel", 4)
+	if len(raw) < 4 {
+		return Security{}
+	}
+	level := int32(binary.LittleEndian.Uint32(raw[:4]))
+	return Security{Lockdown: "securelevel " + strconv.Itoa(int(level))}
+}
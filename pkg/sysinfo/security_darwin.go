@@ -0,0 +1,15 @@
+//go:build darwin
+
+package sysinfo
+
+import "strings"
+
+// getSecurity informa la System Integrity Protection de macOS. Secure Boot
+// y lockdown no tienen equivalente que se pueda leer sin privilegios
+func getSecurity(tr *tracer) Security {
+	// "System Integrity Protection status: enabled."
+	if strings.Contains(tr.runCmd("csrutil", "status"), "enabled") {
+		return Security{MAC: "SIP"}
+	}
+	return Security{}
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"strings"
+)
+
+// secureBootVar es la variable EFI con el estado de Secure Boot. Los 4
+// primeros bytes son los atributos y el quinto el valor
+const secureBootVar = "/sys/firmware/efi/efivars/SecureBoot-8be4df61-93ca-11d2-aa0d-00e098032b8c"
+
+// getSecurity lee SELinux y AppArmor de sus sistemas de archivos, Secure
+// Boot de efivars y el lockdown de securityfs. Todo se puede leer sin root
+func getSecurity(tr *tracer) Security {
+	var s Security
+	switch enforce := tr.readTrim("/sys/fs/selinux/enforce"); {
+	case enforce == "1":
+		s.MAC = "SELinux (enforcing)"
+	case enforce == "0":
+		s.MAC = "SELinux (permissive)"
+	case tr.readTrim("/sys/module/apparmor/parameters/enabled") == "Y":
+		s.MAC = "AppArmor"
+	}
+
+	if data, err := tr.readFile(secureBootVar); err == nil && len(data) >= 5 {
+		s.SecureBoot = "disabled"
+		if data[4] == 1 {
+			s.SecureBoot = "enabled"
+		}
+	} else if _, err := os.Stat("/sys/firmware/efi"); err == nil {
+		// UEFI sin la variable: el firmware no soporta Secure Boot
+		s.SecureBoot = "disabled"
+	}
+
+	// "[none] integrity confidentiality": el modo activo va entre corchetes
+	lockdown := tr.readTrim("/sys/kernel/security/lockdown")
+	if _, after, ok := strings.Cut(lockdown, "["); ok {
+		s.Lockdown, _, _ = strings.Cut(after, "]")
+	}
+	return s
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package sysinfo
+
+import "syscall"
+
+// secureBootKey es donde Windows guarda el estado de Secure Boot. No
+// existe en equipos que arrancan por BIOS
+const secureBootKey = `SYSTEM\CurrentControlSet\Control\SecureBoot\State`
+
+// getSecurity informa el estado de Secure Boot
+func getSecurity(tr *tracer) Security {
+	key, ok := openKey(secureBootKey)
+	if !ok {
+		return Security{}
+	}
+	syscall.RegCloseKey(key)
+	if regDword(secureBootKey, "UEFISecureBootEnabled") == 1 {
+		return Security{SecureBoot: "enabled"}
+	}
+	return Security{SecureBoot: "disabled"}
+}
@@ -0,0 +1,30 @@
+package sysinfo
+
+import "strings"
+
+// Session es una sesión abierta: una terminal, una conexión ssh o un login
+// gráfico
+type Session struct {
+	User string `json:"user"`
+	TTY  string `json:"tty,omitempty"`  // ej. "pts/0", "tty2" o "seat0"
+	Host string `json:"host,omitempty"` // desde dónde se conectó; vacío si es local
+}
+
+// parseWho interpreta la salida de who(1), una sesión por línea:
+//
+//	alice    pts/0        2024-10-15 09:12 (192.0.2.7)
+func parseWho(out string) []Session {
+	var sessions []Session
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Fields(line)
+		if len(f) < 2 {
+			continue
+		}
+		s := Session{User: f[0], TTY: f[1]}
+		if last := f[len(f)-1]; strings.HasPrefix(last, "(") && strings.HasSuffix(last, ")") {
+			s.Host = strings.Trim(last, "()")
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions
+}
@@ -0,0 +1,12 @@
+//go:build darwin || freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+// getSessions usa who(1): el formato de utmpx cambia entre sistemas
+func getSessions(tr *tracer) []Session {
+	out := tr.runCmd("who")
+	if out == "N/A" {
+		return nil
+	}
+	return parseWho(out)
+}
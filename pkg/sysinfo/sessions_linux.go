@@ -0,0 +1,77 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+)
+
+// Formato de un registro de utmp de glibc (utmp.h), igual en todas las
+// arquitecturas de 64 bits y de 32
+const (
+	utmpSize        = 384
+	utmpUserProcess = 7
+)
+
+// getSessions lee las sesiones de /run/utmp. Algunas distros ya no lo
+// escriben (el reemplazo del problema del 2038 es logind), así que sin
+// utmp se le pregunta a loginctl
+func getSessions(tr *tracer) []Session {
+	data, err := tr.readFile("/run/utmp")
+	if err != nil {
+		data, err = tr.readFile("/var/run/utmp")
+	}
+	if err != nil {
+		return sessionsFromLoginctl(tr)
+	}
+
+	var sessions []Session
+	for ; len(data) >= utmpSize; data = data[utmpSize:] {
+		if binary.NativeEndian.Uint16(data[0:2]) != utmpUserProcess {
+			continue
+		}
+		sessions = append(sessions, Session{
+			User: utmpString(data[44:76]),
+			TTY:  utmpString(data[8:40]),
+			Host: utmpString(data[76:332]),
+		})
+	}
+	return sessions
+}
+
+// utmpString corta un campo de utmp en el primer NUL
+func utmpString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+// sessionsFromLoginctl usa la salida JSON de loginctl (systemd 248 o más).
+// Las sesiones "manager" y "background" son del propio systemd, no logins
+func sessionsFromLoginctl(tr *tracer) []Session {
+	var list []struct {
+		User  string `json:"user"`
+		Seat  string `json:"seat"`
+		TTY   string `json:"tty"`
+		Class string `json:"class"`
+	}
+	if json.Unmarshal([]byte(tr.runCmd("loginctl", "list-sessions", "-o", "json")), &list) != nil {
+		return nil
+	}
+	var sessions []Session
+	for _, l := range list {
+		if strings.HasPrefix(l.Class, "manager") || strings.HasPrefix(l.Class, "background") {
+			continue
+		}
+		s := Session{User: l.User, TTY: l.TTY}
+		if s.TTY == "" {
+			s.TTY = l.Seat
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions
+}
@@ -0,0 +1,73 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Funciones de Remote Desktop Services (wtsapi32.dll), que también
+// conocen la sesión de la consola
+var (
+	wtsapi32 = syscall.NewLazyDLL("wtsapi32.dll")
+
+	procWTSEnumerateSessionsW       = wtsapi32.NewProc("WTSEnumerateSessionsW")
+	procWTSQuerySessionInformationW = wtsapi32.NewProc("WTSQuerySessionInformationW")
+	procWTSFreeMemory               = wtsapi32.NewProc("WTSFreeMemory")
+)
+
+// Valores de WTS_CONNECTSTATE_CLASS y WTS_INFO_CLASS
+const (
+	wtsActive       = 0
+	wtsDisconnected = 4
+	wtsUserName     = 5
+	wtsClientName   = 10
+)
+
+// wtsSessionInfo es WTS_SESSION_INFOW
+type wtsSessionInfo struct {
+	SessionID   uint32
+	StationName *uint16
+	State       uint32
+}
+
+// getSessions lista las sesiones con un usuario (la de la consola y las de
+// escritorio remoto, también las desconectadas que siguen abiertas)
+func getSessions(tr *tracer) []Session {
+	var infos *wtsSessionInfo
+	var count uint32
+	// WTS_CURRENT_SERVER_HANDLE es 0
+	if r, _, _ := procWTSEnumerateSessionsW.Call(0, 0, 1, uintptr(unsafe.Pointer(&infos)), uintptr(unsafe.Pointer(&count))); r == 0 {
+		return nil
+	}
+	defer procWTSFreeMemory.Call(uintptr(unsafe.Pointer(infos)))
+
+	var sessions []Session
+	for _, info := range unsafe.Slice(infos, count) {
+		if info.State != wtsActive && info.State != wtsDisconnected {
+			continue
+		}
+		user := wtsQuery(info.SessionID, wtsUserName)
+		if user == "" {
+			continue
+		}
+		sessions = append(sessions, Session{
+			User: user,
+			TTY:  utf16PtrToString(info.StationName),
+			Host: wtsQuery(info.SessionID, wtsClientName),
+		})
+	}
+	return sessions
+}
+
+// wtsQuery lee un dato de texto de una sesión, "" si no lo tiene
+func wtsQuery(session uint32, class uintptr) string {
+	var buf *uint16
+	var size uint32
+	if r, _, _ := procWTSQuerySessionInformationW.Call(0, uintptr(session), class, uintptr(unsafe.Pointer(&buf)), uintptr(unsafe.Pointer(&size))); r == 0 {
+		return ""
+	}
+	defer procWTSFreeMemory.Call(uintptr(unsafe.Pointer(buf)))
+	return utf16PtrToString(buf)
+}
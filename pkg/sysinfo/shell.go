@@ -0,0 +1,46 @@
+package sysinfo
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// shellVersionArgs son los argumentos con los que cada shell imprime su versión
+var shellVersionArgs = map[string][]string{
+	"bash":   {"--version"},
+	"zsh":    {"--version"},
+	"fish":   {"--version"},
+	"tcsh":   {"--version"},
+	"nu":     {"--version"},
+	"xonsh":  {"--version"},
+	"elvish": {"-version"},
+	"pwsh":   {"--version"},
+}
+
+// getShell devuelve el nombre y la versión del shell de $SHELL, ej. "zsh 5.9".
+// Si no se puede sacar la versión devuelve la ruta tal cual
+func getShell(tr *tracer) string {
+	path := getEnvOrDefault("SHELL", "N/A")
+	if path == "N/A" {
+		return path
+	}
+
+	name := filepath.Base(path)
+	args, ok := shellVersionArgs[name]
+	if !ok {
+		return path
+	}
+	if _, err := exec.LookPath(path); err != nil {
+		return path
+	}
+
+	// Solo se mira la primera línea: "GNU bash, version 5.2.15(1)-release ..."
+	out := tr.runCmd(path, args...)
+	first, _, _ := strings.Cut(out, "\n")
+	version := versionRe.FindString(first)
+	if out == "N/A" || version == "" {
+		return path
+	}
+	return name + " " + version
+}
@@ -0,0 +1,7 @@
+package sysinfo
+
+// Sound es la salida de audio por defecto y el servidor que la maneja
+type Sound struct {
+	Device string `json:"device,omitempty"` // ej. "Built-in Audio Analog Stereo"
+	Server string `json:"server,omitempty"` // ej. "PipeWire 1.0.5", "PulseAudio", "ALSA", "Core Audio"
+}
@@ -0,0 +1,33 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"strings"
+)
+
+// getSound lee el dispositivo por defecto de /dev/sndstat (FreeBSD y
+// DragonFly), ej. "pcm0: <Realtek ALC892 (Analog)> (play/rec) default". Si
+// corre sndiod (el de OpenBSD, también está en los otros) ese es el servidor
+func getSound(tr *tracer) Sound {
+	s := Sound{Server: "OSS"}
+	if tr.runCmd("pgrep", "-x", "sndiod") != "N/A" {
+		s.Server = "sndio"
+	}
+	data, err := tr.readFile("/dev/sndstat")
+	if err != nil {
+		if s.Server == "sndio" {
+			return s
+		}
+		return Sound{}
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "pcm") || !strings.HasSuffix(strings.TrimSpace(line), "default") {
+			continue
+		}
+		if _, name, ok := strings.Cut(line, "<"); ok {
+			s.Device, _, _ = strings.Cut(name, ">")
+		}
+	}
+	return s
+}
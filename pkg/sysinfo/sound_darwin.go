@@ -0,0 +1,29 @@
+//go:build darwin
+
+package sysinfo
+
+import "encoding/json"
+
+// getSound busca en "system_profiler SPAudioDataType" el dispositivo que
+// Core Audio usa como salida por defecto
+func getSound(tr *tracer) Sound {
+	var profile struct {
+		Data []struct {
+			Items []struct {
+				Name          string `json:"_name"`
+				DefaultOutput string `json:"coreaudio_default_audio_output_device"`
+			} `json:"_items"`
+		} `json:"SPAudioDataType"`
+	}
+	if json.Unmarshal([]byte(tr.runCmd("system_profiler", "-json", "SPAudioDataType")), &profile) != nil {
+		return Sound{}
+	}
+	for _, d := range profile.Data {
+		for _, item := range d.Items {
+			if item.DefaultOutput == "spaudio_yes" {
+				return Sound{Device: item.Name, Server: "Core Audio"}
+			}
+		}
+	}
+	return Sound{}
+}
@@ -0,0 +1,114 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// getSound busca la salida por defecto en cascada: pactl (PulseAudio o
+// PipeWire con pipewire-pulse), wpctl (PipeWire sin la capa de PulseAudio)
+// y por último la primera placa de ALSA
+func getSound(tr *tracer) Sound {
+	procs := processNames(tr)
+	if slices.Contains(procs, "pipewire-pulse") || slices.Contains(procs, "pulseaudio") {
+		if s := soundFromPactl(tr); s.Device != "" {
+			return s
+		}
+	}
+	if slices.Contains(procs, "pipewire") {
+		if s := soundFromWpctl(tr); s.Device != "" {
+			return s
+		}
+	}
+	if card := firstALSACard(tr); card != "" {
+		return Sound{Device: card, Server: "ALSA"}
+	}
+	return Sound{}
+}
+
+// soundFromPactl toma el servidor de "pactl info" y la descripción del sink
+// por defecto de "pactl list sinks". En PipeWire "Server Name" es
+// "PulseAudio (on PipeWire 1.0.5)"
+func soundFromPactl(tr *tracer) Sound {
+	var s Sound
+	defaultSink := ""
+	for _, line := range strings.Split(tr.runCmd("pactl", "info"), "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Server Name":
+			if _, pw, ok := strings.Cut(value, "(on "); ok {
+				s.Server = strings.TrimSuffix(pw, ")")
+			} else if value == "pulseaudio" {
+				s.Server = "PulseAudio"
+			}
+		case "Server Version":
+			if !strings.Contains(s.Server, " ") {
+				s.Server += " " + value
+			}
+		case "Default Sink":
+			defaultSink = value
+		}
+	}
+	if defaultSink == "" {
+		return s
+	}
+
+	// "pactl --format=json" existe desde PulseAudio 16 y en pipewire-pulse
+	var sinks []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if json.Unmarshal([]byte(tr.runCmd("pactl", "--format=json", "list", "sinks")), &sinks) == nil {
+		for _, sink := range sinks {
+			if sink.Name == defaultSink {
+				s.Device = sink.Description
+			}
+		}
+		return s
+	}
+	name := ""
+	for _, line := range strings.Split(tr.runCmd("pactl", "list", "sinks"), "\n") {
+		line = strings.TrimSpace(line)
+		if v, ok := strings.CutPrefix(line, "Name: "); ok {
+			name = v
+		} else if v, ok := strings.CutPrefix(line, "Description: "); ok && name == defaultSink {
+			s.Device = v
+		}
+	}
+	return s
+}
+
+// soundFromWpctl lee la descripción del sink por defecto de WirePlumber,
+// la línea ` * node.description = "Built-in Audio Analog Stereo"`
+func soundFromWpctl(tr *tracer) Sound {
+	s := Sound{Server: "PipeWire"}
+	for _, line := range strings.Split(tr.runCmd("wpctl", "inspect", "@DEFAULT_AUDIO_SINK@"), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if v, ok := strings.CutPrefix(line, "node.description = "); ok {
+			s.Device = strings.Trim(v, `"`)
+		}
+	}
+	return s
+}
+
+// firstALSACard devuelve el nombre de la primera placa de /proc/asound/cards,
+// que es la que ALSA usa si no se configuró otra:
+//
+//	0 [PCH            ]: HDA-Intel - HDA Intel PCH
+func firstALSACard(tr *tracer) string {
+	data, err := tr.readFile("/proc/asound/cards")
+	if err != nil {
+		return ""
+	}
+	first, _, _ := strings.Cut(string(data), "\n")
+	if _, name, ok := strings.Cut(first, " - "); ok {
+		return strings.TrimSpace(name)
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+//go:build windows
+
+package sysinfo
+
+// mmDevicesRender es donde Windows guarda los dispositivos de salida de audio
+const mmDevicesRender = `SOFTWARE\Microsoft\Windows\CurrentVersion\MMDevices\Audio\Render`
+
+// Propiedades de cada dispositivo (PKEY_Device_DeviceDesc y
+// PKEY_DeviceInterface_FriendlyName), ej. "Speakers" y "Realtek(R) Audio"
+const (
+	pkeyDeviceDesc    = "{a45c254e-df1c-4efd-8020-67d146a850e0},2"
+	pkeyInterfaceName = "{b3f8fa53-0004-438e-9003-51a46e139bfc},6"
+)
+
+// getSound toma el primer dispositivo de salida activo del registro. Cuál
+// es el predeterminado solo lo sabe la API de COM, pero casi siempre hay uno
+// solo activo
+func getSound(tr *tracer) Sound {
+	for _, id := range regSubkeys(mmDevicesRender) {
+		key := mmDevicesRender + `\` + id
+		// DeviceState 1 es DEVICE_STATE_ACTIVE (conectado y habilitado)
+		if regDword(key, "DeviceState") != 1 {
+			continue
+		}
+		desc := regString(key+`\Properties`, pkeyDeviceDesc)
+		if desc == "" {
+			continue
+		}
+		if iface := regString(key+`\Properties`, pkeyInterfaceName); iface != "" {
+			desc += " (" + iface + ")"
+		}
+		return Sound{Device: desc, Server: "Windows Audio"}
+	}
+	return Sound{}
+}
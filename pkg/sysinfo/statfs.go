@@ -0,0 +1,18 @@
+//go:build linux || darwin || freebsd || dragonfly
+
+package sysinfo
+
+import "syscall"
+
+// getDisk obtiene el espacio total y usado del disco
+func getDisk(tr *tracer, path string) Usage {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return Usage{}
+	}
+
+	// Calcula el espacio total y libre (los tipos de los campos cambian según el SO)
+	totalBytes := uint64(stat.Blocks) * uint64(stat.Bsize)
+	freeBytes := uint64(stat.Bavail) * uint64(stat.Bsize)
+	return Usage{Total: totalBytes, Used: totalBytes - freeBytes}
+}
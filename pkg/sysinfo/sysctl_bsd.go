@@ -0,0 +1,65 @@
+//go:build darwin || freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// sysctlString lee un sysctl de texto, "" si no existe
+func sysctlString(name string) string {
+	s, err := syscall.Sysctl(name)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+// sysctlRaw lee un sysctl binario. syscall.Sysctl quita el último byte si
+// es cero (lo trata como fin de string), así que se completa hasta size
+func sysctlRaw(name string, size int) []byte {
+	s, err := syscall.Sysctl(name)
+	if err != nil {
+		return nil
+	}
+	b := []byte(s)
+	for len(b) < size {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// sysctlUint lee un sysctl numérico de hasta 8 bytes
+func sysctlUint(name string) uint64 {
+	b := sysctlRaw(name, 8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b[:8])
+}
+
+// bootUptime calcula el uptime a partir de kern.boottime (un struct timeval)
+func bootUptime() int64 {
+	b := sysctlRaw("kern.boottime", 8)
+	if b == nil {
+		return 0
+	}
+	boot := int64(binary.LittleEndian.Uint64(b[:8]))
+	if boot <= 0 {
+		return 0
+	}
+	return time.Now().Unix() - boot
+}
+
+// bootID identifica el arranque actual por su hora exacta (kern.boottime)
+func bootID(tr *tracer) string {
+	b := sysctlRaw("kern.boottime", 8)
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatUint(binary.LittleEndian.Uint64(b[:8]), 10)
+}
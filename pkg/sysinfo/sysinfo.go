@@ -0,0 +1,282 @@
+// Package sysinfo recolecta la información del sistema que muestra cafetch:
+// sistema operativo, hardware, uso de recursos, red y escritorio. Se puede
+// usar desde otros programas sin pasar por la línea de comandos:
+//
+//	info, err := sysinfo.Collect(ctx, sysinfo.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(info.OS, info.Memory.Percent())
+package sysinfo
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// SystemInfo guarda toda la información del sistema.
+// Los valores numéricos se guardan crudos (bytes, segundos) y se formatean al imprimir
+type SystemInfo struct {
+	OS       string         `json:"os"`
+	Kernel   string         `json:"kernel"`
+	Arch     string         `json:"arch"`
+	Host     string         `json:"host"`
+	Model    string         `json:"model,omitempty"` // modelo del equipo, ej. "Google Pixel 7"
+	Virt     Virt           `json:"virtualization"`
+	Init     string         `json:"init,omitempty"` // sistema de init, ej. "systemd 252" u "OpenRC"
+	Security Security       `json:"security"`
+	Firewall Firewall       `json:"firewall"`
+	User     string         `json:"user"`
+	Shell    string         `json:"shell"`
+	DE       string         `json:"de,omitempty"`
+	WM       string         `json:"wm,omitempty"`
+	Theme    Theme          `json:"theme"`
+	Media    Media          `json:"media"`
+	Weather  string         `json:"weather,omitempty"` // ej. "Partly cloudy +18°C"
+	Term     string         `json:"term"`
+	CPU      CPUInfo        `json:"cpu"`
+	CPUUsage *float64       `json:"cpu_usage_percent,omitempty"` // nil si no se midió
+	GPUs     []string       `json:"gpus,omitempty"`
+	Displays []Display      `json:"displays,omitempty"`
+	Sound    Sound          `json:"sound"`
+	Packages []PackageCount `json:"packages,omitempty"`
+	Uptime   int64          `json:"uptime_seconds"`
+	Locale   string         `json:"locale,omitempty"`
+	Timezone string         `json:"timezone,omitempty"` // nombre IANA, ej. "Europe/Madrid"
+	TZOffset int            `json:"utc_offset_seconds"`
+	Boot     BootTime       `json:"boot"`
+	IP       string         `json:"ip"`
+	Network  []NetInterface `json:"network,omitempty"`
+	WiFi     []WiFi         `json:"wifi,omitempty"`
+	NetRate  NetRate        `json:"net_rate"`
+	IPv6     string         `json:"ipv6,omitempty"`
+	PublicIP string         `json:"public_ip,omitempty"`
+	Memory   Usage          `json:"memory"`
+	Swap     Usage          `json:"swap"`
+	Disk     Usage          `json:"disk"`
+	Disks    []Mount        `json:"disks,omitempty"`
+	Drives   []Drive        `json:"drives,omitempty"`
+
+	KernelInfo   KernelInfo        `json:"kernel_info"`
+	Board        Board             `json:"board"`
+	Load         Load              `json:"load"`
+	Processes    Processes         `json:"processes"`
+	Sessions     []Session         `json:"sessions,omitempty"`
+	Containers   []ContainerCount  `json:"containers,omitempty"`
+	GPUDrivers   []GPUDriver       `json:"gpu_drivers,omitempty"`
+	Batteries    []Battery         `json:"batteries,omitempty"`
+	Bluetooth    []BluetoothDevice `json:"bluetooth,omitempty"`
+	Temperatures []Temperature     `json:"temperatures,omitempty"`
+}
+
+// Usage guarda el total y lo usado de un recurso en bytes
+type Usage struct {
+	Total uint64 `json:"total_bytes"`
+	Used  uint64 `json:"used_bytes"`
+}
+
+// Percent devuelve el porcentaje usado, 0 si no hay total
+func (u Usage) Percent() float64 {
+	if u.Total == 0 {
+		return 0
+	}
+	return float64(u.Used) / float64(u.Total) * 100
+}
+
+// Valores de Options.Memory: cada herramienta cuenta distinto la memoria
+// usada y así se puede mostrar el mismo número que la que uno conoce
+const (
+	MemoryAvailable = "available" // total menos MemAvailable
+	MemoryFree      = "free"      // como free(1): sin buffers ni caché
+	MemoryHtop      = "htop"      // como htop: sin buffers ni caché, con la memoria compartida
+)
+
+// Options elige qué se recolecta. El valor cero es válido y usa los valores
+// por defecto
+type Options struct {
+	IPv6        bool     // incluye las direcciones IPv6 globales
+	PublicIP    bool     // consulta la IP pública (hace una petición de red)
+	PublicIPURL string   // endpoint HTTPS de la IP pública, "" usa DefaultPublicIPURL
+	Sensors     []string // sensores de temperatura (cpu, gpu, nvme), nil usa DefaultSensors
+	Disks       []string // puntos de montaje, ["auto"] los descubre, nil usa DefaultDisks
+	Hostname    string   // HostnameShort, HostnameFQDN o "" para el nombre tal cual
+	Memory      string   // cómo se cuenta la memoria usada en Linux, "" usa MemoryAvailable
+	Media       bool     // pregunta a los reproductores qué está sonando (por D-Bus)
+	WiFi        bool     // lee la red Wi-Fi conectada (en macOS corre system_profiler)
+	Bluetooth   bool     // lista los dispositivos Bluetooth conectados (corre busctl o system_profiler)
+	Sound       bool     // busca la salida de audio por defecto (corre pactl o wpctl)
+	Sessions    bool     // lista quién tiene sesiones abiertas (lee utmp o corre loginctl)
+	Containers  bool     // cuenta los contenedores de Docker y Podman (por el socket de su API)
+	GPUDrivers  bool     // lee el driver y la VRAM de cada GPU (puede correr nvidia-smi o glxinfo)
+	CPUUsage    bool     // mide el uso de CPU (espera SampleInterval)
+	NetRate     bool     // mide el tráfico de la interfaz activa (espera SampleInterval)
+	Weather     bool     // consulta el clima (hace una petición de red, se guarda en CacheDir)
+	WeatherURL  string   // endpoint estilo wttr.in del clima, "" usa DefaultWeatherURL
+
+	Timeout time.Duration // tiempo máximo de cada colector, 0 usa DefaultTimeout
+
+	// SampleInterval es la espera entre las dos lecturas de los datos que se
+	// miden como diferencia, 0 usa DefaultSampleInterval. Todos comparten
+	// la misma espera
+	SampleInterval time.Duration
+
+	// CacheDir es donde se guardan los datos estáticos (OS, CPU, GPU,
+	// modelo) entre ejecuciones, "" no usa caché
+	CacheDir string
+
+	// Modules, si no es nil, limita la recolección a los colectores de esos
+	// módulos de cafetch (los nombres de Timing.Name), ej. []string{"mem"}
+	// para leer solo la memoria. El resto de SystemInfo queda vacío, salvo
+	// con CacheDir y sin caché guardada: ahí los datos estáticos se leen
+	// todos para guardarlos
+	Modules []string
+
+	// Timings, si no es nil, se llama con lo que tardó cada colector (y con
+	// los que salieron de la caché) en el orden en que terminan
+	Timings func(Timing)
+
+	// Logger, si no es nil, recibe en nivel Debug cada archivo leído,
+	// comando ejecutado, petición y error de parseo, con el colector que lo
+	// hizo (atributo "collector")
+	Logger *slog.Logger
+}
+
+// withDefaults completa las opciones vacías con los valores por defecto
+func (o Options) withDefaults() Options {
+	if o.PublicIPURL == "" {
+		o.PublicIPURL = DefaultPublicIPURL
+	}
+	if o.Sensors == nil {
+		o.Sensors = DefaultSensors
+	}
+	if o.Disks == nil {
+		o.Disks = DefaultDisks
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.WeatherURL == "" {
+		o.WeatherURL = DefaultWeatherURL
+	}
+	if o.Memory == "" {
+		o.Memory = MemoryAvailable
+	}
+	if o.SampleInterval <= 0 {
+		o.SampleInterval = DefaultSampleInterval
+	}
+	return o
+}
+
+// Collect recolecta toda la información del sistema. Los colectores corren
+// en paralelo y cada uno tiene opts.Timeout para terminar: uno que se cuelga
+// (un gestor de paquetes lento, por ejemplo) solo deja su dato vacío. Solo
+// devuelve error si ctx se cancela; lo que no se puede detectar queda vacío
+// o en "N/A"
+func Collect(ctx context.Context, opts Options) (*SystemInfo, error) {
+	opts = opts.withDefaults()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info := SystemInfo{
+		OS:     "N/A",
+		Kernel: "N/A",
+		Arch:   runtime.GOARCH,
+		Host:   "N/A",
+		User:   getUser(),
+		Shell:  "N/A",
+		Term:   "N/A",
+		CPU:    CPUInfo{Model: "N/A"},
+	}
+
+	// Cada archivo de /proc se lee una sola vez por ejecución
+	pc := newProcCache()
+	tasks := staticTasks(opts, pc)
+	key, cached := "", false
+	if opts.CacheDir != "" {
+		tr := newTracer(opts.Logger, "cache")
+		key = cacheKey(tr)
+		cached = key != "" && loadStaticCache(tr, opts.CacheDir, key, &info)
+	}
+	dynamic := dynamicTasks(opts, pc)
+	if opts.Modules != nil {
+		// Si hay que llenar la caché los estáticos corren todos, así la
+		// próxima vez están aunque se pidan otros módulos
+		tasks = onlyTasks(tasks, opts.Modules, key != "" && !cached)
+		dynamic = onlyTasks(dynamic, opts.Modules, false)
+	}
+
+	// Con caché válida solo se recolecta lo que no está guardado
+	if cached {
+		if opts.Timings != nil {
+			for _, t := range cachedTimings(tasks) {
+				opts.Timings(t)
+			}
+		}
+		tasks = uncachedTasks(tasks)
+	}
+
+	runTasks(ctx, &info, opts, append(tasks, dynamic...))
+	if key != "" && !cached {
+		saveStaticCache(opts.CacheDir, key, &info)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Refresh vuelve a leer en info los datos que cambian mientras el sistema
+// está encendido (uptime, carga, memoria, discos, baterías y temperaturas),
+// para redibujar sin volver a recolectar todo
+func Refresh(ctx context.Context, info *SystemInfo, opts Options) error {
+	opts = opts.withDefaults()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tasks := dynamicTasks(opts, newProcCache())
+	if opts.Modules != nil {
+		tasks = onlyTasks(tasks, opts.Modules, false)
+	}
+	runTasks(ctx, info, opts, tasks)
+	return ctx.Err()
+}
+
+// cmdTimeout es lo máximo que puede tardar un comando externo. Los
+// colectores se abandonan antes (Options.Timeout), esto evita que un
+// comando colgado quede corriendo para siempre
+const cmdTimeout = 10 * time.Second
+
+// cmdWaitDelay es cuánto se espera a que se cierre la salida después de que
+// el comando terminó o se cortó. Un hijo que la deje abierta haría que
+// Output no vuelva nunca
+const cmdWaitDelay = 100 * time.Millisecond
+
+// runCmd ejecuta un comando y devuelve su salida
+func (t *tracer) runCmd(name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = cmdWaitDelay
+	start := time.Now()
+	out, err := cmd.Output()
+	if t.cmd(name, args, start, err) != nil {
+		return "N/A"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// getEnvOrDefault obtiene una variable de entorno o devuelve un valor por defecto
+func getEnvOrDefault(key, defaultVal string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultVal
+}
@@ -0,0 +1,133 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// getOS arma el nombre con kern.ostype y kern.osrelease, ej. "OpenBSD 7.4". En FreeBSD se usa
+// freebsd-version, que incluye el nivel de parche del userland
+func getOS(tr *tracer) string {
+	name := sysctlString("kern.ostype")
+	if name == "" {
+		return runtime.GOOS
+	}
+	version := getKernel()
+	if runtime.GOOS == "freebsd" {
+		if v := tr.runCmd("freebsd-version", "-u"); v != "N/A" {
+			version = v
+		}
+	}
+	return name + " " + version
+}
+
+// getUptime usa kern.boottime porque los BSD no tienen /proc/uptime
+func getUptime(tr *tracer, pc *procCache) int64 {
+	return bootUptime()
+}
+
+// getMemory usa los contadores de páginas del kernel y cuenta como usadas
+// las activas y las wired. FreeBSD y DragonFly los exponen por sysctl; en
+// OpenBSD y NetBSD están en un struct uvmexp, así que se leen de "vmstat -s"
+func getMemory(tr *tracer, pc *procCache, mode string) Usage {
+	var total, pageSize, active, wired uint64
+	switch runtime.GOOS {
+	case "freebsd", "dragonfly":
+		total = sysctlUint("hw.physmem")
+		pageSize = sysctlUint("hw.pagesize")
+		active = sysctlUint("vm.stats.vm.v_active_count")
+		wired = sysctlUint("vm.stats.vm.v_wire_count")
+	default:
+		// La tabla de syscall de OpenBSD ya mapea hw.physmem a HW_PHYSMEM64
+		name := "hw.physmem64"
+		if runtime.GOOS == "openbsd" {
+			name = "hw.physmem"
+		}
+		total = sysctlUint(name)
+		stats := vmstatCounters(tr)
+		pageSize, active, wired = stats["bytes per page"], stats["pages active"], stats["pages wired"]
+	}
+	if total == 0 {
+		return Usage{}
+	}
+
+	used := (active + wired) * pageSize
+	if used > total {
+		used = total
+	}
+	return Usage{Total: total, Used: used}
+}
+
+// vmstatLine matchea líneas de "vmstat -s" como "   123456 pages active"
+var vmstatLine = regexp.MustCompile(`^(\d+)\s+(.+)$`)
+
+// vmstatCounters devuelve los contadores de "vmstat -s" por descripción
+func vmstatCounters(tr *tracer) map[string]uint64 {
+	counters := map[string]uint64{}
+	out := tr.runCmd("vmstat", "-s")
+	if out == "N/A" {
+		return counters
+	}
+	for _, line := range strings.Split(out, "\n") {
+		m := vmstatLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		counters[m[2]], _ = strconv.ParseUint(m[1], 10, 64)
+	}
+	return counters
+}
+
+// getSwap usa swapinfo en FreeBSD/DragonFly y swapctl en OpenBSD/NetBSD
+// (los dos en bloques de 1K)
+func getSwap(tr *tracer, pc *procCache) Usage {
+	switch runtime.GOOS {
+	case "freebsd", "dragonfly":
+		return parseSwapinfo(tr.runCmd("swapinfo", "-k"))
+	}
+	return parseSwapctl(tr.runCmd("swapctl", "-sk"))
+}
+
+// parseSwapinfo suma los dispositivos de "swapinfo -k":
+//
+//	Device          1K-blocks     Used    Avail Capacity
+//	/dev/ada0p3       2097152    10240  2086912     0%
+func parseSwapinfo(out string) Usage {
+	var swap Usage
+	if out == "N/A" {
+		return swap
+	}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		// Se saltan el encabezado y la fila "Total" que aparece con varios dispositivos
+		if len(fields) < 3 || fields[0] == "Device" || fields[0] == "Total" {
+			continue
+		}
+		total, err1 := strconv.ParseUint(fields[1], 10, 64)
+		used, err2 := strconv.ParseUint(fields[2], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		swap.Total += total * 1024
+		swap.Used += used * 1024
+	}
+	return swap
+}
+
+// swapctlLine matchea "total: 1048576 1K-blocks allocated, 0 used, 1048576 available"
+var swapctlLine = regexp.MustCompile(`total:\s+(\d+)\s+1K-blocks allocated,\s+(\d+)\s+used`)
+
+// parseSwapctl lee el resumen de "swapctl -sk"
+func parseSwapctl(out string) Usage {
+	m := swapctlLine.FindStringSubmatch(out)
+	if m == nil {
+		return Usage{}
+	}
+	total, _ := strconv.ParseUint(m[1], 10, 64)
+	used, _ := strconv.ParseUint(m[2], 10, 64)
+	return Usage{Total: total * 1024, Used: used * 1024}
+}
@@ -0,0 +1,70 @@
+//go:build darwin
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// getOS arma el nombre con sw_vers, ej. "macOS 14.2.1 (23C71)"
+func getOS(tr *tracer) string {
+	name := tr.runCmd("sw_vers", "-productName")
+	version := tr.runCmd("sw_vers", "-productVersion")
+	if name == "N/A" {
+		return "macOS"
+	}
+	s := name + " " + version
+	if build := tr.runCmd("sw_vers", "-buildVersion"); build != "N/A" {
+		s += " (" + build + ")"
+	}
+	return s
+}
+
+// getUptime usa kern.boottime porque macOS no tiene /proc
+func getUptime(tr *tracer, pc *procCache) int64 {
+	return bootUptime()
+}
+
+// vmStatLine matchea líneas como "Pages active:     123456."
+var vmStatLine = regexp.MustCompile(`^(.+):\s+(\d+)\.?$`)
+
+// getMemory toma el total de hw.memsize y lo usado de vm_stat, sumando las
+// páginas activas, wired y comprimidas (lo mismo que muestra Activity Monitor)
+func getMemory(tr *tracer, pc *procCache, mode string) Usage {
+	total := sysctlUint("hw.memsize")
+	if total == 0 {
+		return Usage{}
+	}
+
+	out := tr.runCmd("vm_stat")
+	pageSize := sysctlUint("hw.pagesize")
+	pages := map[string]uint64{}
+	for _, line := range strings.Split(out, "\n") {
+		m := vmStatLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		pages[m[1]], _ = strconv.ParseUint(m[2], 10, 64)
+	}
+
+	used := (pages["Pages active"] + pages["Pages wired down"] + pages["Pages occupied by compressor"]) * pageSize
+	if used > total {
+		used = total
+	}
+	return Usage{Total: total, Used: used}
+}
+
+// getSwap lee vm.swapusage (struct xsw_usage: total, avail, used...)
+func getSwap(tr *tracer, pc *procCache) Usage {
+	b := sysctlRaw("vm.swapusage", 24)
+	if b == nil {
+		return Usage{}
+	}
+	return Usage{
+		Total: binary.LittleEndian.Uint64(b[0:8]),
+		Used:  binary.LittleEndian.Uint64(b[16:24]),
+	}
+}
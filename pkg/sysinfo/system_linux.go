@@ -0,0 +1,135 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// getOS obtiene el nombre del sistema operativo
+func getOS(tr *tracer) string {
+	// Android no tiene /etc/os-release
+	if isAndroid() {
+		return androidOS(tr)
+	}
+	name := osReleaseName(tr)
+	// Dentro de WSL se aclara sobre qué corre, ej. "Ubuntu 24.04 on Windows (WSL2)"
+	if wsl := wslVersion(tr); wsl != "" {
+		name += " on Windows (" + wsl + ")"
+	}
+	return name
+}
+
+// osReleaseName devuelve el PRETTY_NAME de /etc/os-release
+func osReleaseName(tr *tracer) string {
+	// Intenta leer /etc/os-release primero
+	file, err := tr.openFile("/etc/os-release")
+	if err != nil {
+		return runtime.GOOS
+	}
+	defer file.Close()
+
+	// Busca la línea PRETTY_NAME
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "PRETTY_NAME=") {
+			return strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), `"`)
+		}
+	}
+	return runtime.GOOS
+}
+
+// getUptime obtiene los segundos que lleva encendido el sistema (0 si no se sabe)
+func getUptime(tr *tracer, pc *procCache) int64 {
+	// Parsea los segundos desde /proc/uptime
+	fields := strings.Fields(string(pc.Read(tr, "/proc/uptime")))
+	if len(fields) > 0 {
+		if seconds, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			return int64(seconds)
+		}
+	}
+
+	// Sin /proc montado (chroots, algunos sandboxes) se le pregunta al kernel
+	var si syscall.Sysinfo_t
+	if syscall.Sysinfo(&si) != nil {
+		return 0
+	}
+	return int64(si.Uptime)
+}
+
+// parseMeminfo lee /proc/meminfo y devuelve cada campo en bytes
+func parseMeminfo(tr *tracer, pc *procCache) map[string]uint64 {
+	values := map[string]uint64{}
+	data := pc.Read(tr, "/proc/meminfo")
+	if data == nil {
+		return values
+	}
+
+	// Lee las líneas de /proc/meminfo: "MemTotal:       16318480 kB"
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		// Extrae los valores en kilobytes y los convierte a bytes
+		val, _ := strconv.ParseUint(fields[1], 10, 64)
+		values[strings.TrimSuffix(fields[0], ":")] = val * 1024
+	}
+	return values
+}
+
+// getMemory obtiene la memoria total y usada. Lo usado se cuenta según mode:
+//
+//	MemoryAvailable  MemTotal - MemAvailable (lo que el kernel estima que se puede pedir)
+//	MemoryFree       como free(1): MemTotal - MemFree - Buffers - Cached - SReclaimable
+//	MemoryHtop       como htop y neofetch: lo mismo que free pero la memoria
+//	                 compartida (Shmem, ej. tmpfs) cuenta como usada
+func getMemory(tr *tracer, pc *procCache, mode string) Usage {
+	mem := parseMeminfo(tr, pc)
+	total := mem["MemTotal"]
+	avail, ok := mem["MemAvailable"]
+	// Los kernels anteriores a 3.14 no tienen MemAvailable
+	if !ok && mode == MemoryAvailable {
+		mode = MemoryFree
+	}
+
+	var free uint64
+	switch mode {
+	case MemoryFree:
+		free = mem["MemFree"] + mem["Buffers"] + mem["Cached"] + mem["SReclaimable"]
+	case MemoryHtop:
+		free = mem["MemFree"] + mem["Buffers"] + mem["Cached"] + mem["SReclaimable"]
+		if free > mem["Shmem"] {
+			free -= mem["Shmem"]
+		}
+	default:
+		free = avail
+	}
+	if free > total {
+		return Usage{Total: total}
+	}
+	return Usage{Total: total, Used: total - free}
+}
+
+// getSwap obtiene el swap total y usado (todo en 0 si no hay swap)
+func getSwap(tr *tracer, pc *procCache) Usage {
+	mem := parseMeminfo(tr, pc)
+	total, free := mem["SwapTotal"], mem["SwapFree"]
+	if free > total {
+		return Usage{Total: total}
+	}
+	return Usage{Total: total, Used: total - free}
+}
+
+// bootID identifica el arranque actual; el kernel genera uno nuevo en cada boot
+func bootID(tr *tracer) string {
+	return tr.readTrim("/proc/sys/kernel/random/boot_id")
+}
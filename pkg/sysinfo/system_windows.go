@@ -0,0 +1,119 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// currentVersionKey guarda la versión de Windows en el registro
+const currentVersionKey = `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
+
+// getOS arma el nombre desde el registro, ej. "Windows 11 Pro 23H2"
+func getOS(tr *tracer) string {
+	name := regString(currentVersionKey, "ProductName")
+	if name == "" {
+		return "Windows"
+	}
+	// Windows 11 sigue diciendo "Windows 10" en ProductName; se distingue por el build
+	if build, _ := strconv.Atoi(regString(currentVersionKey, "CurrentBuild")); build >= 22000 {
+		name = strings.Replace(name, "Windows 10", "Windows 11", 1)
+	}
+	if v := regString(currentVersionKey, "DisplayVersion"); v != "" {
+		name += " " + v
+	}
+	return name
+}
+
+// getKernel devuelve la versión de NT, ej. "10.0.22631.2861"
+func getKernel() string {
+	major := regDword(currentVersionKey, "CurrentMajorVersionNumber")
+	minor := regDword(currentVersionKey, "CurrentMinorVersionNumber")
+	build := regString(currentVersionKey, "CurrentBuild")
+	if build == "" {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d.%d.%s.%d", major, minor, build, regDword(currentVersionKey, "UBR"))
+}
+
+// getUptime usa GetTickCount64 (milisegundos desde el arranque)
+func getUptime(tr *tracer, pc *procCache) int64 {
+	ms, _, _ := procGetTickCount64.Call()
+	return int64(ms / 1000)
+}
+
+// bootID identifica el arranque actual por su hora, redondeada al minuto
+// porque se calcula a partir del uptime
+func bootID(tr *tracer) string {
+	boot := time.Now().Add(-time.Duration(getUptime(tr, nil)) * time.Second)
+	return strconv.FormatInt(boot.Round(time.Minute).Unix(), 10)
+}
+
+// memoryStatusEx es el struct MEMORYSTATUSEX de la API de Windows
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// globalMemoryStatus llama a GlobalMemoryStatusEx
+func globalMemoryStatus() (memoryStatusEx, bool) {
+	var st memoryStatusEx
+	st.Length = uint32(unsafe.Sizeof(st))
+	ok, _, _ := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&st)))
+	return st, ok != 0
+}
+
+// getMemory obtiene la memoria física total y usada
+func getMemory(tr *tracer, pc *procCache, mode string) Usage {
+	st, ok := globalMemoryStatus()
+	if !ok {
+		return Usage{}
+	}
+	return Usage{Total: st.TotalPhys, Used: st.TotalPhys - st.AvailPhys}
+}
+
+// getSwap estima el archivo de paginación: el "page file" que informa
+// Windows incluye la RAM, así que se le resta
+func getSwap(tr *tracer, pc *procCache) Usage {
+	st, ok := globalMemoryStatus()
+	if !ok || st.TotalPageFile <= st.TotalPhys {
+		return Usage{}
+	}
+	total := st.TotalPageFile - st.TotalPhys
+	free := st.AvailPageFile - st.AvailPhys
+	if st.AvailPageFile < st.AvailPhys || free > total {
+		return Usage{Total: total}
+	}
+	return Usage{Total: total, Used: total - free}
+}
+
+// getDisk usa GetDiskFreeSpaceExW sobre la unidad de la ruta
+func getDisk(tr *tracer, path string) Usage {
+	var freeAvail, total, totalFree uint64
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return Usage{}
+	}
+	ok, _, _ := procGetDiskFreeSpaceExW.Call(
+		uintptr(unsafe.Pointer(p)),
+		uintptr(unsafe.Pointer(&freeAvail)),
+		uintptr(unsafe.Pointer(&total)),
+		uintptr(unsafe.Pointer(&totalFree)),
+	)
+	if ok == 0 {
+		return Usage{}
+	}
+	return Usage{Total: total, Used: total - freeAvail}
+}
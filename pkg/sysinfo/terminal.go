@@ -0,0 +1,124 @@
+package sysinfo
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// terminalNames relaciona el comm de /proc (cortado a 15 caracteres) con el
+// nombre del emulador de terminal
+var terminalNames = map[string]string{
+	"gnome-terminal-": "GNOME Terminal",
+	"kgx":             "GNOME Console",
+	"ptyxis":          "Ptyxis",
+	"kitty":           "kitty",
+	"alacritty":       "Alacritty",
+	"konsole":         "Konsole",
+	"xfce4-terminal":  "Xfce Terminal",
+	"mate-terminal":   "MATE Terminal",
+	"lxterminal":      "LXTerminal",
+	"qterminal":       "QTerminal",
+	"foot":            "foot",
+	"footclient":      "foot",
+	"wezterm-gui":     "WezTerm",
+	"tilix":           "Tilix",
+	"terminator":      "Terminator",
+	"terminology":     "Terminology",
+	"urxvt":           "urxvt",
+	"rxvt":            "rxvt",
+	"xterm":           "xterm",
+	"st":              "st",
+	"ghostty":         "Ghostty",
+	"contour":         "Contour",
+	"tmux: server":    "tmux",
+	"screen":          "screen",
+	"code":            "VS Code",
+}
+
+// terminalVersionCmds son los emuladores a los que se les puede pedir
+// --version sin que abran una ventana
+var terminalVersionCmds = map[string]string{
+	"kitty":     "kitty",
+	"Alacritty": "alacritty",
+	"foot":      "foot",
+	"WezTerm":   "wezterm",
+	"Ghostty":   "ghostty",
+}
+
+var versionRe = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// getTerminal detecta el emulador de terminal subiendo por los procesos
+// padre (saltando shells, sudo, etc.). Si no lo encuentra (ej. por SSH)
+// devuelve $TERM
+func getTerminal(tr *tracer) string {
+	name := ""
+	for pid := os.Getppid(); pid > 1; {
+		comm, ppid, ok := procStat(tr, pid)
+		if !ok {
+			break
+		}
+		if comm == "sshd" {
+			break
+		}
+		if term, found := terminalNames[comm]; found {
+			name = term
+			break
+		}
+		pid = ppid
+	}
+
+	// Terminales que no se ven como proceso padre (macOS, VS Code) lo avisan por env
+	if name == "" {
+		name = os.Getenv("TERM_PROGRAM")
+	}
+	if name == "" {
+		return getEnvOrDefault("TERM", "N/A")
+	}
+
+	if version := terminalVersion(tr, name); version != "" {
+		return name + " " + version
+	}
+	return name
+}
+
+// terminalVersion busca la versión en TERM_PROGRAM_VERSION o con --version
+func terminalVersion(tr *tracer, name string) string {
+	if v := os.Getenv("TERM_PROGRAM_VERSION"); v != "" && strings.EqualFold(os.Getenv("TERM_PROGRAM"), name) {
+		return v
+	}
+	bin, ok := terminalVersionCmds[name]
+	if !ok {
+		return ""
+	}
+	if _, err := exec.LookPath(bin); err != nil {
+		return ""
+	}
+	return versionRe.FindString(tr.runCmd(bin, "--version"))
+}
+
+// procStat lee el nombre y el padre de un proceso desde /proc/<pid>/stat
+func procStat(tr *tracer, pid int) (comm string, ppid int, ok bool) {
+	data, err := tr.readFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return "", 0, false
+	}
+
+	// Formato: "pid (comm) estado ppid ...". El comm puede tener espacios y paréntesis
+	s := string(data)
+	open, close := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if open < 0 || close < open {
+		return "", 0, false
+	}
+	fields := strings.Fields(s[close+1:])
+	if len(fields) < 2 {
+		return "", 0, false
+	}
+	ppid, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return s[open+1 : close], ppid, true
+}
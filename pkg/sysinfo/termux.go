@@ -0,0 +1,67 @@
+package sysinfo
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// termuxPrefix devuelve el prefijo de Termux (donde vive su /usr), o "" si
+// no se corre dentro de Termux
+func termuxPrefix() string {
+	prefix := os.Getenv("PREFIX")
+	if os.Getenv("TERMUX_VERSION") != "" || strings.HasPrefix(prefix, "/data/data/com.termux/") {
+		return prefix
+	}
+	return ""
+}
+
+// isAndroid indica si se corre sobre Android (Termux u otra app con shell)
+func isAndroid() bool {
+	_, err := os.Stat("/system/build.prop")
+	return err == nil || termuxPrefix() != ""
+}
+
+// getprop lee una propiedad del sistema de Android, "" si no existe
+func getprop(tr *tracer, name string) string {
+	if _, err := exec.LookPath("getprop"); err != nil {
+		return ""
+	}
+	val := tr.runCmd("getprop", name)
+	if val == "N/A" {
+		return ""
+	}
+	return val
+}
+
+// androidOS arma el nombre del sistema, ej. "Android 14 (Termux 0.118.0)"
+func androidOS(tr *tracer) string {
+	name := "Android"
+	if version := getprop(tr, "ro.build.version.release"); version != "" {
+		name += " " + version
+	}
+	if termux := os.Getenv("TERMUX_VERSION"); termux != "" {
+		name += " (Termux " + termux + ")"
+	}
+	return name
+}
+
+// androidModel devuelve fabricante y modelo del dispositivo, ej. "Google Pixel 7"
+func androidModel(tr *tracer) string {
+	brand := getprop(tr, "ro.product.manufacturer")
+	model := getprop(tr, "ro.product.model")
+	// Muchos fabricantes ya incluyen la marca en el modelo
+	if brand == "" || strings.HasPrefix(strings.ToLower(model), strings.ToLower(brand)) {
+		return model
+	}
+	return strings.TrimSpace(brand + " " + model)
+}
+
+// androidSoC devuelve el SoC que informa Android 12+, ej. "Qualcomm SM8550"
+func androidSoC(tr *tracer) string {
+	model := getprop(tr, "ro.soc.model")
+	if model == "" {
+		return ""
+	}
+	return strings.TrimSpace(getprop(tr, "ro.soc.manufacturer") + " " + model)
+}
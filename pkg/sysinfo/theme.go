@@ -0,0 +1,132 @@
+package sysinfo
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Theme guarda la apariencia del escritorio
+type Theme struct {
+	GTK    string `json:"gtk,omitempty"`
+	Qt     string `json:"qt,omitempty"`
+	Icons  string `json:"icons,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+	Font   string `json:"font,omitempty"`
+}
+
+// getTheme detecta tema GTK/Qt, iconos, cursor y fuente. Para GTK prueba
+// gsettings y después settings.ini; para Qt lee kdeglobals y qt5ct/qt6ct
+func getTheme(tr *tracer) Theme {
+	var t Theme
+	home, _ := os.UserHomeDir()
+	configDir := filepath.Join(home, ".config")
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		configDir = dir
+	}
+
+	// gsettings solo tiene sentido dentro de una sesión gráfica
+	if sessionType() != "" {
+		t.GTK = gsetting(tr, "gtk-theme")
+		t.Icons = gsetting(tr, "icon-theme")
+		t.Cursor = gsetting(tr, "cursor-theme")
+		t.Font = gsetting(tr, "font-name")
+	}
+
+	// settings.ini de GTK 3 y 4
+	for _, ver := range []string{"gtk-3.0", "gtk-4.0"} {
+		ini := readINI(tr, filepath.Join(configDir, ver, "settings.ini"))
+		s := ini["Settings"]
+		fillEmpty(&t.GTK, s["gtk-theme-name"])
+		fillEmpty(&t.Icons, s["gtk-icon-theme-name"])
+		fillEmpty(&t.Cursor, s["gtk-cursor-theme-name"])
+		fillEmpty(&t.Font, s["gtk-font-name"])
+	}
+
+	// Plasma guarda todo en kdeglobals
+	kde := readINI(tr, filepath.Join(configDir, "kdeglobals"))
+	fillEmpty(&t.Qt, kde["General"]["widgetStyle"])
+	fillEmpty(&t.Icons, kde["Icons"]["Theme"])
+	fillEmpty(&t.Font, qtFont(kde["General"]["font"]))
+
+	// Fuera de Plasma el tema de Qt suele venir de qt5ct/qt6ct
+	for _, ct := range []string{"qt6ct", "qt5ct"} {
+		ini := readINI(tr, filepath.Join(configDir, ct, ct+".conf"))
+		fillEmpty(&t.Qt, ini["Appearance"]["style"])
+		fillEmpty(&t.Icons, ini["Appearance"]["icon_theme"])
+		fillEmpty(&t.Font, qtFont(strings.Trim(ini["Fonts"]["general"], `"`)))
+	}
+
+	// El cursor por defecto de X11 vive en ~/.icons/default/index.theme
+	if t.Cursor == "" {
+		ini := readINI(tr, filepath.Join(home, ".icons", "default", "index.theme"))
+		t.Cursor = ini["Icon Theme"]["Inherits"]
+	}
+	return t
+}
+
+// gsetting lee una clave de org.gnome.desktop.interface
+func gsetting(tr *tracer, key string) string {
+	if _, err := exec.LookPath("gsettings"); err != nil {
+		return ""
+	}
+	args := []string{"get", "org.gnome.desktop.interface", key}
+	start := time.Now()
+	out, err := exec.Command("gsettings", args...).Output()
+	if tr.cmd("gsettings", args, start, err) != nil {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(string(out)), "'")
+}
+
+// qtFont convierte "Noto Sans,10,-1,5,50,0,0,0,0,0" en "Noto Sans 10"
+func qtFont(spec string) string {
+	parts := strings.Split(spec, ",")
+	if len(parts) < 2 {
+		return spec
+	}
+	return parts[0] + " " + parts[1]
+}
+
+// fillEmpty asigna val a dst solo si dst todavía está vacío
+func fillEmpty(dst *string, val string) {
+	if *dst == "" {
+		*dst = val
+	}
+}
+
+// readINI lee un archivo .ini/.conf como sección -> clave -> valor.
+// Si el archivo no existe devuelve un mapa vacío
+func readINI(tr *tracer, path string) map[string]map[string]string {
+	out := map[string]map[string]string{}
+	file, err := tr.openFile(path)
+	if err != nil {
+		return out
+	}
+	defer file.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line[1 : len(line)-1]
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if out[section] == nil {
+			out[section] = map[string]string{}
+		}
+		out[section][strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package sysinfo
+
+import (
+	"errors"
+	"io/fs"
+	"os/exec"
+	"time"
+)
+
+// Estados de un colector en Timing
+const (
+	TimingOK      = "ok"      // terminó a tiempo
+	TimingTimeout = "timeout" // se pasó de su timeout y se abandonó
+	TimingNoData  = "no data" // terminó pero no se pudo leer en este sistema
+	TimingCached  = "cached"  // no corrió: el dato salió de la caché
+)
+
+// Timing es lo que tardó un colector, para encontrar los lentos. Name es el
+// módulo de cafetch que usa su dato (varios separados por comas si lo
+// comparten, ej. "uptime,load,procs")
+type Timing struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Status   string        `json:"status"`
+
+	// Errors son las fuentes que fallaron (*SourceError). Que falle una no
+	// siempre deja el dato vacío: muchos colectores prueban varias rutas
+	// hasta encontrar una
+	Errors []error `json:"-"`
+
+	// Err es por qué el colector no tiene su dato: nil si lo tiene o si
+	// simplemente no existe en este sistema (no hay batería, no está el
+	// comando), context.DeadlineExceeded con TimingTimeout, o las fuentes
+	// de Errors que fallaron de verdad (permisos, un comando que salió con
+	// error, un valor que no se pudo parsear) unidas con errors.Join
+	Err error `json:"-"`
+}
+
+// failure devuelve las fuentes de errs que fallaron por algo distinto de no
+// existir, unidas con errors.Join (nil si no hay)
+func failure(errs []error) error {
+	var failed []error
+	for _, err := range errs {
+		if !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, exec.ErrNotFound) {
+			failed = append(failed, err)
+		}
+	}
+	return errors.Join(failed...)
+}
+
+// cachedTimings devuelve un Timing TimingCached por cada tarea estática
+func cachedTimings(tasks []task) []Timing {
+	var out []Timing
+	for _, t := range tasks {
+		if t.static {
+			out = append(out, Timing{Name: t.name, Status: TimingCached})
+		}
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package sysinfo
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/user"
+	"strings"
+)
+
+// Valores de Options.Hostname
+const (
+	HostnameShort = "short" // hasta el primer punto, ej. "laptop"
+	HostnameFQDN  = "fqdn"  // el nombre completo según el resolver, ej. "laptop.example.com"
+)
+
+// getUser devuelve el nombre del usuario actual. Las variables de entorno
+// quedan de último recurso porque en cron, systemd o contenedores suelen
+// no estar
+func getUser() string {
+	if u, _ := user.Current(); u != nil && u.Username != "" {
+		// En Windows viene con el dominio, ej. "EQUIPO\usuario"
+		name := u.Username
+		if i := strings.LastIndexByte(name, '\\'); i >= 0 {
+			name = name[i+1:]
+		}
+		return name
+	}
+	for _, key := range []string{"USER", "LOGNAME", "USERNAME"} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return "N/A"
+}
+
+// getHostname devuelve el nombre del equipo en la forma que pide mode
+// (HostnameShort, HostnameFQDN o "" tal cual lo da el sistema). No se usa
+// $HOSTNAME primero porque casi ningún shell la exporta
+func getHostname(ctx context.Context, tr *tracer, mode string) string {
+	name := ""
+	// En WSL el nombre que importa es el del equipo Windows
+	if wslVersion(tr) != "" {
+		name = wslHostname(tr)
+	}
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+	if name == "" {
+		name = tr.readTrim("/etc/hostname")
+	}
+	for _, key := range []string{"HOSTNAME", "COMPUTERNAME"} {
+		if name == "" {
+			name = os.Getenv(key)
+		}
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "N/A"
+	}
+
+	switch mode {
+	case HostnameShort:
+		name, _, _ = strings.Cut(name, ".")
+	case HostnameFQDN:
+		if fqdn := lookupFQDN(ctx, name); fqdn != "" {
+			name = fqdn
+		}
+	}
+	return name
+}
+
+// lookupFQDN busca el nombre completo de name como "hostname -f": el CNAME
+// o el nombre inverso de alguna de sus direcciones. Devuelve "" si el
+// resolver no sabe nada (pasa seguido en equipos sin dominio)
+func lookupFQDN(ctx context.Context, name string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	if cname, err := net.DefaultResolver.LookupCNAME(ctx, name); err == nil {
+		if cname = strings.TrimSuffix(cname, "."); strings.Contains(cname, ".") {
+			return cname
+		}
+	}
+	addrs, err := net.DefaultResolver.LookupHost(ctx, name)
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		names, err := net.DefaultResolver.LookupAddr(ctx, addr)
+		if err != nil {
+			continue
+		}
+		for _, n := range names {
+			if n = strings.TrimSuffix(n, "."); strings.HasPrefix(n, name+".") {
+				return n
+			}
+		}
+	}
+	return ""
+}
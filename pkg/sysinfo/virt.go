@@ -0,0 +1,82 @@
+package sysinfo
+
+import "strings"
+
+// Virt indica si se corre dentro de una máquina virtual o de un contenedor.
+// Los dos pueden estar a la vez (ej. Docker dentro de una VM de KVM)
+type Virt struct {
+	VM        string `json:"vm,omitempty"`        // hipervisor, ej. "KVM"
+	Container string `json:"container,omitempty"` // runtime, ej. "docker"
+}
+
+// virtSignal es una de las pistas que se revisan para detectar una VM o un
+// contenedor. Devuelve el nombre encontrado o "" si la pista no aplica
+type virtSignal func() string
+
+// firstSignal revisa las pistas en orden (de la más confiable a la menos) y
+// devuelve la primera que detecta algo
+func firstSignal(signals ...virtSignal) string {
+	for _, signal := range signals {
+		if name := signal(); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// hypervisorStrings relaciona textos del firmware (DMI/SMBIOS, modelo del
+// equipo) con el hipervisor que los deja. Hyper-V se reconoce por el
+// producto "Virtual Machine", porque "Microsoft" solo también es una Surface
+var hypervisorStrings = []struct{ match, name string }{
+	{"kvm", "KVM"},
+	{"qemu", "QEMU"},
+	{"vmware", "VMware"},
+	{"virtualbox", "VirtualBox"},
+	{"innotek", "VirtualBox"},
+	{"virtual machine", "Hyper-V"},
+	{"parallels", "Parallels"},
+	{"xen", "Xen"},
+	{"bochs", "Bochs"},
+	{"bhyve", "bhyve"},
+	{"virtualmac", "Apple Virtualization"},
+	{"amazon ec2", "Amazon EC2"},
+	{"google compute engine", "Google Compute Engine"},
+}
+
+// hypervisorFromText busca un hipervisor conocido en textos del firmware
+func hypervisorFromText(texts ...string) string {
+	lower := strings.ToLower(strings.Join(texts, " "))
+	for _, h := range hypervisorStrings {
+		if strings.Contains(lower, h.match) {
+			return h.name
+		}
+	}
+	return ""
+}
+
+// vmNames traduce los identificadores cortos que usan systemd-detect-virt y
+// kern.vm_guest a nombres para mostrar. Los que no están se muestran tal cual
+var vmNames = map[string]string{
+	"kvm":       "KVM",
+	"qemu":      "QEMU",
+	"vmware":    "VMware",
+	"oracle":    "VirtualBox",
+	"vbox":      "VirtualBox",
+	"microsoft": "Hyper-V",
+	"hv":        "Hyper-V",
+	"xen":       "Xen",
+	"parallels": "Parallels",
+	"bochs":     "Bochs",
+	"amazon":    "Amazon EC2",
+	"google":    "Google Compute Engine",
+	"apple":     "Apple Virtualization",
+	"nvmm":      "NVMM",
+}
+
+// vmName devuelve el nombre para mostrar de un identificador de hipervisor
+func vmName(id string) string {
+	if name, ok := vmNames[id]; ok {
+		return name
+	}
+	return id
+}
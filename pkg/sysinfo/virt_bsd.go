@@ -0,0 +1,31 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+// getVirt usa kern.vm_guest en FreeBSD y DragonFly; en OpenBSD y NetBSD se
+// deduce del modelo SMBIOS. Un jail de FreeBSD cuenta como contenedor
+func getVirt(tr *tracer, pc *procCache, model string) Virt {
+	return Virt{
+		VM: firstSignal(
+			func() string {
+				id := sysctlString("kern.vm_guest")
+				if id == "" || id == "none" {
+					return ""
+				}
+				if id == "generic" {
+					return "Unknown"
+				}
+				return vmName(id)
+			},
+			func() string { return hypervisorFromText(model) },
+		),
+		Container: firstSignal(
+			func() string {
+				if sysctlUint("security.jail.jailed") == 1 {
+					return "jail"
+				}
+				return ""
+			},
+		),
+	}
+}
@@ -0,0 +1,15 @@
+//go:build darwin
+
+package sysinfo
+
+// getVirt usa kern.hv_vmm_present, que macOS pone en 1 dentro de una VM. El
+// hipervisor se deduce del modelo (VMware7,1, VirtualMac2,1...)
+func getVirt(tr *tracer, pc *procCache, model string) Virt {
+	if sysctlUint("kern.hv_vmm_present") == 0 {
+		return Virt{}
+	}
+	if name := hypervisorFromText(model); name != "" {
+		return Virt{VM: name}
+	}
+	return Virt{VM: "Unknown"}
+}
@@ -0,0 +1,110 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// getVirt detecta el hipervisor y el contenedor. Dentro de WSL no se
+// muestra nada porque la línea OS ya lo dice
+func getVirt(tr *tracer, pc *procCache, model string) Virt {
+	if wslVersion(tr) != "" {
+		return Virt{}
+	}
+	return Virt{
+		VM: firstSignal(
+			func() string { return detectVirt(tr, "--vm") },
+			func() string {
+				const dmi = "/sys/class/dmi/id/"
+				return hypervisorFromText(model, tr.readTrim(dmi+"sys_vendor"), tr.readTrim(dmi+"product_name"), tr.readTrim(dmi+"bios_vendor"))
+			},
+			func() string { return vmName(tr.readTrim("/sys/hypervisor/type")) },
+			func() string {
+				// Las VMs ARM sin DMI lo anuncian en el device tree, ej. "linux,kvm"
+				compat := strings.TrimRight(tr.readTrim("/proc/device-tree/hypervisor/compatible"), "\x00")
+				if _, id, ok := strings.Cut(compat, ","); ok {
+					return vmName(id)
+				}
+				return ""
+			},
+			func() string {
+				// El flag "hypervisor" de cpuid no dice cuál es, solo que hay uno
+				if strings.Contains(string(pc.Read(tr, "/proc/cpuinfo")), " hypervisor") {
+					return "Unknown"
+				}
+				return ""
+			},
+		),
+		Container: firstSignal(
+			func() string { return fileSignal("/.dockerenv", "docker") },
+			func() string { return fileSignal("/run/.containerenv", "podman") },
+			func() string { return tr.readTrim("/run/systemd/container") },
+			func() string { return environValue(tr, "/proc/1/environ", "container") },
+			func() string { return cgroupContainer(tr, pc) },
+			func() string { return detectVirt(tr, "--container") },
+		),
+	}
+}
+
+// detectVirt le pregunta a systemd-detect-virt, que ya junta casi todas las
+// pistas. Si no detecta nada sale con error y runCmd devuelve "N/A"
+func detectVirt(tr *tracer, flag string) string {
+	if _, err := exec.LookPath("systemd-detect-virt"); err != nil {
+		return ""
+	}
+	id := tr.runCmd("systemd-detect-virt", flag)
+	if id == "N/A" || id == "none" || id == "wsl" {
+		return ""
+	}
+	if flag == "--vm" {
+		return vmName(id)
+	}
+	return id
+}
+
+// fileSignal devuelve name si el archivo existe
+func fileSignal(path, name string) string {
+	if _, err := os.Stat(path); err == nil {
+		return name
+	}
+	return ""
+}
+
+// environValue busca una variable en un /proc/<pid>/environ (separado por
+// bytes 0). El de PID 1 solo lo puede leer root
+func environValue(tr *tracer, path, key string) string {
+	data, err := tr.readFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, kv := range strings.Split(string(data), "\x00") {
+		if val, ok := strings.CutPrefix(kv, key+"="); ok {
+			return val
+		}
+	}
+	return ""
+}
+
+// cgroupRuntimes son los runtimes que dejan su nombre en la ruta del cgroup
+var cgroupRuntimes = []struct{ match, name string }{
+	{"kubepods", "kubernetes"},
+	{"libpod", "podman"},
+	{"docker", "docker"},
+	{"containerd", "containerd"},
+	{"lxc", "lxc"},
+}
+
+// cgroupContainer busca el runtime en el cgroup de PID 1. Con cgroups v2 y
+// namespaces la ruta suele ser "/" y esta pista no alcanza
+func cgroupContainer(tr *tracer, pc *procCache) string {
+	data := string(pc.Read(tr, "/proc/1/cgroup"))
+	for _, r := range cgroupRuntimes {
+		if strings.Contains(data, r.match) {
+			return r.name
+		}
+	}
+	return ""
+}
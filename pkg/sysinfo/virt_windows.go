@@ -0,0 +1,9 @@
+//go:build windows
+
+package sysinfo
+
+// getVirt deduce el hipervisor de los datos SMBIOS que Windows copia al
+// registro (los mismos del modelo del equipo)
+func getVirt(tr *tracer, pc *procCache, model string) Virt {
+	return Virt{VM: hypervisorFromText(model, regString(biosKey, "BIOSVendor"))}
+}
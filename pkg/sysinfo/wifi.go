@@ -0,0 +1,51 @@
+package sysinfo
+
+// WiFi es una interfaz inalámbrica conectada a una red
+type WiFi struct {
+	Interface string `json:"interface"` // ej. "wlan0"; en Windows la descripción del adaptador
+	SSID      string `json:"ssid"`
+	Frequency int    `json:"frequency_mhz,omitempty"`
+	Band      string `json:"band,omitempty"`       // "2.4 GHz", "5 GHz", "6 GHz" o "60 GHz"
+	Signal    int    `json:"signal_dbm,omitempty"` // negativo, ej. -52; 0 si no se sabe
+	Quality   int    `json:"quality_percent,omitempty"`
+}
+
+// wifiBand devuelve la banda de una frecuencia en MHz, "" si no la conoce
+func wifiBand(mhz int) string {
+	switch {
+	case mhz >= 2400 && mhz < 2500:
+		return "2.4 GHz"
+	case mhz >= 4900 && mhz < 5925:
+		return "5 GHz"
+	case mhz >= 5925 && mhz < 7200:
+		return "6 GHz"
+	case mhz >= 57000 && mhz < 72000:
+		return "60 GHz"
+	}
+	return ""
+}
+
+// channelBand deduce la banda del número de canal cuando no se conoce la
+// frecuencia. Los canales de 6 GHz repiten números, así que no se distinguen
+func channelBand(channel int) string {
+	switch {
+	case channel >= 1 && channel <= 14:
+		return "2.4 GHz"
+	case channel >= 32 && channel <= 177:
+		return "5 GHz"
+	}
+	return ""
+}
+
+// signalQuality convierte dBm a porcentaje como NetworkManager: -100 dBm
+// o menos es 0% y -50 dBm o más es 100%
+func signalQuality(dbm int) int {
+	q := 2 * (dbm + 100)
+	if q < 0 {
+		return 0
+	}
+	if q > 100 {
+		return 100
+	}
+	return q
+}
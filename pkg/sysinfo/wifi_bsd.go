@@ -0,0 +1,77 @@
+//go:build freebsd || openbsd || netbsd || dragonfly
+
+package sysinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// getWiFi parsea ifconfig. FreeBSD muestra "ssid MiRed channel 36 (5180
+// MHz 11a ht/40+)" y OpenBSD "ieee80211: join MiRed chan 36 bssid ... 72%";
+// un SSID con espacios viene entre comillas
+func getWiFi(tr *tracer) []WiFi {
+	out := tr.runCmd("ifconfig")
+	if out == "N/A" {
+		return nil
+	}
+
+	var wifi []WiFi
+	var cur WiFi
+	active := false
+	flush := func() {
+		if cur.SSID != "" && active {
+			wifi = append(wifi, cur)
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		// Cada interfaz empieza sin sangría: "wlan0: flags=..."
+		if line != "" && line[0] != ' ' && line[0] != '\t' {
+			flush()
+			name, _, _ := strings.Cut(line, ":")
+			cur, active = WiFi{Interface: name}, false
+			continue
+		}
+		line = strings.TrimSpace(line)
+		if status, ok := strings.CutPrefix(line, "status: "); ok {
+			active = status == "associated" || status == "active"
+			continue
+		}
+		line = strings.TrimPrefix(line, "ieee80211: ")
+		key, rest, _ := strings.Cut(line, " ")
+		if key != "ssid" && key != "join" && key != "nwid" {
+			continue
+		}
+		cur.SSID, rest = cutSSID(rest)
+		fields := strings.Fields(rest)
+		for i, f := range fields {
+			switch {
+			case (f == "channel" || f == "chan") && i+1 < len(fields):
+				ch, _ := strconv.Atoi(fields[i+1])
+				cur.Band = channelBand(ch)
+			case strings.HasPrefix(f, "(") && i+1 < len(fields) && fields[i+1] == "MHz":
+				if mhz, err := strconv.Atoi(f[1:]); err == nil {
+					cur.Frequency, cur.Band = mhz, wifiBand(mhz)
+				}
+			case strings.HasSuffix(f, "%"):
+				cur.Quality, _ = strconv.Atoi(strings.TrimSuffix(f, "%"))
+			case strings.HasSuffix(f, "dBm"):
+				if s, err := strconv.Atoi(strings.TrimSuffix(f, "dBm")); err == nil {
+					cur.Signal = s
+				}
+			}
+		}
+	}
+	flush()
+	return wifi
+}
+
+// cutSSID separa el SSID del resto de la línea, con o sin comillas
+func cutSSID(s string) (string, string) {
+	if quoted, ok := strings.CutPrefix(s, `"`); ok {
+		ssid, rest, _ := strings.Cut(quoted, `"`)
+		return ssid, rest
+	}
+	ssid, rest, _ := strings.Cut(s, " ")
+	return ssid, rest
+}
@@ -0,0 +1,51 @@
+//go:build darwin
+
+package sysinfo
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// getWiFi lee la red actual de "system_profiler SPAirPortDataType". Desde
+// macOS 14.4 el SSID sale como "<redacted>" si la terminal no tiene permiso
+// de ubicación; en ese caso igual se muestran la banda y la señal
+func getWiFi(tr *tracer) []WiFi {
+	var profile struct {
+		Data []struct {
+			Interfaces []struct {
+				Name    string `json:"_name"`
+				Current *struct {
+					Name    string `json:"_name"`
+					Channel string `json:"spairport_network_channel"` // "36 (5GHz, 80MHz)"
+					Signal  string `json:"spairport_signal_noise"`    // "-52 dBm / -94 dBm"
+				} `json:"spairport_current_network_information"`
+			} `json:"spairport_airport_interfaces"`
+		} `json:"SPAirPortDataType"`
+	}
+	if json.Unmarshal([]byte(tr.runCmd("system_profiler", "-json", "SPAirPortDataType")), &profile) != nil {
+		return nil
+	}
+
+	var wifi []WiFi
+	for _, d := range profile.Data {
+		for _, iface := range d.Interfaces {
+			cur := iface.Current
+			if cur == nil {
+				continue
+			}
+			w := WiFi{Interface: iface.Name, SSID: cur.Name}
+			if _, band, ok := strings.Cut(cur.Channel, "("); ok {
+				band, _, _ = strings.Cut(band, ",")
+				w.Band = strings.Replace(band, "GHz", " GHz", 1)
+			}
+			dbm, _, _ := strings.Cut(cur.Signal, " dBm")
+			if s, err := strconv.Atoi(dbm); err == nil {
+				w.Signal, w.Quality = s, signalQuality(s)
+			}
+			wifi = append(wifi, w)
+		}
+	}
+	return wifi
+}
@@ -0,0 +1,294 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Constantes de generic netlink y nl80211 (linux/genetlink.h y
+// linux/nl80211.h) que no trae el paquete syscall
+const (
+	genlIDCtrl         = 0x10
+	ctrlCmdGetFamily   = 3
+	ctrlAttrFamilyID   = 1
+	ctrlAttrFamilyName = 2
+
+	nl80211CmdGetInterface = 5
+	nl80211CmdGetStation   = 17
+	nl80211AttrIfindex     = 3
+	nl80211AttrIfname      = 4
+	nl80211AttrWiphyFreq   = 38
+	nl80211AttrStaInfo     = 21
+	nl80211AttrSSID        = 52
+	nl80211StaInfoSignal   = 7
+)
+
+// getWiFi lista las interfaces inalámbricas conectadas. Pregunta al kernel
+// por nl80211 (no necesita root ni comandos) y si falla usa iw o nmcli.
+// Sin placa Wi-Fi (nada en /sys/class/net/*/phy80211) no hace nada
+func getWiFi(tr *tracer) []WiFi {
+	ifaces, _ := filepath.Glob("/sys/class/net/*/phy80211")
+	if len(ifaces) == 0 {
+		return nil
+	}
+	if wifi, err := wifiFromNL80211(); err == nil {
+		return wifi
+	}
+	var wifi []WiFi
+	for _, path := range ifaces {
+		if w, ok := wifiFromIw(tr, filepath.Base(filepath.Dir(path))); ok {
+			wifi = append(wifi, w)
+		}
+	}
+	if len(wifi) == 0 {
+		wifi = wifiFromNmcli(tr)
+	}
+	return wifi
+}
+
+// wifiFromNL80211 pide las interfaces (con su SSID y frecuencia) y, de las
+// conectadas, la señal de la estación a la que están asociadas
+func wifiFromNL80211() ([]WiFi, error) {
+	c, err := dialGenl()
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(c.fd)
+
+	family, err := c.familyID("nl80211")
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := c.request(family, nl80211CmdGetInterface, syscall.NLM_F_DUMP, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var wifi []WiFi
+	for _, msg := range msgs {
+		attrs := parseNetlinkAttrs(msg)
+		ssid := attrs[nl80211AttrSSID]
+		if len(ssid) == 0 || len(attrs[nl80211AttrIfindex]) < 4 {
+			continue
+		}
+		w := WiFi{
+			Interface: strings.TrimRight(string(attrs[nl80211AttrIfname]), "\x00"),
+			SSID:      string(ssid),
+		}
+		if f := attrs[nl80211AttrWiphyFreq]; len(f) >= 4 {
+			w.Frequency = int(binary.NativeEndian.Uint32(f))
+			w.Band = wifiBand(w.Frequency)
+		}
+		w.Signal = c.stationSignal(family, attrs[nl80211AttrIfindex][:4])
+		if w.Signal != 0 {
+			w.Quality = signalQuality(w.Signal)
+		}
+		wifi = append(wifi, w)
+	}
+	return wifi, nil
+}
+
+// stationSignal devuelve la señal en dBm del access point al que está
+// asociada la interfaz, 0 si no se pudo leer
+func (c *genlConn) stationSignal(family uint16, ifindex []byte) int {
+	msgs, err := c.request(family, nl80211CmdGetStation, syscall.NLM_F_DUMP, netlinkAttr(nl80211AttrIfindex, ifindex))
+	if err != nil {
+		return 0
+	}
+	for _, msg := range msgs {
+		info := parseNetlinkAttrs(parseNetlinkAttrs(msg)[nl80211AttrStaInfo])
+		if s := info[nl80211StaInfoSignal]; len(s) >= 1 {
+			return int(int8(s[0]))
+		}
+	}
+	return 0
+}
+
+// genlConn es un socket de generic netlink
+type genlConn struct {
+	fd  int
+	seq uint32
+}
+
+// dialGenl abre el socket. Las respuestas tienen un timeout para no colgar
+// el colector si el kernel no contesta
+func dialGenl() (*genlConn, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_GENERIC)
+	if err != nil {
+		return nil, err
+	}
+	tv := syscall.NsecToTimeval(int64(time.Second))
+	if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	return &genlConn{fd: fd}, nil
+}
+
+// familyID resuelve el número de una familia de generic netlink por nombre
+func (c *genlConn) familyID(name string) (uint16, error) {
+	msgs, err := c.request(genlIDCtrl, ctrlCmdGetFamily, 0, netlinkAttr(ctrlAttrFamilyName, []byte(name+"\x00")))
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range msgs {
+		if id := parseNetlinkAttrs(msg)[ctrlAttrFamilyID]; len(id) >= 2 {
+			return binary.NativeEndian.Uint16(id), nil
+		}
+	}
+	return 0, errors.New("nl80211: familia no encontrada")
+}
+
+// request manda un comando y junta los atributos de cada respuesta (sin
+// los encabezados de netlink y genetlink)
+func (c *genlConn) request(family uint16, cmd uint8, flags uint16, attrs []byte) ([][]byte, error) {
+	c.seq++
+	msg := make([]byte, syscall.NLMSG_HDRLEN+4, syscall.NLMSG_HDRLEN+4+len(attrs))
+	binary.NativeEndian.PutUint16(msg[4:6], family)
+	binary.NativeEndian.PutUint16(msg[6:8], syscall.NLM_F_REQUEST|flags)
+	binary.NativeEndian.PutUint32(msg[8:12], c.seq)
+	msg[syscall.NLMSG_HDRLEN] = cmd
+	msg[syscall.NLMSG_HDRLEN+1] = 1 // versión de genetlink
+	msg = append(msg, attrs...)
+	binary.NativeEndian.PutUint32(msg[0:4], uint32(len(msg)))
+	if err := syscall.Sendto(c.fd, msg, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+
+	var out [][]byte
+	buf := make([]byte, 32*1024)
+	for {
+		n, _, err := syscall.Recvfrom(c.fd, buf, 0)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			if m.Header.Seq != c.seq {
+				continue
+			}
+			switch m.Header.Type {
+			case syscall.NLMSG_DONE:
+				return out, nil
+			case syscall.NLMSG_ERROR:
+				if len(m.Data) >= 4 {
+					if code := int32(binary.NativeEndian.Uint32(m.Data[:4])); code != 0 {
+						return nil, syscall.Errno(-code)
+					}
+				}
+				return out, nil
+			}
+			if len(m.Data) >= 4 {
+				out = append(out, m.Data[4:])
+			}
+			// Sin NLM_F_DUMP la respuesta es un solo mensaje
+			if m.Header.Flags&syscall.NLM_F_MULTI == 0 {
+				return out, nil
+			}
+		}
+	}
+}
+
+// netlinkAttr arma un atributo de netlink: largo, tipo y datos alineados a 4
+func netlinkAttr(typ uint16, data []byte) []byte {
+	b := make([]byte, 4, 4+len(data)+3)
+	binary.NativeEndian.PutUint16(b[0:2], uint16(4+len(data)))
+	binary.NativeEndian.PutUint16(b[2:4], typ)
+	b = append(b, data...)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// parseNetlinkAttrs separa una lista de atributos por tipo. Sirve también
+// para los atributos anidados, que son otra lista dentro de los datos
+func parseNetlinkAttrs(b []byte) map[uint16][]byte {
+	attrs := map[uint16][]byte{}
+	for len(b) >= 4 {
+		n := int(binary.NativeEndian.Uint16(b[0:2]))
+		if n < 4 || n > len(b) {
+			break
+		}
+		// Los dos bits altos del tipo marcan anidado y orden de red
+		attrs[binary.NativeEndian.Uint16(b[2:4])&0x3fff] = b[4:n]
+		if n = (n + 3) &^ 3; n > len(b) {
+			break
+		}
+		b = b[n:]
+	}
+	return attrs
+}
+
+// wifiFromIw parsea "iw dev <iface> link":
+//
+//	Connected to aa:bb:cc:dd:ee:ff (on wlan0)
+//		SSID: MiRed
+//		freq: 5180
+//		signal: -52 dBm
+func wifiFromIw(tr *tracer, iface string) (WiFi, bool) {
+	out := tr.runCmd("iw", "dev", iface, "link")
+	if !strings.HasPrefix(out, "Connected") {
+		return WiFi{}, false
+	}
+	w := WiFi{Interface: iface}
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "SSID":
+			w.SSID = value
+		case "freq":
+			// Las versiones nuevas de iw muestran decimales, ej. "5180.0"
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				w.Frequency = int(f)
+				w.Band = wifiBand(w.Frequency)
+			}
+		case "signal":
+			if s, err := strconv.Atoi(strings.TrimSuffix(value, " dBm")); err == nil {
+				w.Signal, w.Quality = s, signalQuality(s)
+			}
+		}
+	}
+	return w, w.SSID != ""
+}
+
+// wifiFromNmcli usa NetworkManager, que da la señal solo en porcentaje. En
+// el modo -t los ":" del SSID vienen como "\:", por eso el SSID va último
+func wifiFromNmcli(tr *tracer) []WiFi {
+	out := tr.runCmd("nmcli", "-t", "-f", "IN-USE,DEVICE,FREQ,SIGNAL,SSID", "device", "wifi", "list", "--rescan", "no")
+	if out == "N/A" {
+		return nil
+	}
+	var wifi []WiFi
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, ":", 5)
+		if len(f) != 5 || f[0] != "*" {
+			continue
+		}
+		w := WiFi{Interface: f[1], SSID: strings.ReplaceAll(f[4], `\:`, ":")}
+		// "5180 MHz"
+		if mhz, err := strconv.Atoi(strings.TrimSuffix(f[2], " MHz")); err == nil {
+			w.Frequency, w.Band = mhz, wifiBand(mhz)
+		}
+		w.Quality, _ = strconv.Atoi(f[3])
+		wifi = append(wifi, w)
+	}
+	return wifi
+}
@@ -0,0 +1,113 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Funciones de la WLAN API (wlanapi.dll). Se cargan aparte porque en
+// Windows Server sin el servicio de Wi-Fi la DLL no existe
+var (
+	wlanapi = syscall.NewLazyDLL("wlanapi.dll")
+
+	procWlanOpenHandle     = wlanapi.NewProc("WlanOpenHandle")
+	procWlanCloseHandle    = wlanapi.NewProc("WlanCloseHandle")
+	procWlanEnumInterfaces = wlanapi.NewProc("WlanEnumInterfaces")
+	procWlanQueryInterface = wlanapi.NewProc("WlanQueryInterface")
+	procWlanFreeMemory     = wlanapi.NewProc("WlanFreeMemory")
+)
+
+// Valores de WLAN_INTF_OPCODE y WLAN_INTERFACE_STATE
+const (
+	wlanOpcodeCurrentConnection = 7
+	wlanOpcodeChannelNumber     = 8
+	wlanStateConnected          = 1
+)
+
+// wlanInterfaceInfo es WLAN_INTERFACE_INFO
+type wlanInterfaceInfo struct {
+	GUID        syscall.GUID
+	Description [256]uint16
+	State       uint32
+}
+
+// wlanConnectionAttributes es el principio de WLAN_CONNECTION_ATTRIBUTES
+// (con WLAN_ASSOCIATION_ATTRIBUTES adentro); lo de seguridad no se usa
+type wlanConnectionAttributes struct {
+	State       uint32
+	Mode        uint32
+	ProfileName [256]uint16
+	SSIDLength  uint32
+	SSID        [32]byte
+	BSSType     uint32
+	BSSID       [6]byte
+	PhyType     uint32
+	PhyIndex    uint32
+	Quality     uint32 // 0 a 100
+	RxRate      uint32
+	TxRate      uint32
+}
+
+// getWiFi pregunta al servicio WLAN por la conexión de cada adaptador
+// inalámbrico. Windows da la señal en porcentaje y el canal, no la frecuencia
+func getWiFi(tr *tracer) []WiFi {
+	if wlanapi.Load() != nil {
+		return nil
+	}
+	var version uint32
+	var handle syscall.Handle
+	if r, _, _ := procWlanOpenHandle.Call(2, 0, uintptr(unsafe.Pointer(&version)), uintptr(unsafe.Pointer(&handle))); r != 0 {
+		return nil
+	}
+	defer procWlanCloseHandle.Call(uintptr(handle), 0)
+
+	var list *struct {
+		Count uint32
+		Index uint32
+		Items [1]wlanInterfaceInfo
+	}
+	if r, _, _ := procWlanEnumInterfaces.Call(uintptr(handle), 0, uintptr(unsafe.Pointer(&list))); r != 0 {
+		return nil
+	}
+	defer procWlanFreeMemory.Call(uintptr(unsafe.Pointer(list)))
+
+	var wifi []WiFi
+	for _, iface := range unsafe.Slice(&list.Items[0], list.Count) {
+		if iface.State != wlanStateConnected {
+			continue
+		}
+		var conn *wlanConnectionAttributes
+		if !wlanQuery(handle, &iface.GUID, wlanOpcodeCurrentConnection, unsafe.Pointer(&conn)) {
+			continue
+		}
+		n := conn.SSIDLength
+		if n > uint32(len(conn.SSID)) {
+			n = uint32(len(conn.SSID))
+		}
+		w := WiFi{
+			Interface: syscall.UTF16ToString(iface.Description[:]),
+			SSID:      string(conn.SSID[:n]),
+			Quality:   int(conn.Quality),
+		}
+		procWlanFreeMemory.Call(uintptr(unsafe.Pointer(conn)))
+
+		var channel *uint32
+		if wlanQuery(handle, &iface.GUID, wlanOpcodeChannelNumber, unsafe.Pointer(&channel)) {
+			w.Band = channelBand(int(*channel))
+			procWlanFreeMemory.Call(uintptr(unsafe.Pointer(channel)))
+		}
+		wifi = append(wifi, w)
+	}
+	return wifi
+}
+
+// wlanQuery llama a WlanQueryInterface; data recibe un puntero que después
+// hay que liberar con WlanFreeMemory
+func wlanQuery(handle syscall.Handle, guid *syscall.GUID, opcode uintptr, data unsafe.Pointer) bool {
+	var size, valueType uint32
+	r, _, _ := procWlanQueryInterface.Call(uintptr(handle), uintptr(unsafe.Pointer(guid)), opcode, 0,
+		uintptr(unsafe.Pointer(&size)), uintptr(data), uintptr(unsafe.Pointer(&valueType)))
+	return r == 0
+}
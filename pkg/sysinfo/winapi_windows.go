@@ -0,0 +1,125 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Funciones de la API de Windows que no trae el paquete syscall
+var (
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+	user32   = syscall.NewLazyDLL("user32.dll")
+	iphlpapi = syscall.NewLazyDLL("iphlpapi.dll")
+
+	procGlobalMemoryStatusEx           = kernel32.NewProc("GlobalMemoryStatusEx")
+	procGetTickCount64                 = kernel32.NewProc("GetTickCount64")
+	procGetDiskFreeSpaceExW            = kernel32.NewProc("GetDiskFreeSpaceExW")
+	procGetLogicalDriveStringsW        = kernel32.NewProc("GetLogicalDriveStringsW")
+	procGetDriveTypeW                  = kernel32.NewProc("GetDriveTypeW")
+	procGetVolumeInformationW          = kernel32.NewProc("GetVolumeInformationW")
+	procGetSystemPowerStatus           = kernel32.NewProc("GetSystemPowerStatus")
+	procGetSystemTimes                 = kernel32.NewProc("GetSystemTimes")
+	procGetLogicalProcessorInformation = kernel32.NewProc("GetLogicalProcessorInformation")
+	procEnumDisplayDevicesW            = user32.NewProc("EnumDisplayDevicesW")
+	procEnumDisplaySettingsW           = user32.NewProc("EnumDisplaySettingsW")
+	procGetIfEntry                     = iphlpapi.NewProc("GetIfEntry")
+)
+
+// regString lee un valor REG_SZ de HKEY_LOCAL_MACHINE, "" si no existe
+func regString(path, name string) string {
+	key, ok := openKey(path)
+	if !ok {
+		return ""
+	}
+	defer syscall.RegCloseKey(key)
+
+	var typ, size uint32
+	namePtr, _ := syscall.UTF16PtrFromString(name)
+	if syscall.RegQueryValueEx(key, namePtr, nil, &typ, nil, &size) != nil || size == 0 {
+		return ""
+	}
+	buf := make([]uint16, size/2+1)
+	if syscall.RegQueryValueEx(key, namePtr, nil, &typ, (*byte)(unsafe.Pointer(&buf[0])), &size) != nil {
+		return ""
+	}
+	return syscall.UTF16ToString(buf)
+}
+
+// regDword lee un valor REG_DWORD de HKEY_LOCAL_MACHINE, 0 si no existe
+func regDword(path, name string) uint32 {
+	key, ok := openKey(path)
+	if !ok {
+		return 0
+	}
+	defer syscall.RegCloseKey(key)
+
+	var typ, val uint32
+	size := uint32(4)
+	namePtr, _ := syscall.UTF16PtrFromString(name)
+	if syscall.RegQueryValueEx(key, namePtr, nil, &typ, (*byte)(unsafe.Pointer(&val)), &size) != nil {
+		return 0
+	}
+	return val
+}
+
+// regQword lee un valor REG_QWORD de HKEY_LOCAL_MACHINE, 0 si no existe.
+// Un REG_DWORD también se lee bien: ocupa los 4 bytes bajos
+func regQword(path, name string) uint64 {
+	key, ok := openKey(path)
+	if !ok {
+		return 0
+	}
+	defer syscall.RegCloseKey(key)
+
+	var typ uint32
+	var val uint64
+	size := uint32(8)
+	namePtr, _ := syscall.UTF16PtrFromString(name)
+	if syscall.RegQueryValueEx(key, namePtr, nil, &typ, (*byte)(unsafe.Pointer(&val)), &size) != nil {
+		return 0
+	}
+	return val
+}
+
+// regSubkeys lista las subclaves de una clave de HKEY_LOCAL_MACHINE
+func regSubkeys(path string) []string {
+	key, ok := openKey(path)
+	if !ok {
+		return nil
+	}
+	defer syscall.RegCloseKey(key)
+
+	var names []string
+	for i := uint32(0); ; i++ {
+		buf := make([]uint16, 256)
+		n := uint32(len(buf))
+		if syscall.RegEnumKeyEx(key, i, &buf[0], &n, nil, nil, nil, nil) != nil {
+			return names
+		}
+		names = append(names, syscall.UTF16ToString(buf[:n]))
+	}
+}
+
+// openKey abre una clave de HKEY_LOCAL_MACHINE en modo lectura
+func openKey(path string) (syscall.Handle, bool) {
+	var key syscall.Handle
+	pathPtr, _ := syscall.UTF16PtrFromString(path)
+	if syscall.RegOpenKeyEx(syscall.HKEY_LOCAL_MACHINE, pathPtr, 0, syscall.KEY_READ, &key) != nil {
+		return 0, false
+	}
+	return key, true
+}
+
+// utf16PtrToString convierte un texto terminado en NUL que devolvió la API
+func utf16PtrToString(p *uint16) string {
+	if p == nil {
+		return ""
+	}
+	n := 0
+	for ptr := unsafe.Pointer(p); *(*uint16)(ptr) != 0; ptr = unsafe.Add(ptr, 2) {
+		n++
+	}
+	return syscall.UTF16ToString(unsafe.Slice(p, n))
+}
@@ -0,0 +1,40 @@
+package sysinfo
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// wslVersion detecta si se corre dentro de WSL y devuelve "WSL1" o "WSL2",
+// o "" si no. WSL2 usa un kernel propio ("...-microsoft-standard-WSL2") y
+// WSL1 emula uno con "Microsoft" en la versión
+func wslVersion(tr *tracer) string {
+	release := tr.readTrim("/proc/sys/kernel/osrelease")
+	if release == "" {
+		release = tr.readTrim("/proc/version")
+	}
+	lower := strings.ToLower(release)
+	switch {
+	case strings.Contains(lower, "wsl2"), strings.Contains(lower, "microsoft-standard"):
+		return "WSL2"
+	case strings.Contains(lower, "microsoft"):
+		return "WSL1"
+	case os.Getenv("WSL_DISTRO_NAME") != "":
+		return "WSL"
+	}
+	return ""
+}
+
+// wslHostname pide el nombre del equipo Windows a cmd.exe (la interop de WSL
+// lo deja en el PATH). Devuelve "" si la interop está deshabilitada
+func wslHostname(tr *tracer) string {
+	if _, err := exec.LookPath("cmd.exe"); err != nil {
+		return ""
+	}
+	name := tr.runCmd("cmd.exe", "/c", "echo %COMPUTERNAME%")
+	if name == "N/A" || strings.Contains(name, "%") {
+		return ""
+	}
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// pluginTimeout es lo máximo que se espera a un plugin antes de ocultar su línea
+const pluginTimeout = 2 * time.Second
+
+// pluginDir devuelve la carpeta de plugins, ~/.config/cafetch/modules
+func pluginDir() string {
+	path := configPath()
+	if path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(path), "modules")
+}
+
+// registerPlugins agrega como módulo cada ejecutable de dir, con el nombre
+// del archivo sin extensión (ej. "vpn.sh" es el módulo "vpn"). Se pueden
+// ubicar en modules del config como cualquier otro; sin config van al final.
+// Un plugin con el nombre de un módulo de cafetch se ignora
+func registerPlugins(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var names []string
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if strings.HasPrefix(e.Name(), ".") || !isExecutable(path) {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())))
+		if _, exists := modules[name]; exists || name == "break" {
+			fmt.Fprintf(os.Stderr, "cafetch: plugin %s: module %q already exists\n", e.Name(), name)
+			continue
+		}
+		modules[name] = module{Label: name, Section: "custom", Pairs: func(sysinfo.SystemInfo) []labeledValue {
+			return runPlugin(path)
+		}}
+		names = append(names, name)
+	}
+
+	if len(names) > 0 {
+		sort.Strings(names)
+		defaultModules = append(append(defaultModules, "break"), names...)
+	}
+}
+
+// isExecutable indica si el archivo se puede ejecutar. En Windows no hay bit
+// de ejecución, así que se mira la extensión
+func isExecutable(path string) bool {
+	st, err := os.Stat(path)
+	if err != nil || st.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return st.Mode()&0o111 != 0
+}
+
+// runPlugin ejecuta el plugin y parsea su salida. Si falla o tarda más de
+// pluginTimeout no muestra nada
+func runPlugin(path string) []labeledValue {
+	out, took, err := runWithTimeout(pluginTimeout, path)
+	if err != nil {
+		debugLog.Debug("plugin failed", "path", path, "duration", took, "err", err)
+		return nil
+	}
+	debugLog.Debug("plugin", "path", path, "duration", took, "bytes", len(out))
+	return parsePluginOutput(out)
+}
+
+// runWithTimeout corre un plugin o un [[custom]] y devuelve su salida y
+// cuánto tardó. Si el comando deja procesos hijos con la salida abierta no
+// se los espera más de 100ms, si no el timeout no alcanzaría para cortarlo
+func runWithTimeout(timeout time.Duration, name string, args ...string) ([]byte, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = 100 * time.Millisecond
+	start := time.Now()
+	out, err := cmd.Output()
+	if err != nil {
+		err = errors.New(commandError(ctx, err))
+	}
+	return out, time.Since(start), err
+}
+
+// parsePluginOutput entiende dos formatos: JSON, un objeto
+// {"label": "VPN", "value": "wg0"} o un array de ellos, o texto con una
+// línea "Etiqueta: valor" por renglón. Las líneas sin etiqueta usan el
+// nombre del plugin
+func parsePluginOutput(out []byte) []labeledValue {
+	out = bytes.TrimSpace(out)
+	if len(out) > 0 && (out[0] == '{' || out[0] == '[') {
+		var many []labeledValue
+		if json.Unmarshal(out, &many) == nil {
+			return many
+		}
+		var one labeledValue
+		if json.Unmarshal(out, &one) == nil {
+			return []labeledValue{one}
+		}
+	}
+
+	var lines []labeledValue
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if label, value, ok := strings.Cut(line, ": "); ok {
+			lines = append(lines, labeledValue{Label: strings.TrimSpace(label), Value: strings.TrimSpace(value)})
+		} else {
+			lines = append(lines, labeledValue{Value: line})
+		}
+	}
+	return lines
+}
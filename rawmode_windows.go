@@ -0,0 +1,34 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"unsafe"
+)
+
+// Modos de entrada de la consola
+const (
+	enableProcessedInput       = 0x0001
+	enableLineInput            = 0x0002
+	enableEchoInput            = 0x0004
+	enableVirtualTerminalInput = 0x0200
+)
+
+// makeRaw pone la consola f en modo crudo y devuelve la función que la
+// restaura. Con ENABLE_VIRTUAL_TERMINAL_INPUT las flechas llegan como
+// secuencias ANSI, igual que en una terminal Unix
+func makeRaw(f *os.File) (restore func(), err error) {
+	handle := f.Fd()
+	var old uint32
+	if ok, _, e := procGetConsoleMode.Call(handle, uintptr(unsafe.Pointer(&old))); ok == 0 {
+		return nil, e
+	}
+	raw := old&^(enableProcessedInput|enableLineInput|enableEchoInput) | enableVirtualTerminalInput
+	if ok, _, e := procSetConsoleMode.Call(handle, uintptr(raw)); ok == 0 {
+		return nil, e
+	}
+	return func() {
+		procSetConsoleMode.Call(handle, uintptr(old))
+	}, nil
+}
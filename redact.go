@@ -0,0 +1,185 @@
+package main
+
+import (
+	"net"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// Textos que reemplazan a los datos ocultos por --anonymize
+const (
+	redactedUser = "user"
+	redactedHost = "host"
+	redactedIPv4 = "x.x.x.x"
+	redactedIPv6 = "x:x:x:x"
+	redactedMAC  = "xx:xx:xx:xx:xx:xx"
+	redactedSSID = "wifi"
+)
+
+// Direcciones que pueden aparecer en textos libres (plugins, plantillas)
+var (
+	ipv4Re = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	macRe  = regexp.MustCompile(`\b(?:[0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}\b`)
+)
+
+// anonymize reemplaza en info los datos que identifican al usuario o a la
+// máquina, para poder compartir la salida en público. Un campo nuevo con
+// datos así (números de serie, ids) se tiene que agregar acá. Además pasa r
+// (si no es nil) por todos los textos, así las rutas dentro del home o el
+// hostname en un campo libre tampoco salen en --json
+func anonymize(info *sysinfo.SystemInfo, r *redactor) {
+	if info.User != "N/A" {
+		info.User = redactedUser
+	}
+	if info.Host != "N/A" {
+		info.Host = redactedHost
+	}
+	if info.IP != "" && info.IP != "N/A" {
+		info.IP = redactedIPv4
+	}
+	if info.IPv6 != "" {
+		info.IPv6 = redactedIPv6
+	}
+	if info.PublicIP != "" && info.PublicIP != "N/A" {
+		info.PublicIP = redactedIP(info.PublicIP)
+	}
+	network := make([]sysinfo.NetInterface, len(info.Network))
+	for n, iface := range info.Network {
+		if iface.IPv4 != "" {
+			iface.IPv4 = redactedIPv4
+		}
+		if iface.IPv6 != "" {
+			iface.IPv6 = redactedIPv6
+		}
+		network[n] = iface
+	}
+	info.Network = network
+	// El SSID alcanza para ubicar la red en un mapa de redes Wi-Fi
+	wifi := make([]sysinfo.WiFi, len(info.WiFi))
+	for n, w := range info.WiFi {
+		w.SSID = redactedSSID
+		wifi[n] = w
+	}
+	info.WiFi = wifi
+	// Las otras sesiones muestran usuarios y direcciones de otras personas
+	sessions := make([]sysinfo.Session, len(info.Sessions))
+	for n, s := range info.Sessions {
+		s.User = redactedUser
+		if s.Host != "" {
+			s.Host = redactedHost
+		}
+		sessions[n] = s
+	}
+	info.Sessions = sessions
+	if r != nil {
+		redactStrings(reflect.ValueOf(info).Elem(), r)
+	}
+}
+
+// redactStrings pasa r por todos los strings de v. Los slices se copian
+// antes de cambiarlos, así no se toca lo que comparten con otra copia de
+// SystemInfo (la del servidor, la anterior de --watch)
+func redactStrings(v reflect.Value, r *redactor) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(r.Replace(v.String()))
+	case reflect.Struct:
+		for n := 0; n < v.NumField(); n++ {
+			if v.Type().Field(n).IsExported() {
+				redactStrings(v.Field(n), r)
+			}
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		for n := 0; n < c.Len(); n++ {
+			redactStrings(c.Index(n), r)
+		}
+		v.Set(c)
+	}
+}
+
+// redactedIP devuelve el reemplazo de ip según sea IPv4 o IPv6
+func redactedIP(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return redactedIPv6
+	}
+	return redactedIPv4
+}
+
+// redactor oculta en un texto libre (la salida de los plugins, rutas dentro
+// del home) los datos reales que anonymize saca de SystemInfo
+type redactor struct {
+	exact *strings.Replacer // el home y las IPv6, que se buscan tal cual
+	names *regexp.Regexp    // usuario y hostname, solo como palabra entera
+	repl  map[string]string // reemplazo de cada nombre de names
+}
+
+// newRedactor arma los reemplazos de los datos de info que anonymize va a
+// ocultar. Se llama una vez antes de anonymize, con los datos reales, y se
+// reusa en cada refresco
+func newRedactor(info sysinfo.SystemInfo) *redactor {
+	var pairs []string
+	if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+		pairs = append(pairs, home, "~")
+	}
+	if info.IPv6 != "" {
+		pairs = append(pairs, info.IPv6, redactedIPv6)
+	}
+	if info.PublicIP != "" && info.PublicIP != "N/A" {
+		pairs = append(pairs, info.PublicIP, redactedIP(info.PublicIP))
+	}
+	for _, iface := range info.Network {
+		if iface.IPv6 != "" {
+			pairs = append(pairs, iface.IPv6, redactedIPv6)
+		}
+	}
+	r := &redactor{exact: strings.NewReplacer(pairs...), repl: map[string]string{}}
+
+	// Un nombre corto ("an", "arch") también es parte de otras palabras
+	// ("Debian", "Arch Linux"), así que solo se reemplaza entero
+	if info.Host != "N/A" && info.Host != "" {
+		r.repl[info.Host] = redactedHost
+	}
+	// root no identifica a nadie y reemplazarlo rompería rutas como /root
+	if info.User != "N/A" && info.User != "" && info.User != "root" {
+		r.repl[info.User] = redactedUser
+	}
+	if len(r.repl) > 0 {
+		var alts []string
+		for name := range r.repl {
+			alts = append(alts, regexp.QuoteMeta(name))
+		}
+		// El más largo primero, por si uno contiene al otro
+		sort.Slice(alts, func(a, b int) bool { return len(alts[a]) > len(alts[b]) })
+		r.names = regexp.MustCompile(`\b(?:` + strings.Join(alts, "|") + `)\b`)
+	}
+	return r
+}
+
+// Replace devuelve s sin los datos reales. Las IPv4 y MAC se buscan por su
+// forma, así que se ocultan aunque vengan de un plugin
+func (r *redactor) Replace(s string) string {
+	s = r.exact.Replace(s)
+	if r.names != nil {
+		s = r.names.ReplaceAllStringFunc(s, func(name string) string { return r.repl[name] })
+	}
+	s = ipv4Re.ReplaceAllString(s, redactedIPv4)
+	return macRe.ReplaceAllString(s, redactedMAC)
+}
+
+// redactText oculta en s los datos personales si está activo --anonymize
+func (cfg config) redactText(s string) string {
+	if cfg.Redactor == nil {
+		return s
+	}
+	return cfg.Redactor.Replace(s)
+}
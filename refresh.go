@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// defaultWatchInterval son los segundos entre redibujados de --watch sin valor
+const defaultWatchInterval = 2
+
+// Secuencias ANSI usadas por el modo watch
+const (
+	altScreenOn  = "\033[?1049h"
+	altScreenOff = "\033[?1049l"
+	cursorHome   = "\033[H"
+	clearLine    = "\033[K"
+	clearBelow   = "\033[J"
+	hideCursor   = "\033[?25l"
+	showCursor   = "\033[?25h"
+)
+
+// watchFlag es el valor de --watch, que funciona con o sin número:
+// "--watch" usa defaultWatchInterval y "--watch=5" cada 5 segundos
+type watchFlag struct {
+	seconds *int
+}
+
+func (w watchFlag) String() string {
+	if w.seconds == nil {
+		return ""
+	}
+	return strconv.Itoa(*w.seconds)
+}
+
+func (w watchFlag) Set(s string) error {
+	switch s {
+	case "true":
+		*w.seconds = defaultWatchInterval
+		return nil
+	case "false":
+		*w.seconds = 0
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return errors.New("expected a positive number of seconds")
+	}
+	*w.seconds = n
+	return nil
+}
+
+// watchArgs junta "--watch 5" en "--watch=5": como --watch también va sin
+// número, flag no toma el argumento siguiente y cortaría ahí el resto de
+// los flags. El número se revisa acá para no dar el error de un flag
+// booleano ("invalid boolean value")
+func watchArgs(args []string) ([]string, error) {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(out, args[i:]...), nil
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "watch" {
+			out = append(out, arg)
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			if _, err := strconv.Atoi(args[i+1]); err == nil {
+				value, hasValue = args[i+1], true
+				i++
+			}
+		}
+		if hasValue && value != "true" && value != "false" {
+			if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+				return nil, fmt.Errorf("--watch: %q is not a positive number of seconds", value)
+			}
+		}
+		if hasValue {
+			arg = "--watch=" + value
+		}
+		out = append(out, arg)
+	}
+	return out, nil
+}
+
+// IsBoolFlag permite usar --watch sin valor
+func (w watchFlag) IsBoolFlag() bool {
+	return true
+}
+
+// runWatch redibuja la salida cada interval hasta recibir SIGINT/SIGTERM.
+// Se dibuja en la pantalla alternativa y cada cuadro sobrescribe al
+// anterior en su lugar (sin borrar la pantalla), así no parpadea. Los datos
+// estáticos se reutilizan y solo se recolectan los dinámicos
+func runWatch(info sysinfo.SystemInfo, cfg config, interval time.Duration) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	os.Stdout.WriteString(altScreenOn + hideCursor)
+	// Devuelve la pantalla y el cursor antes de salir
+	defer os.Stdout.WriteString(showCursor + altScreenOff)
+
+	// Los plugins y los [[custom]] corren una vez por intervalo
+	custom := cacheCustomModules()
+
+	for {
+		// Cada línea borra lo que quedaba del cuadro anterior a su derecha,
+		// y al final se borra lo que sobra abajo
+		lines := renderInfo(info, cfg)
+		os.Stdout.WriteString(cursorHome + strings.Join(lines, clearLine+"\n") + clearLine + "\n" + clearBelow)
+
+		select {
+		case <-sig:
+			return
+		case <-ticker.C:
+			custom.reset()
+			sysinfo.Refresh(context.Background(), &info, cfg.shownOptions())
+			if cfg.Anonymize {
+				anonymize(&info, cfg.Redactor)
+			}
+		}
+	}
+}
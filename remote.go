@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// remoteTimeout es el tiempo máximo para conectarse y recolectar la info
+// de la otra máquina
+const remoteTimeout = 30 * time.Second
+
+// remoteScript corre en la otra máquina. Si tiene cafetch se usa su JSON;
+// si no, se juntan los datos básicos con herramientas POSIX como líneas
+// clave=valor (memoria y disco en KB)
+const remoteScript = `if command -v cafetch >/dev/null 2>&1; then exec cafetch --json; fi
+echo "user=$(id -un)"
+echo "host=$(hostname 2>/dev/null || uname -n)"
+echo "kernel=$(uname -r)"
+echo "arch=$(uname -m)"
+(. /etc/os-release 2>/dev/null && echo "os=$PRETTY_NAME")
+echo "shell=$SHELL"
+[ -r /proc/uptime ] && echo "uptime=$(cut -d' ' -f1 /proc/uptime)"
+[ -r /proc/loadavg ] && echo "load=$(cut -d' ' -f1-3 /proc/loadavg)"
+[ -r /proc/cpuinfo ] && echo "cpu=$(grep -m1 'model name' /proc/cpuinfo | cut -d: -f2-)"
+echo "threads=$(getconf _NPROCESSORS_ONLN 2>/dev/null)"
+[ -r /proc/cpuinfo ] && awk -F: '/^physical id/ {p = $2} /^core id/ {c[p ":" $2] = 1} END {for (k in c) n++; if (n) print "cores=" n}' /proc/cpuinfo
+[ -r /proc/meminfo ] && awk '/^MemTotal:/ {print "mem_total=" $2} /^MemAvailable:/ {print "mem_available=" $2}' /proc/meminfo
+echo "procs=$(ps -A -o pid= 2>/dev/null | wc -l)"
+df -Pk / 2>/dev/null | awk 'NR == 2 {print "disk_total=" $2; print "disk_available=" $4; print "disk_device=" $1}'
+exit 0`
+
+// fetchRemote obtiene la info de target (user@host o un alias de
+// ~/.ssh/config) corriendo remoteScript con el cliente ssh del sistema
+func fetchRemote(target string) (*sysinfo.SystemInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	// El script va por sh -c para no depender del shell de login remoto
+	var stderr bytes.Buffer
+	// "--" para que un target como "-oProxyCommand=..." no sea una opción
+	cmd := exec.CommandContext(ctx, "ssh", "-o", "BatchMode=yes", "-o", "ConnectTimeout=10", "--",
+		target, "sh -c "+shellQuote(remoteScript))
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("ssh %s: %s", target, msg)
+		}
+		return nil, fmt.Errorf("ssh %s: %w", target, err)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(out), []byte("{")) {
+		var info sysinfo.SystemInfo
+		if err := json.Unmarshal(out, &info); err != nil {
+			return nil, fmt.Errorf("%s: invalid cafetch --json output: %w", target, err)
+		}
+		return &info, nil
+	}
+	return parseRemoteBasic(out)
+}
+
+// parseRemoteBasic arma la info a partir de las líneas clave=valor de
+// remoteScript. Lo que no vino queda en "N/A" o vacío
+func parseRemoteBasic(out []byte) (*sysinfo.SystemInfo, error) {
+	info := &sysinfo.SystemInfo{
+		OS: "N/A", Kernel: "N/A", Arch: "N/A", Host: "N/A", User: "N/A", Shell: "N/A", Term: "N/A",
+		CPU: sysinfo.CPUInfo{Model: "N/A"},
+	}
+	var memAvailable, diskAvailable uint64
+	var disk sysinfo.Mount
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), "=")
+		val = strings.TrimSpace(val)
+		if !ok || val == "" {
+			continue
+		}
+		kb, _ := strconv.ParseUint(val, 10, 64)
+		switch key {
+		case "user":
+			info.User = val
+		case "host":
+			info.Host = val
+		case "kernel":
+			info.Kernel = val
+		case "arch":
+			info.Arch = val
+		case "os":
+			info.OS = val
+		case "shell":
+			info.Shell = filepath.Base(val)
+		case "uptime":
+			secs, _ := strconv.ParseFloat(val, 64)
+			info.Uptime = int64(secs)
+		case "load":
+			if f := strings.Fields(val); len(f) == 3 {
+				info.Load.One, _ = strconv.ParseFloat(f[0], 64)
+				info.Load.Five, _ = strconv.ParseFloat(f[1], 64)
+				info.Load.Fifteen, _ = strconv.ParseFloat(f[2], 64)
+			}
+		case "cpu":
+			info.CPU.Model = strings.Join(strings.Fields(val), " ")
+		case "threads":
+			info.CPU.Threads, _ = strconv.Atoi(val)
+		case "cores":
+			// Solo con "core id" en /proc/cpuinfo; getconf cuenta hilos
+			info.CPU.Cores, _ = strconv.Atoi(val)
+		case "procs":
+			info.Processes.Total, _ = strconv.Atoi(val)
+		case "mem_total":
+			info.Memory.Total = kb * 1024
+		case "mem_available":
+			memAvailable = kb * 1024
+		case "disk_total":
+			disk.Total = kb * 1024
+		case "disk_available":
+			diskAvailable = kb * 1024
+		case "disk_device":
+			disk.Device = val
+		}
+	}
+	if info.Kernel == "N/A" {
+		return nil, errors.New("unexpected output from the remote host")
+	}
+	if info.Memory.Total > memAvailable {
+		info.Memory.Used = info.Memory.Total - memAvailable
+	}
+	// Lo reservado para root cuenta como usado, igual que en getDisk
+	if disk.Total > diskAvailable {
+		disk.Path, disk.Used = "/", disk.Total-diskAvailable
+		info.Disk, info.Disks = disk.Usage, []sysinfo.Mount{disk}
+	}
+	return info, nil
+}
+
+// shellQuote encierra s entre comillas simples para sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
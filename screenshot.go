@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// screenshotFont es DejaVu Sans Mono rasterizada a celdas de 10x20 píxeles
+// con 16 niveles de gris: ASCII, Latin-1 y los símbolos que usa la salida
+// (flechas, bloques de las barras, "…"). Formato: ancho, alto y cantidad de
+// glifos (u8, u8, u16) y por glifo la runa (u32) y los píxeles a 4 bits.
+// DejaVu es © Bitstream y © DejaVu fonts team, bajo la licencia de Bitstream Vera
+//
+//go:embed screenshot_font.bin
+var screenshotFont []byte
+
+// Colores del fondo y del texto sin color, los mismos que en --output html
+var (
+	screenshotBG = color.RGBA{0x1e, 0x1e, 0x1e, 0xff}
+	screenshotFG = int32(0xd4d4d4)
+)
+
+// screenshotPadding es el borde alrededor del texto, en celdas
+const screenshotPadding = 2
+
+// bitmapFont son los glifos de screenshotFont por runa
+type bitmapFont struct {
+	w, h   int
+	glyphs map[rune][]byte
+}
+
+var (
+	fontOnce   sync.Once
+	loadedFont bitmapFont
+)
+
+// font decodifica screenshotFont la primera vez que se usa
+func font() bitmapFont {
+	fontOnce.Do(func() {
+		f := bitmapFont{w: int(screenshotFont[0]), h: int(screenshotFont[1]), glyphs: map[rune][]byte{}}
+		count := int(binary.LittleEndian.Uint16(screenshotFont[2:4]))
+		size := f.w * f.h / 2
+		for i, p := 0, 4; i < count && p+4+size <= len(screenshotFont); i++ {
+			r := rune(binary.LittleEndian.Uint32(screenshotFont[p:]))
+			f.glyphs[r] = screenshotFont[p+4 : p+4+size]
+			p += 4 + size
+		}
+		loadedFont = f
+	})
+	return loadedFont
+}
+
+// writeScreenshot dibuja las líneas (con sus colores ANSI) en path, como
+// PNG o SVG según la extensión
+func writeScreenshot(path string, lines []string) error {
+	var buf bytes.Buffer
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		if err := png.Encode(&buf, renderPNG(lines)); err != nil {
+			return err
+		}
+	case ".svg":
+		buf.WriteString(renderSVG(lines))
+	default:
+		return fmt.Errorf("%s: use a .png or .svg file", path)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// screenshotColumns es el ancho en celdas de la línea más larga
+func screenshotColumns(lines []string) int {
+	cols := 0
+	for _, line := range lines {
+		if n := visibleLen(line); n > cols {
+			cols = n
+		}
+	}
+	return cols
+}
+
+// renderPNG pinta cada carácter con su glifo mezclado sobre el fondo. La
+// negrita se simula dibujando el glifo otra vez un píxel a la derecha. Los
+// caracteres que no están en la fuente se dibujan como "?"
+func renderPNG(lines []string) *image.RGBA {
+	f := font()
+	cols := screenshotColumns(lines)
+	img := image.NewRGBA(image.Rect(0, 0, (cols+2*screenshotPadding)*f.w, (len(lines)+2*screenshotPadding)*f.h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = screenshotBG.R, screenshotBG.G, screenshotBG.B, 0xff
+	}
+
+	for row, line := range lines {
+		col := 0
+		y := (row + screenshotPadding) * f.h
+		for _, span := range parseANSI(line) {
+			fg := span.fg
+			if fg < 0 {
+				fg = screenshotFG
+			}
+			for _, r := range span.text {
+				x := (col + screenshotPadding) * f.w
+				glyph, ok := f.glyphs[r]
+				if !ok && r != ' ' {
+					glyph = f.glyphs['?']
+				}
+				drawGlyph(img, f, glyph, x, y, fg)
+				if span.bold {
+					drawGlyph(img, f, glyph, x+1, y, fg)
+				}
+				col++
+			}
+		}
+	}
+	return img
+}
+
+// drawGlyph mezcla el glifo con el color fg en la posición x, y
+func drawGlyph(img *image.RGBA, f bitmapFont, glyph []byte, x, y int, fg int32) {
+	fr, fg8, fb := uint32(fg>>16&0xff), uint32(fg>>8&0xff), uint32(fg&0xff)
+	for n := 0; n < len(glyph)*2; n++ {
+		v := uint32(glyph[n/2])
+		if n%2 == 0 {
+			v >>= 4
+		}
+		alpha := v & 0xf
+		if alpha == 0 {
+			continue
+		}
+		px, py := x+n%f.w, y+n/f.w
+		if !(image.Point{px, py}.In(img.Rect)) {
+			continue
+		}
+		i := img.PixOffset(px, py)
+		blend := func(dst uint8, src uint32) uint8 {
+			return uint8((uint32(dst)*(15-alpha) + src*alpha) / 15)
+		}
+		img.Pix[i] = blend(img.Pix[i], fr)
+		img.Pix[i+1] = blend(img.Pix[i+1], fg8)
+		img.Pix[i+2] = blend(img.Pix[i+2], fb)
+	}
+}
+
+// renderSVG arma un SVG con una línea de texto por renglón. El texto queda
+// como texto (se puede copiar) y lo dibuja la fuente monoespaciada del que
+// lo abre; el tamaño es el de la misma grilla que el PNG
+func renderSVG(lines []string) string {
+	f := font()
+	cols := screenshotColumns(lines)
+	w, h := (cols+2*screenshotPadding)*f.w, (len(lines)+2*screenshotPadding)*f.h
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", w, h, w, h)
+	fmt.Fprintf(&b, "<rect width=\"100%%\" height=\"100%%\" fill=\"#%02x%02x%02x\"/>\n", screenshotBG.R, screenshotBG.G, screenshotBG.B)
+	// Las fuentes monoespaciadas comunes avanzan 0.6em por carácter
+	fmt.Fprintf(&b, "<g font-family=\"'DejaVu Sans Mono', Menlo, Consolas, monospace\" font-size=\"%.2f\" fill=\"#%06x\" xml:space=\"preserve\">\n",
+		float64(f.w)/0.6, screenshotFG)
+	for row, line := range lines {
+		if strings.TrimSpace(stripANSI(line)) == "" {
+			continue
+		}
+		// La línea de base queda a 3/4 de la celda
+		fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\">", screenshotPadding*f.w, (row+screenshotPadding)*f.h+f.h*3/4)
+		for _, span := range parseANSI(line) {
+			var attrs string
+			if span.fg >= 0 {
+				attrs += fmt.Sprintf(" fill=\"#%06x\"", span.fg)
+			}
+			if span.bold {
+				attrs += " font-weight=\"bold\""
+			}
+			text := html.EscapeString(span.text)
+			if attrs == "" {
+				b.WriteString(text)
+			} else {
+				fmt.Fprintf(&b, "<tspan%s>%s</tspan>", attrs, text)
+			}
+		}
+		b.WriteString("</text>\n")
+	}
+	b.WriteString("</g>\n</svg>\n")
+	return b.String()
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// defaultListen es la dirección de "cafetch serve" si no se pasa --listen
+const defaultListen = ":9123"
+
+// server sirve la info por HTTP. Los datos estáticos se recolectan una vez
+// al arrancar y los dinámicos se vuelven a leer en cada petición
+type server struct {
+	mu        sync.Mutex
+	info      sysinfo.SystemInfo
+	opts      sysinfo.Options
+	anonymize bool      // anonymize del config
+	redactor  *redactor // armado con los datos reales del inicio
+}
+
+// runServe es el subcomando "cafetch serve": expone la info como JSON en
+// /info y en el formato de texto de Prometheus en /metrics
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", defaultListen, "`address` to listen on")
+	fs.Parse(args)
+
+	cfg := userConfig()
+	opts := cfg.collectOptions()
+	info, err := sysinfo.Collect(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
+	}
+
+	s := &server{info: *info, opts: opts, anonymize: cfg.Anonymize, redactor: newRedactor(*info)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/info", s.handleInfo)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	srv := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	fmt.Fprintln(os.Stderr, "cafetch: listening on", *listen)
+	if err := srv.ListenAndServe(); err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch:", err)
+		os.Exit(1)
+	}
+}
+
+// snapshot actualiza los datos dinámicos y devuelve una copia. Refresh
+// reemplaza los slices en vez de modificarlos, así que la copia no cambia
+// aunque llegue otra petición
+func (s *server) snapshot(ctx context.Context) sysinfo.SystemInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sysinfo.Refresh(ctx, &s.info, s.opts)
+	info := s.info
+	if s.anonymize {
+		anonymize(&info, s.redactor)
+	}
+	return info
+}
+
+// handleInfo responde la info completa como JSON, igual que --json
+func (s *server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	info := s.snapshot(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(info)
+}
+
+// handleMetrics responde los recursos como gauges de Prometheus
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	info := s.snapshot(r.Context())
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeMetrics(w, info)
+}
+
+// sample es un valor de una métrica con sus etiquetas (pares nombre, valor)
+type sample struct {
+	labels []string
+	value  float64
+}
+
+// labelEscaper escapa los valores de las etiquetas como pide Prometheus
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeGauge escribe una métrica de tipo gauge con su ayuda. Sin muestras
+// no escribe nada
+func writeGauge(w io.Writer, name, help string, samples ...sample) {
+	if len(samples) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	for _, s := range samples {
+		var labels []string
+		for i := 0; i+1 < len(s.labels); i += 2 {
+			labels = append(labels, s.labels[i]+`="`+labelEscaper.Replace(s.labels[i+1])+`"`)
+		}
+		value := strconv.FormatFloat(s.value, 'g', -1, 64)
+		if len(labels) == 0 {
+			fmt.Fprintf(w, "%s %s\n", name, value)
+		} else {
+			fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(labels, ","), value)
+		}
+	}
+}
+
+// writeMetrics escribe todas las métricas de info
+func writeMetrics(w io.Writer, info sysinfo.SystemInfo) {
+	writeGauge(w, "cafetch_info", "System description, always 1.",
+		sample{[]string{"os", info.OS, "kernel", info.Kernel, "arch", info.Arch, "host", info.Host}, 1})
+	writeGauge(w, "cafetch_uptime_seconds", "Time since boot in seconds.", sample{value: float64(info.Uptime)})
+
+	writeGauge(w, "cafetch_load1", "1m load average.", sample{value: info.Load.One})
+	writeGauge(w, "cafetch_load5", "5m load average.", sample{value: info.Load.Five})
+	writeGauge(w, "cafetch_load15", "15m load average.", sample{value: info.Load.Fifteen})
+	writeGauge(w, "cafetch_processes", "Number of processes.", sample{value: float64(info.Processes.Total)})
+	writeGauge(w, "cafetch_processes_running", "Number of running processes.", sample{value: float64(info.Processes.Running)})
+
+	writeGauge(w, "cafetch_memory_total_bytes", "Total memory in bytes.", sample{value: float64(info.Memory.Total)})
+	writeGauge(w, "cafetch_memory_used_bytes", "Used memory in bytes.", sample{value: float64(info.Memory.Used)})
+	writeGauge(w, "cafetch_swap_total_bytes", "Total swap in bytes.", sample{value: float64(info.Swap.Total)})
+	writeGauge(w, "cafetch_swap_used_bytes", "Used swap in bytes.", sample{value: float64(info.Swap.Used)})
+
+	var total, used []sample
+	for _, m := range info.Disks {
+		labels := []string{"mountpoint", m.Path, "device", m.Device, "fstype", m.FSType}
+		total = append(total, sample{labels, float64(m.Total)})
+		used = append(used, sample{labels, float64(m.Used)})
+	}
+	writeGauge(w, "cafetch_disk_total_bytes", "Filesystem size in bytes.", total...)
+	writeGauge(w, "cafetch_disk_used_bytes", "Used filesystem space in bytes.", used...)
+
+	var temps []sample
+	for _, t := range info.Temperatures {
+		temps = append(temps, sample{[]string{"sensor", t.Sensor}, t.Celsius})
+	}
+	writeGauge(w, "cafetch_temperature_celsius", "Sensor temperature in degrees Celsius.", temps...)
+
+	var batteries []sample
+	for _, b := range info.Batteries {
+		batteries = append(batteries, sample{[]string{"battery", b.Name}, float64(b.Capacity)})
+	}
+	writeGauge(w, "cafetch_battery_capacity_percent", "Battery charge in percent.", batteries...)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// defaultShareURL es el paste de --share: recibe el texto en el cuerpo de un
+// POST y responde con el link
+const defaultShareURL = "https://paste.rs/"
+
+// shareTimeout es lo máximo que se espera a la subida
+const shareTimeout = 15 * time.Second
+
+// shareMaxResponse es lo que se lee de la respuesta, que debería ser solo el link
+const shareMaxResponse = 4 << 10
+
+// shareText arma lo que sube --share (y copia --copy) en el formato elegido: JSON con
+// --json, markdown o html con --output y si no el texto sin colores
+func shareText(info sysinfo.SystemInfo, cfg config, opts options) string {
+	switch {
+	case opts.JSON:
+		var b bytes.Buffer
+		printJSON(&b, info)
+		return b.String()
+	case opts.Output == "markdown":
+		return renderMarkdown(info, cfg)
+	case opts.Output == "html":
+		return renderHTML(info, cfg)
+	}
+	return strings.Join(renderInfo(info, cfg), "\n") + "\n"
+}
+
+// shareOutput sube text a endpoint con un POST y devuelve el link. El
+// servicio tiene que aceptar el texto crudo en el cuerpo (paste.rs,
+// un pastebin propio...) y responder con el link en el cuerpo o en el
+// header Location
+func shareOutput(endpoint, text string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shareTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(text))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("User-Agent", "cafetch/"+currentBuild().Version)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, shareMaxResponse))
+	if err != nil {
+		return "", err
+	}
+
+	// Location puede ser relativo al endpoint
+	var link string
+	if loc, err := resp.Location(); err == nil {
+		link = loc.String()
+	} else if fields := strings.Fields(string(body)); len(fields) > 0 {
+		link = fields[0]
+	}
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", errors.New("the paste service didn't answer with a link")
+	}
+	return link, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+)
+
+// sixelImage escala la imagen a width x height píxeles y la codifica como
+// sixel. Los colores se reducen a un cubo de 6x6x6 (216 colores), que
+// alcanza para un logo y evita tener que cuantizar de verdad. Los píxeles
+// transparentes no se dibujan
+func sixelImage(img image.Image, width, height int) string {
+	b := img.Bounds()
+	// pixel devuelve el color del cubo para (x, y) ya escalado, -1 si es transparente
+	pixel := func(x, y int) int {
+		r, g, bl, a := img.At(b.Min.X+x*b.Dx()/width, b.Min.Y+y*b.Dy()/height).RGBA()
+		if a < 0x8000 {
+			return -1
+		}
+		cube := func(v uint32) int { return int((v>>8)*5+127) / 255 }
+		return cube(r)*36 + cube(g)*6 + cube(bl)
+	}
+
+	var s strings.Builder
+	// P2=1: el fondo queda transparente. La cabecera de raster fija el tamaño
+	fmt.Fprintf(&s, "\033P0;1;0q\"1;1;%d;%d", width, height)
+	for i := 0; i < 216; i++ {
+		fmt.Fprintf(&s, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+	}
+
+	// Cada banda son 6 filas de píxeles; por cada color se escribe una
+	// pasada con los bits de las filas donde aparece
+	for y0 := 0; y0 < height; y0 += 6 {
+		bands := map[int][]byte{}
+		for dy := 0; dy < 6 && y0+dy < height; dy++ {
+			for x := 0; x < width; x++ {
+				c := pixel(x, y0+dy)
+				if c < 0 {
+					continue
+				}
+				if bands[c] == nil {
+					bands[c] = make([]byte, width)
+				}
+				bands[c][x] |= 1 << dy
+			}
+		}
+
+		colors := make([]int, 0, len(bands))
+		for c := range bands {
+			colors = append(colors, c)
+		}
+		sort.Ints(colors)
+		for i, c := range colors {
+			if i > 0 {
+				s.WriteByte('$')
+			}
+			fmt.Fprintf(&s, "#%d", c)
+			writeSixelRuns(&s, bands[c])
+		}
+		s.WriteByte('-')
+	}
+	s.WriteString("\033\\")
+	return s.String()
+}
+
+// writeSixelRuns escribe una fila de sixels comprimiendo las repeticiones
+// con "!n" cuando conviene
+func writeSixelRuns(s *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		ch := byte('?' + row[i])
+		if n := j - i; n > 3 {
+			fmt.Fprintf(s, "!%d%c", n, ch)
+		} else {
+			s.WriteString(strings.Repeat(string(ch), n))
+		}
+		i = j
+	}
+}
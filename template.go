@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// formatPart es un pedazo de un formato: texto tal cual o un {campo}
+type formatPart struct {
+	text  string
+	field string
+}
+
+// formatFields son los campos sueltos que se pueden usar en un formato
+// además de los módulos, ej. "{mem.used}/{mem.total}"
+var formatFields = map[string]func(i sysinfo.SystemInfo) string{
+	"user":     func(i sysinfo.SystemInfo) string { return i.User },
+	"hostname": func(i sysinfo.SystemInfo) string { return i.Host },
+
+	"mem.used":     func(i sysinfo.SystemInfo) string { return sizeUnits.format(i.Memory.Used) },
+	"mem.total":    func(i sysinfo.SystemInfo) string { return sizeUnits.format(i.Memory.Total) },
+	"mem.percent":  func(i sysinfo.SystemInfo) string { return fmt.Sprintf("%.1f", i.Memory.Percent()) },
+	"swap.used":    func(i sysinfo.SystemInfo) string { return sizeUnits.format(i.Swap.Used) },
+	"swap.total":   func(i sysinfo.SystemInfo) string { return sizeUnits.format(i.Swap.Total) },
+	"swap.percent": func(i sysinfo.SystemInfo) string { return fmt.Sprintf("%.1f", i.Swap.Percent()) },
+	"disk.used":    func(i sysinfo.SystemInfo) string { return sizeUnits.format(i.Disk.Used) },
+	"disk.total":   func(i sysinfo.SystemInfo) string { return sizeUnits.format(i.Disk.Total) },
+	"disk.percent": func(i sysinfo.SystemInfo) string { return fmt.Sprintf("%.1f", i.Disk.Percent()) },
+
+	"cpu.model":   func(i sysinfo.SystemInfo) string { return i.CPU.Model },
+	"cpu.cores":   func(i sysinfo.SystemInfo) string { return strconv.Itoa(i.CPU.Cores) },
+	"cpu.threads": func(i sysinfo.SystemInfo) string { return strconv.Itoa(i.CPU.Threads) },
+
+	"uptime.seconds": func(i sysinfo.SystemInfo) string { return strconv.FormatInt(i.Uptime, 10) },
+	"load.1":         func(i sysinfo.SystemInfo) string { return fmt.Sprintf("%.2f", i.Load.One) },
+	"load.5":         func(i sysinfo.SystemInfo) string { return fmt.Sprintf("%.2f", i.Load.Five) },
+	"load.15":        func(i sysinfo.SystemInfo) string { return fmt.Sprintf("%.2f", i.Load.Fifteen) },
+	"procs.total":    func(i sysinfo.SystemInfo) string { return strconv.Itoa(i.Processes.Total) },
+	"procs.running":  func(i sysinfo.SystemInfo) string { return strconv.Itoa(i.Processes.Running) },
+}
+
+// parseFormat separa un formato en texto y campos. "{{" y "}}" son llaves
+// literales. Los campos válidos son:
+//
+//	{os}, {mem}...      el valor de un módulo, como en la salida normal
+//	{mem.used}...       un campo de formatFields
+//	{label:os}          la etiqueta del módulo (renombrada o traducida)
+//	{color:os}          el color del módulo o sección, o uno como en [colors]
+//	{reset}             vuelve al color normal
+func parseFormat(format string) ([]formatPart, error) {
+	var parts []formatPart
+	var text strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(format) && format[i+1] == c:
+			text.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(format[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed { at position %d", i+1)
+			}
+			field := format[i+1 : i+end]
+			if err := checkFormatField(field); err != nil {
+				return nil, err
+			}
+			if text.Len() > 0 {
+				parts = append(parts, formatPart{text: text.String()})
+				text.Reset()
+			}
+			parts = append(parts, formatPart{field: field})
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("unexpected } at position %d (use }} for a literal brace)", i+1)
+		default:
+			text.WriteByte(c)
+		}
+	}
+	if text.Len() > 0 {
+		parts = append(parts, formatPart{text: text.String()})
+	}
+	return parts, nil
+}
+
+// checkFormatField revisa que el campo exista, para avisar de un typo al
+// leer el config y no mostrar un hueco
+func checkFormatField(field string) error {
+	if field == "reset" {
+		return nil
+	}
+	if _, ok := formatFields[field]; ok {
+		return nil
+	}
+	if _, ok := modules[field]; ok {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(field, "label:"); ok {
+		if _, known := modules[name]; known {
+			return nil
+		}
+	}
+	if spec, ok := strings.CutPrefix(field, "color:"); ok {
+		if _, known := modules[spec]; known || isColorSection(spec) {
+			return nil
+		}
+		if _, known := colorCode(spec, true); known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown field {%s}", field)
+}
+
+// expandFormat arma las líneas de salida a partir del formato ya validado
+func expandFormat(parts []formatPart, info sysinfo.SystemInfo, cfg config) []string {
+	var b strings.Builder
+	for _, p := range parts {
+		if p.field == "" {
+			b.WriteString(p.text)
+			continue
+		}
+		b.WriteString(formatValue(p.field, info, cfg))
+	}
+	return strings.Split(b.String(), "\n")
+}
+
+// formatValue resuelve un campo del formato
+func formatValue(field string, info sysinfo.SystemInfo, cfg config) string {
+	if field == "reset" {
+		return cfg.reset()
+	}
+	if f, ok := formatFields[field]; ok {
+		return f(info)
+	}
+	if mod, ok := modules[field]; ok {
+		var values []string
+		for _, e := range mod.entries(field, info, cfg) {
+			if mod.Pairs != nil {
+				values = append(values, e.label+": "+e.value)
+			} else {
+				values = append(values, e.value)
+			}
+		}
+		return strings.Join(values, ", ")
+	}
+	if name, ok := strings.CutPrefix(field, "label:"); ok {
+		return cfg.label(name)
+	}
+	spec, _ := strings.CutPrefix(field, "color:")
+	if mod, ok := modules[spec]; ok {
+		return cfg.color(spec, mod.Section)
+	}
+	if isColorSection(spec) {
+		return cfg.color("", spec)
+	}
+	if !cfg.Color {
+		return ""
+	}
+	code, _ := colorCode(spec, cfg.TrueColor)
+	return code
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// timingReport junta lo que tardó cada colector y cada plugin o [[custom]]
+// para --timings
+type timingReport struct {
+	collect time.Duration    // lo que tardó toda la recolección
+	items   []sysinfo.Timing // colectores, en el orden en que terminaron
+	custom  map[string]sysinfo.Timing
+}
+
+func newTimingReport() *timingReport {
+	return &timingReport{custom: map[string]sysinfo.Timing{}}
+}
+
+// add es el Options.Timings de la recolección
+func (r *timingReport) add(t sysinfo.Timing) {
+	r.items = append(r.items, t)
+}
+
+// timeCustomModules envuelve los plugins y los [[custom]] para medirlos.
+// A diferencia de los colectores corren uno tras otro al armar la salida,
+// así que lo que tardan se suma entero
+func (r *timingReport) timeCustomModules() {
+	for name, m := range modules {
+		if m.Section != "custom" {
+			continue
+		}
+		name, pairs, lines := name, m.Pairs, m.Lines
+		if pairs != nil {
+			m.Pairs = func(info sysinfo.SystemInfo) []labeledValue {
+				start := time.Now()
+				out := pairs(info)
+				r.custom[name] = sysinfo.Timing{Name: name, Duration: time.Since(start), Status: customStatus(len(out))}
+				return out
+			}
+		}
+		if lines != nil {
+			m.Lines = func(info sysinfo.SystemInfo) []string {
+				start := time.Now()
+				out := lines(info)
+				r.custom[name] = sysinfo.Timing{Name: name, Duration: time.Since(start), Status: customStatus(len(out))}
+				return out
+			}
+		}
+		modules[name] = m
+	}
+}
+
+// customStatus es el estado de un plugin o comando: sin salida es que
+// falló, se pasó del timeout o no tenía nada que mostrar
+func customStatus(lines int) string {
+	if lines == 0 {
+		return sysinfo.TimingNoData
+	}
+	return sysinfo.TimingOK
+}
+
+// print escribe el reporte: primero los colectores, que corren en
+// paralelo, y después los plugins, cada grupo del más lento al más rápido
+func (r *timingReport) print(w io.Writer) {
+	fmt.Fprintf(w, "collectors (in parallel, %s total):\n", formatTiming(r.collect))
+	printTimings(w, r.items)
+	if len(r.custom) > 0 {
+		var custom []sysinfo.Timing
+		var total time.Duration
+		for _, t := range r.custom {
+			custom = append(custom, t)
+			total += t.Duration
+		}
+		fmt.Fprintf(w, "plugins and custom commands (one after another, %s total):\n", formatTiming(total))
+		printTimings(w, custom)
+	}
+}
+
+// printTimings escribe una línea por colector, del más lento al más rápido,
+// con las fuentes que le fallaron debajo
+func printTimings(w io.Writer, items []sysinfo.Timing) {
+	sort.SliceStable(items, func(a, b int) bool { return items[a].Duration > items[b].Duration })
+	width := 0
+	for _, t := range items {
+		if len(t.Name) > width {
+			width = len(t.Name)
+		}
+	}
+	for _, t := range items {
+		d := formatTiming(t.Duration)
+		if n := visibleLen(d); n < 8 {
+			d = strings.Repeat(" ", 8-n) + d
+		}
+		line := fmt.Sprintf("  %-*s  %s", width, t.Name, d)
+		if t.Status != sysinfo.TimingOK {
+			line += "  " + t.Status
+		}
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+		for _, err := range t.Errors {
+			fmt.Fprintln(w, "      failed:", err)
+		}
+	}
+}
+
+// formatTiming redondea a microsegundos ("85µs"), a décimas de
+// milisegundo ("812.4ms") o a milisegundos si pasa del segundo ("3.001s")
+func formatTiming(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "-"
+	case d < time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	case d < time.Second:
+		return d.Round(100 * time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
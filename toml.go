@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML interpreta el subconjunto de TOML que usa el config de cafetch:
+// pares clave = valor (strings, números, booleanos y arrays), tablas [tabla]
+// y arrays de tablas [[tabla]]. Devuelve un mapa donde cada tabla es un
+// map[string]any y cada array de tablas es un []map[string]any
+func parseTOML(src string) (map[string]any, error) {
+	root := map[string]any{}
+	current := root
+
+	lines := strings.Split(src, "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+
+		// Array de tablas: [[nombre]]
+		if strings.HasPrefix(line, "[[") {
+			if !strings.HasSuffix(line, "]]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", lineNo)
+			}
+			name := strings.TrimSpace(line[2 : len(line)-2])
+			table := map[string]any{}
+			list, _ := root[name].([]map[string]any)
+			if _, isTable := root[name].(map[string]any); isTable {
+				return nil, fmt.Errorf("line %d: %q is already a table", lineNo, name)
+			}
+			root[name] = append(list, table)
+			current = table
+			continue
+		}
+
+		// Tabla: [nombre]
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", lineNo)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			table, ok := root[name].(map[string]any)
+			if !ok {
+				if _, exists := root[name]; exists {
+					return nil, fmt.Errorf("line %d: %q is already defined", lineNo, name)
+				}
+				table = map[string]any{}
+				root[name] = table
+			}
+			current = table
+			continue
+		}
+
+		// Par clave = valor
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		raw = strings.TrimSpace(raw)
+
+		// Los arrays pueden ocupar varias líneas
+		if strings.HasPrefix(raw, "[") {
+			for !arrayClosed(raw) && i+1 < len(lines) {
+				i++
+				raw += " " + strings.TrimSpace(stripComment(lines[i]))
+			}
+		}
+
+		val, err := parseTOMLValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		current[key] = val
+	}
+	return root, nil
+}
+
+// stripComment quita un comentario # que no esté dentro de un string
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// arrayClosed indica si los corchetes del array ya están balanceados
+func arrayClosed(raw string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// parseTOMLValue convierte el texto de un valor a string, int64, float64, bool o []any
+func parseTOMLValue(raw string) (any, error) {
+	switch {
+	case raw == "":
+		return nil, fmt.Errorf("missing value")
+	case strings.HasPrefix(raw, `"`):
+		if len(raw) < 2 || !strings.HasSuffix(raw, `"`) {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		return strconv.Unquote(raw)
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	case strings.HasPrefix(raw, "["):
+		if !strings.HasSuffix(raw, "]") {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		return parseTOMLArray(raw[1 : len(raw)-1])
+	case raw == "true":
+		return true, nil
+	case raw == "false":
+		return false, nil
+	}
+
+	num := strings.ReplaceAll(raw, "_", "")
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(num, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("invalid value %s", raw)
+}
+
+// parseTOMLArray separa los elementos de un array respetando strings y arrays anidados
+func parseTOMLArray(body string) ([]any, error) {
+	var items []any
+	var quote byte
+	depth, start := 0, 0
+
+	flush := func(end int) error {
+		item := strings.TrimSpace(body[start:end])
+		start = end + 1
+		if item == "" {
+			return nil
+		}
+		val, err := parseTOMLValue(item)
+		if err != nil {
+			return err
+		}
+		items = append(items, val)
+		return nil
+	}
+
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		case ch == ',' && depth == 0:
+			if err := flush(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(len(body)); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// Secuencias ANSI propias de la vista interactiva
+const (
+	wrapOff = "\033[?7l"
+	wrapOn  = "\033[?7h"
+	reverse = "\033[7m"
+	dim     = "\033[2m"
+)
+
+// tuiHelp es la línea de ayuda al pie de la vista interactiva
+const tuiHelp = "↑↓ move  enter expand  space hide/show  r refresh  q quit"
+
+// tuiSections son los títulos de las secciones que agrupan a los módulos
+var tuiSections = map[string]string{
+	"system":   "System",
+	"hardware": "Hardware",
+	"network":  "Network",
+	"desktop":  "Desktop",
+	"custom":   "Custom",
+}
+
+// tui es el estado de la vista interactiva
+type tui struct {
+	info sysinfo.SystemInfo
+	cfg  config
+
+	names    []string        // módulos navegables, en el orden del config
+	cursor   int             // índice en names del módulo seleccionado
+	offset   int             // primera fila visible
+	expanded map[string]bool // módulos con los detalles abiertos
+	hidden   map[string]bool // módulos ocultos (empiezan ocultos los de disable)
+	custom   *customCache    // salida de los plugins y [[custom]] del último refresco
+}
+
+// tuiRow es una fila ya dibujada. item es el índice en names del módulo al
+// que pertenece, -1 en los títulos de sección
+type tuiRow struct {
+	item int
+	text string
+}
+
+// newTUI arma el estado inicial con los módulos del config. Los
+// desactivados aparecen ocultos para poder mostrarlos con espacio
+func newTUI(info sysinfo.SystemInfo, cfg config) *tui {
+	t := &tui{info: info, cfg: cfg, expanded: map[string]bool{}, hidden: map[string]bool{}, custom: cacheCustomModules()}
+	seen := map[string]bool{}
+	for _, name := range cfg.Modules {
+		// title y version van en el encabezado
+		if _, ok := modules[name]; !ok || seen[name] || name == "title" || name == "version" {
+			continue
+		}
+		seen[name] = true
+		t.names = append(t.names, name)
+	}
+	for _, d := range cfg.Disable {
+		t.hidden[strings.ToLower(d)] = true
+	}
+	return t
+}
+
+// runTUI muestra la vista interactiva hasta que se presiona q. Los datos
+// dinámicos se actualizan cada interval
+func runTUI(info sysinfo.SystemInfo, cfg config, interval time.Duration) error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return errors.New("--tui needs a terminal")
+	}
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	// Las teclas se leen aparte. Una secuencia de escape puede llegar
+	// partida en dos lecturas: si una termina a mitad de una se espera un
+	// poco al resto antes de tomar el ESC como tecla (que sale)
+	reads := make(chan string)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(reads)
+				return
+			}
+			reads <- string(buf[:n])
+		}
+	}()
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		pending := ""
+		for {
+			s, ok := <-reads
+			if !ok {
+				return
+			}
+			pending += s
+		wait:
+			for incompleteEscape(pending) {
+				select {
+				case s, ok := <-reads:
+					if !ok {
+						return
+					}
+					pending += s
+				case <-time.After(escapeWait):
+					break wait
+				}
+			}
+			for _, key := range splitKeys(pending) {
+				keys <- key
+			}
+			pending = ""
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	os.Stdout.WriteString(altScreenOn + hideCursor + wrapOff)
+	defer os.Stdout.WriteString(wrapOn + showCursor + altScreenOff)
+
+	t := newTUI(info, cfg)
+	for {
+		t.draw()
+
+		select {
+		case <-sig:
+			return nil
+		case <-ticker.C:
+			t.refresh()
+		case key, ok := <-keys:
+			if !ok || !t.handleKey(key) {
+				return nil
+			}
+			if key == "r" {
+				t.refresh()
+			}
+		}
+	}
+}
+
+// escapeWait es lo que se espera al resto de una secuencia de escape antes
+// de tomar el ESC solo como una tecla
+const escapeWait = 50 * time.Millisecond
+
+// incompleteEscape indica si s termina a mitad de una secuencia de escape:
+// un ESC solo, "\x1b[" sin su byte final o "\x1bO" sin la tecla
+func incompleteEscape(s string) bool {
+	i := strings.LastIndexByte(s, '\x1b')
+	if i < 0 {
+		return false
+	}
+	rest := s[i:]
+	switch {
+	case len(rest) == 1:
+		return true
+	case rest[1] == 'O':
+		return len(rest) == 2
+	case rest[1] == '[':
+		for _, c := range []byte(rest[2:]) {
+			if c >= '@' && c <= '~' {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// splitKeys separa una lectura de la terminal en teclas: una secuencia de
+// escape (las flechas mandan "\x1b[A") o un carácter
+func splitKeys(s string) []string {
+	var keys []string
+	for len(s) > 0 {
+		n := 1
+		if s[0] == '\x1b' && len(s) > 2 && (s[1] == '[' || s[1] == 'O') {
+			// La secuencia termina en el primer byte entre '@' y '~'
+			n = 2
+			for n < len(s) && (s[n] < '@' || s[n] > '~') {
+				n++
+			}
+			if n < len(s) {
+				n++
+			}
+		} else if _, size := utf8.DecodeRuneInString(s); size > 1 {
+			n = size
+		}
+		keys = append(keys, s[:n])
+		s = s[n:]
+	}
+	return keys
+}
+
+// refresh vuelve a leer los datos dinámicos y a correr los plugins y los
+// [[custom]] (en el próximo dibujo)
+func (t *tui) refresh() {
+	t.custom.reset()
+	sysinfo.Refresh(context.Background(), &t.info, t.cfg.shownOptions())
+	if t.cfg.Anonymize {
+		anonymize(&t.info, t.cfg.Redactor)
+	}
+}
+
+// handleKey aplica una tecla. Devuelve false para salir
+func (t *tui) handleKey(key string) bool {
+	if len(t.names) == 0 {
+		return key != "q" && key != "\x1b" && key != "\x03"
+	}
+	name := t.names[t.cursor]
+	switch key {
+	case "q", "\x1b", "\x03":
+		return false
+	case "\x1b[A", "\x1bOA", "k":
+		t.move(-1)
+	case "\x1b[B", "\x1bOB", "j":
+		t.move(1)
+	case "\x1b[5~":
+		t.move(-t.height() / 2)
+	case "\x1b[6~":
+		t.move(t.height() / 2)
+	case "\x1b[H", "\x1bOH", "g":
+		t.cursor = 0
+	case "\x1b[F", "\x1bOF", "G":
+		t.cursor = len(t.names) - 1
+	case "\r", "\n":
+		t.expanded[name] = !t.expanded[name]
+	case "\x1b[C", "\x1bOC", "l":
+		t.expanded[name] = true
+	case "\x1b[D", "\x1bOD", "h":
+		t.expanded[name] = false
+	case " ", "x":
+		t.hidden[name] = !t.hidden[name]
+	}
+	return true
+}
+
+// move mueve la selección n módulos, sin pasarse de los extremos
+func (t *tui) move(n int) {
+	t.cursor += n
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	if t.cursor >= len(t.names) {
+		t.cursor = len(t.names) - 1
+	}
+}
+
+// height devuelve las filas disponibles para los módulos: la terminal menos
+// el encabezado y la ayuda
+func (t *tui) height() int {
+	rows := 24
+	if ws, ok := termWinsize(); ok && ws.Row > 0 {
+		rows = int(ws.Row)
+	}
+	if rows < 4 {
+		return 1
+	}
+	return rows - 3
+}
+
+// rows arma todas las filas de la vista, con la sección de cada módulo
+// como título y los detalles de los módulos expandidos debajo
+func (t *tui) rows() []tuiRow {
+	bold, reset := "", t.cfg.reset()
+	if t.cfg.Color {
+		bold = "\033[1m"
+	}
+
+	// Las etiquetas se alinean todas juntas
+	width := 0
+	for _, name := range t.names {
+		if n := utf8.RuneCountInString(t.label(name)); n > width {
+			width = n
+		}
+	}
+
+	closed, open := "▸", "▾"
+	if !t.cfg.Unicode {
+		closed, open = "+", "-"
+	}
+
+	var rows []tuiRow
+	section := ""
+	for n, name := range t.names {
+		mod := modules[name]
+		if mod.Section != section {
+			section = mod.Section
+			if title, ok := tuiSections[section]; ok {
+				if len(rows) > 0 {
+					rows = append(rows, tuiRow{item: -1})
+				}
+				rows = append(rows, tuiRow{item: -1, text: bold + title + reset})
+			}
+		}
+
+		// Marcador de los módulos con detalles
+		marker := " "
+		if mod.Details != nil {
+			marker = closed
+			if t.expanded[name] {
+				marker = open
+			}
+		}
+
+		label := t.label(name)
+		pad := strings.Repeat(" ", width-utf8.RuneCountInString(label))
+		color := t.cfg.color(name, mod.Section)
+		prefix := " " + marker + " " + color + label + reset + pad + "  "
+		if t.hidden[name] {
+			rows = append(rows, tuiRow{item: n, text: prefix + t.faint("(hidden)")})
+			continue
+		}
+
+		entries := mod.entries(name, t.info, t.cfg)
+		if len(entries) == 0 {
+			rows = append(rows, tuiRow{item: n, text: prefix + t.faint("-")})
+		}
+		indent := strings.Repeat(" ", width+5)
+		for i, e := range entries {
+			value := e.value
+			// Los plugins traen sus propias etiquetas
+			if e.label != label && mod.Pairs != nil {
+				value = e.label + ": " + value
+			}
+			if i == 0 {
+				rows = append(rows, tuiRow{item: n, text: prefix + value})
+			} else {
+				rows = append(rows, tuiRow{item: n, text: indent + value})
+			}
+		}
+
+		if t.expanded[name] && mod.Details != nil {
+			for _, d := range mod.Details(t.info) {
+				rows = append(rows, tuiRow{item: n, text: indent + t.faint(d)})
+			}
+		}
+	}
+	return rows
+}
+
+// label devuelve la etiqueta del módulo, o su nombre si no tiene
+func (t *tui) label(name string) string {
+	if l := t.cfg.label(name); l != "" {
+		return l
+	}
+	return name
+}
+
+// faint atenúa s si hay colores
+func (t *tui) faint(s string) string {
+	if !t.cfg.Color {
+		return s
+	}
+	return dim + s + t.cfg.reset()
+}
+
+// draw dibuja la vista completa en su lugar. Se desplaza lo justo para que
+// el módulo seleccionado quede a la vista
+func (t *tui) draw() {
+	rows := t.rows()
+	height := t.height()
+
+	// Filas del módulo seleccionado
+	first, last := -1, -1
+	for i, r := range rows {
+		if r.item != t.cursor {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	// El título de la sección también se muestra si está justo arriba
+	if first > 0 && rows[first-1].item < 0 && rows[first-1].text != "" {
+		first--
+	}
+	if last-first >= height {
+		last = first + height - 1
+	}
+	if first < t.offset {
+		t.offset = first
+	}
+	if last >= t.offset+height {
+		t.offset = last - height + 1
+	}
+	if limit := len(rows) - height; t.offset > limit {
+		t.offset = limit
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	title := modules["title"].Value(t.info)
+	b.WriteString(" " + t.cfg.color("title", "title") + title + t.cfg.reset() + clearLine + "\n" + clearLine + "\n")
+	for i := t.offset; i < t.offset+height; i++ {
+		if i < len(rows) {
+			text := rows[i].text
+			if rows[i].item == t.cursor {
+				if t.cfg.Color {
+					text = reverse + stripANSI(text) + t.cfg.reset()
+				} else {
+					text = ">" + strings.TrimPrefix(text, " ")
+				}
+			}
+			b.WriteString(text)
+		}
+		b.WriteString(clearLine + "\n")
+	}
+	help := tuiHelp
+	if !t.cfg.Unicode {
+		help = strings.Replace(help, "↑↓", "j/k", 1)
+	}
+	b.WriteString(t.faint(" "+help) + clearLine + clearBelow)
+	os.Stdout.WriteString(b.String())
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/c4feina/cafetch/pkg/sysinfo"
+)
+
+// units elige cómo se escriben los tamaños en bytes. El valor se escala a
+// la mayor unidad en la que queda >= 1 (KiB, MiB, GiB, TiB...)
+type units struct {
+	Decimal   bool // potencias de 1000 (kB, MB, GB) en vez de 1024 (KiB, MiB, GiB)
+	Precision int  // decimales como máximo; los ceros al final no se escriben
+}
+
+// Sufijos de cada potencia, de bytes para arriba
+var (
+	binarySuffixes  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	decimalSuffixes = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+// sizeUnits es el formato de todos los tamaños de la salida. main lo
+// configura desde el config antes de imprimir
+var sizeUnits = units{Precision: 1}
+
+// format escribe b bytes en la unidad que corresponda, ej. "931.5GiB"
+func (u units) format(b uint64) string {
+	base, suffixes := 1024.0, binarySuffixes
+	if u.Decimal {
+		base, suffixes = 1000, decimalSuffixes
+	}
+	v, i := float64(b), 0
+	for v >= base && i < len(suffixes)-1 {
+		v /= base
+		i++
+	}
+	if i == 0 {
+		return strconv.FormatUint(b, 10) + suffixes[0]
+	}
+	s := strconv.FormatFloat(v, 'f', u.Precision, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s + suffixes[i]
+}
+
+// formatUsage arma la línea de uso de un recurso, ej. "3.2GiB / 15.5GiB (20.6%)"
+func formatUsage(u sysinfo.Usage) string {
+	return fmt.Sprintf("%s / %s (%.1f%%)", sizeUnits.format(u.Used), sizeUnits.format(u.Total), u.Percent())
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// releasesURL es la API de la última release. Es una variable para que un
+// fork pueda apuntar a la suya con -ldflags "-X main.releasesURL=..."
+var releasesURL = "https://api.github.com/repos/c4feina/cafetch/releases/latest"
+
+// updateKey es la clave pública ed25519 (en base64) con la que se firma
+// checksums.txt. Se fija al compilar; sin ella cafetch update no instala
+// nada, porque el SHA-256 solo no prueba de dónde vino el binario
+var updateKey = ""
+
+// downloadMax es lo más que se baja de un archivo de la release. Un binario
+// de cafetch pesa unos pocos MB; más que esto es una respuesta rota
+const downloadMax = 64 << 20
+
+// updateTimeout es lo máximo que se espera a toda la actualización,
+// incluida la descarga del binario
+const updateTimeout = 2 * time.Minute
+
+// release es lo que se usa de la respuesta de la API de GitHub
+type release struct {
+	Tag    string `json:"tag_name"`
+	Assets []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// asset devuelve la URL del archivo name de la release, "" si no está
+func (r release) asset(name string) string {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a.URL
+		}
+	}
+	return ""
+}
+
+// runUpdate busca la última release y, si es más nueva, reemplaza el
+// binario en ejecución. Cada release trae un binario por plataforma
+// (cafetch-linux-amd64, cafetch-windows-amd64.exe...) y un checksums.txt
+// en el formato de sha256sum, firmado en checksums.txt.sig
+func runUpdate(args []string) {
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	check := fs.Bool("check", false, "only check whether there is a newer release")
+	force := fs.Bool("force", false, "install the latest release even if it isn't newer")
+	fs.Parse(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+
+	current := currentBuild().Version
+	rel, err := latestRelease(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: update:", err)
+		os.Exit(1)
+	}
+	latest := strings.TrimPrefix(rel.Tag, "v")
+	// Un build de desarrollo no tiene versión con la que comparar: no se
+	// lo pisa salvo con --force
+	if current == "dev" && !*force {
+		fmt.Printf("cafetch %s is the latest release (this is a development build, --force installs it)\n", latest)
+		return
+	}
+	if current != "dev" && compareVersions(latest, current) <= 0 && !*force {
+		fmt.Printf("cafetch %s is up to date\n", current)
+		return
+	}
+	if *check {
+		fmt.Printf("cafetch %s is available (installed: %s)\n", latest, current)
+		return
+	}
+
+	if err := installRelease(ctx, rel); err != nil {
+		fmt.Fprintln(os.Stderr, "cafetch: update:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("cafetch updated: %s -> %s\n", current, latest)
+}
+
+// latestRelease consulta la API de releases
+func latestRelease(ctx context.Context) (release, error) {
+	var rel release
+	body, err := download(ctx, releasesURL)
+	if err != nil {
+		return rel, err
+	}
+	if err := json.Unmarshal(body, &rel); err != nil {
+		return rel, fmt.Errorf("invalid release info: %w", err)
+	}
+	if rel.Tag == "" {
+		return rel, errors.New("the release has no tag")
+	}
+	return rel, nil
+}
+
+// installRelease baja el binario de esta plataforma, lo verifica contra
+// checksums.txt firmado con updateKey y lo pone en lugar del actual
+func installRelease(ctx context.Context, rel release) error {
+	if updateKey == "" {
+		return errors.New("this build has no update key to verify the release signature; download it from the releases page or use your package manager")
+	}
+	name := "cafetch-" + runtime.GOOS + "-" + runtime.GOARCH
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	binURL, sumsURL := rel.asset(name), rel.asset("checksums.txt")
+	if binURL == "" {
+		return fmt.Errorf("release %s has no binary for %s/%s", rel.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	if sumsURL == "" {
+		return fmt.Errorf("release %s has no checksums.txt", rel.Tag)
+	}
+
+	sums, err := download(ctx, sumsURL)
+	if err != nil {
+		return err
+	}
+	sigURL := rel.asset("checksums.txt.sig")
+	if sigURL == "" {
+		return fmt.Errorf("release %s has no checksums.txt.sig", rel.Tag)
+	}
+	sig, err := download(ctx, sigURL)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(sums, sig); err != nil {
+		return err
+	}
+	want, ok := checksumFor(sums, name)
+	if !ok {
+		return fmt.Errorf("checksums.txt has no entry for %s", name)
+	}
+
+	bin, err := download(ctx, binURL)
+	if err != nil {
+		return err
+	}
+	if got := sha256.Sum256(bin); hex.EncodeToString(got[:]) != want {
+		return fmt.Errorf("%s: checksum mismatch, not installing it", name)
+	}
+	return replaceExecutable(bin)
+}
+
+// download hace un GET y devuelve el cuerpo, con error si no es un 200
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "cafetch/"+currentBuild().Version)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	// Se lee un byte de más para saber si se pasó del límite
+	body, err := io.ReadAll(io.LimitReader(resp.Body, downloadMax+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > downloadMax {
+		return nil, fmt.Errorf("%s: bigger than %d MB", url, downloadMax>>20)
+	}
+	return body, nil
+}
+
+// checksumFor busca el SHA-256 de name en un archivo de sha256sum:
+// "<hash>  <archivo>" por línea (con "*" antes del nombre en modo binario)
+func checksumFor(sums []byte, name string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		f := strings.Fields(scanner.Text())
+		if len(f) == 2 && strings.TrimPrefix(f[1], "*") == name {
+			return strings.ToLower(f[0]), true
+		}
+	}
+	return "", false
+}
+
+// verifySignature comprueba la firma ed25519 de checksums.txt, que viene
+// en base64 o en los 64 bytes crudos
+func verifySignature(sums, sig []byte) error {
+	key, err := base64.StdEncoding.DecodeString(updateKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("invalid update key in this build")
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig))); err == nil {
+		sig = decoded
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), sums, sig) {
+		return errors.New("checksums.txt: invalid signature, not installing the update")
+	}
+	return nil
+}
+
+// replaceExecutable escribe bin junto al ejecutable actual y lo pone en su
+// lugar con un rename, así nunca queda un binario a medio escribir. Windows
+// no deja reemplazar un .exe en uso pero sí renombrarlo, así que el viejo
+// queda como .old hasta la próxima actualización
+func replaceExecutable(bin []byte) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if real, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = real
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".cafetch-update-*")
+	if err != nil {
+		return fmt.Errorf("can't write next to %s (try with sudo): %w", exe, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bin); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return err
+		}
+		// Si el nuevo no se pudo poner se devuelve el viejo a su lugar, para
+		// no dejar al usuario sin cafetch.exe
+		if err := os.Rename(tmp.Name(), exe); err != nil {
+			os.Rename(old, exe)
+			return err
+		}
+		return nil
+	}
+	return os.Rename(tmp.Name(), exe)
+}
+
+// compareVersions compara dos versiones "1.4.0" número por número: -1, 0
+// o 1. Lo que va después de un "-" (ej. "1.5.0-rc1") no se tiene en cuenta
+func compareVersions(a, b string) int {
+	pa := strings.Split(strings.SplitN(a, "-", 2)[0], ".")
+	pb := strings.Split(strings.SplitN(b, "-", 2)[0], ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Datos del build. Se pueden fijar al compilar:
+//
+//	go build -ldflags "-X main.version=1.4.0 -X main.commit=$(git rev-parse --short HEAD) -X main.buildDate=$(date -u +%Y-%m-%d)"
+//
+// Los que quedan vacíos salen de la info que Go guarda en el binario
+var (
+	version   string
+	commit    string
+	buildDate string
+)
+
+// buildInfo es la versión instalada de cafetch, para --version y --json
+type buildInfo struct {
+	Version  string `json:"version"`
+	Commit   string `json:"commit,omitempty"`
+	Date     string `json:"build_date,omitempty"`
+	Modified bool   `json:"modified,omitempty"` // compilado con cambios sin commitear
+	Go       string `json:"go"`
+}
+
+// pseudoVersionRe reconoce las pseudo-versiones que Go arma para un commit
+// sin tag, ej. "v0.0.0-20240502101500-3f2a9c1d0b7e" o
+// "v1.4.1-0.20240502101500-3f2a9c1d0b7e" (lo mismo que
+// module.IsPseudoVersion, sin depender de golang.org/x/mod)
+var pseudoVersionRe = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+-(?:[0-9A-Za-z.-]*\.)?[0-9]{14}-[0-9a-f]{12}(?:\+incompatible)?$`)
+
+// currentBuild junta los datos de -ldflags con los de debug.ReadBuildInfo:
+// "go install ...@v1.4.0" deja la versión del módulo y "go build" dentro
+// del repo el commit y su fecha. Desde Go 1.24 "go build" también deja una
+// pseudo-versión (y "+dirty" con cambios sin commitear), que no es una
+// release: en ese caso, y sin ningún dato, la versión es "dev"
+func currentBuild() buildInfo {
+	b := buildInfo{Version: version, Commit: commit, Date: buildDate, Go: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		v := bi.Main.Version
+		if b.Version == "" && v != "(devel)" && !strings.HasSuffix(v, "+dirty") && !pseudoVersionRe.MatchString(v) {
+			b.Version = strings.TrimPrefix(v, "v")
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if b.Commit == "" {
+					b.Commit = s.Value
+					if len(b.Commit) > 12 {
+						b.Commit = b.Commit[:12]
+					}
+				}
+			case "vcs.time":
+				if b.Date == "" {
+					b.Date = s.Value
+				}
+			case "vcs.modified":
+				b.Modified = s.Value == "true"
+			}
+		}
+	}
+	if b.Version == "" {
+		b.Version = "dev"
+	}
+	return b
+}
+
+// String arma la línea de --version, ej. "cafetch 1.4.0 (commit
+// 3f2a9c1d0b7e, built 2024-05-02, go1.22.2)"
+func (b buildInfo) String() string {
+	details := []string{}
+	if b.Commit != "" {
+		c := "commit " + b.Commit
+		if b.Modified {
+			c += "+dirty"
+		}
+		details = append(details, c)
+	}
+	if b.Date != "" {
+		details = append(details, "built "+b.Date)
+	}
+	details = append(details, b.Go)
+	return "cafetch " + b.Version + " (" + strings.Join(details, ", ") + ")"
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// termWinsize pide el tamaño de la terminal al kernel con TIOCGWINSZ. ok es
+// false si la salida no es una terminal
+func termWinsize() (ws winsize, ok bool) {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	return ws, errno == 0
+}
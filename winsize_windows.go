@@ -0,0 +1,32 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"unsafe"
+)
+
+var procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+
+// consoleScreenBufferInfo es CONSOLE_SCREEN_BUFFER_INFO
+type consoleScreenBufferInfo struct {
+	size              [2]int16
+	cursorPosition    [2]int16
+	attributes        uint16
+	window            [4]int16 // left, top, right, bottom
+	maximumWindowSize [2]int16
+}
+
+// termWinsize pide el tamaño de la ventana de la consola. La consola no
+// informa el tamaño de las celdas en píxeles, así que esos quedan en 0 y
+// cellSize usa los valores por defecto
+func termWinsize() (ws winsize, ok bool) {
+	var info consoleScreenBufferInfo
+	if r, _, _ := procGetConsoleScreenBufferInfo.Call(os.Stdout.Fd(), uintptr(unsafe.Pointer(&info))); r == 0 {
+		return ws, false
+	}
+	ws.Col = uint16(info.window[2] - info.window[0] + 1)
+	ws.Row = uint16(info.window[3] - info.window[1] + 1)
+	return ws, true
+}